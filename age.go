@@ -0,0 +1,58 @@
+package gcfg
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ahmedkamalio/gcfg/internal/age"
+)
+
+// ErrAgeIdentityFailed indicates failure to load the age identities decrypting a config file.
+var ErrAgeIdentityFailed = errors.New("failed to load age identity")
+
+// AgeIdentityFile returns a DecryptFunc decrypting age-encrypted files (see
+// https://age-encryption.org), binary or ASCII-armored, with the X25519 identities of the identity
+// file at path, as generated by age-keygen:
+//
+//	gcfg.NewJSONProvider(
+//		gcfg.WithJSONFilePath("config.json.age"),
+//		gcfg.WithJSONDecrypt(gcfg.AgeIdentityFile("/run/secrets/age.key")),
+//	)
+//
+// The identity file is read on every load, so rotated identities are picked up, and outside of the
+// sandbox of the providers (see WithBaseDir). Passphrase-encrypted files and plugin or SSH
+// identities are not supported.
+func AgeIdentityFile(path string) DecryptFunc {
+	return func(ciphertext []byte) ([]byte, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrAgeIdentityFailed, err)
+		}
+
+		return ageDecrypt(ciphertext, data, path)
+	}
+}
+
+// AgeIdentityEnv returns a DecryptFunc decrypting age-encrypted files like AgeIdentityFile, with
+// the identities held by the environment variable name (e.g. "AGE_IDENTITY"), one per line.
+func AgeIdentityEnv(name string) DecryptFunc {
+	return func(ciphertext []byte) ([]byte, error) {
+		data, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: environment variable %s is not set", ErrAgeIdentityFailed, name)
+		}
+
+		return ageDecrypt(ciphertext, []byte(data), "$"+name)
+	}
+}
+
+// ageDecrypt decrypts ciphertext with the identities parsed from identities, read from source.
+func ageDecrypt(ciphertext, identities []byte, source string) ([]byte, error) {
+	parsed, err := age.ParseIdentities(identities)
+	if err != nil {
+		return nil, fmt.Errorf("%w from %s: %w", ErrAgeIdentityFailed, source, err)
+	}
+
+	return age.Decrypt(ciphertext, parsed)
+}
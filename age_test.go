@@ -0,0 +1,65 @@
+package gcfg_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ageIdentity is the identity (X25519 scalar made of 32 bytes 0x01) decrypting ageConfig.
+const ageIdentity = "AGE-SECRET-KEY-1QYQSZQGPQYQSZQGPQYQSZQGPQYQSZQGPQYQSZQGPQYQSZQGPQYQSZ9K4CN"
+
+// ageConfig is {"database": {"password": "s3cr3t"}} encrypted to the recipient of ageIdentity.
+const ageConfig = `-----BEGIN AGE ENCRYPTED FILE-----
+YWdlLWVuY3J5cHRpb24ub3JnL3YxCi0+IFgyNTUxOSB6UmJSdmFXaG9BNS9ObE8x
+c3VFQkxvZFlmaUtSSzZubFN2Y1hIUkhremtRCkZGZjNiWkZ6MUQyWnAzb05adjFo
+Z0xGanB2bmFFWkZnTVE5d2VNeEpmaE0KLS0tIE9oemdjRWZzU1NDN3g3a0FTSGFa
+bGV0bktydUNtMEdoV2tuNTM1eHFDeDgKkTig8+d5TngQ5X2jUyK4KJebVzVdGB/z
+6ObvD15f+/vNxIeXScML4xu5rzOmn0NxHdLmExhhI9IO8WT+1UW53i299fM=
+-----END AGE ENCRYPTED FILE-----
+`
+
+// ageJSONProvider returns a JSON provider loading ageConfig, decrypted with decrypt.
+func ageJSONProvider(decrypt gcfg.DecryptFunc) *gcfg.JSONProvider {
+	return gcfg.NewJSONProvider(
+		gcfg.WithJSONFilePath("config.json.age"),
+		gcfg.WithJSONFileFS(fstest.MapFS{"config.json.age": &fstest.MapFile{Data: []byte(ageConfig)}}),
+		gcfg.WithJSONDecrypt(decrypt),
+	)
+}
+
+func TestAgeIdentityFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "keys.txt")
+	require.NoError(t, os.WriteFile(path, []byte("# created by age-keygen\n"+ageIdentity+"\n"), 0o600))
+
+	values, err := ageJSONProvider(gcfg.AgeIdentityFile(path)).Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"database": map[string]any{"password": "s3cr3t"}}, values)
+
+	_, err = ageJSONProvider(gcfg.AgeIdentityFile(path + ".missing")).Load()
+	require.ErrorIs(t, err, gcfg.ErrFileDecryptFailed)
+	require.ErrorIs(t, err, gcfg.ErrAgeIdentityFailed)
+}
+
+func TestAgeIdentityEnv(t *testing.T) {
+	t.Setenv("GCFG_TEST_AGE_IDENTITY", ageIdentity)
+
+	values, err := ageJSONProvider(gcfg.AgeIdentityEnv("GCFG_TEST_AGE_IDENTITY")).Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"database": map[string]any{"password": "s3cr3t"}}, values)
+
+	_, err = ageJSONProvider(gcfg.AgeIdentityEnv("GCFG_TEST_AGE_IDENTITY_UNSET")).Load()
+	require.ErrorIs(t, err, gcfg.ErrAgeIdentityFailed)
+
+	t.Setenv("GCFG_TEST_AGE_IDENTITY", "AGE-SECRET-KEY-1INVALID")
+
+	_, err = ageJSONProvider(gcfg.AgeIdentityEnv("GCFG_TEST_AGE_IDENTITY")).Load()
+	require.ErrorIs(t, err, gcfg.ErrAgeIdentityFailed)
+}
@@ -0,0 +1,105 @@
+package gcfg
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// Audited operations, see AuditEvent.
+const (
+	AuditOpSet         = "Set"
+	AuditOpSetDefault  = "SetDefault"
+	AuditOpSetDefaults = "SetDefaults"
+	AuditOpDelete      = "Delete"
+	AuditOpMergeFrom   = "MergeFrom"
+)
+
+// AuditEvent describes a runtime mutation of the configuration.
+//
+// Values are not recorded; they are identified by hashes instead, so the audit trail can be
+// stored without leaking secrets while still allowing to verify which value was set.
+type AuditEvent struct {
+	// Time is the time of the mutation.
+	Time time.Time
+	// Operation is the mutating operation, e.g. AuditOpSet.
+	Operation string
+	// Key is the dotted path of the mutated value (e.g., "database.host").
+	Key string
+	// OldValueHash is the hash of the previous value, empty if the key was not set.
+	OldValueHash string
+	// NewValueHash is the hash of the new value, see HashValue. Empty if the key was deleted.
+	NewValueHash string
+	// Caller is the location (file:line) of the code that called the mutating operation.
+	Caller string
+}
+
+// OnAudit registers a hook called for every runtime mutation of the configuration (Set,
+// SetDefault, SetDefaults, Delete, MergeFrom), so regulated environments can reconstruct who
+// changed what at runtime.
+// The hook is called synchronously after the mutation is applied.
+func (c *Config) OnAudit(hook func(event AuditEvent)) *Config {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.auditHook = hook
+
+	return c
+}
+
+// HashValue returns the hash identifying value in audit events, i.e. the hex-encoded SHA-256
+// of its Go-syntax representation.
+func HashValue(value any) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%#v", value)))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// auditEvent returns the audit event for a mutation, nil if no audit hook is registered.
+// It must be called directly by the mutating operation, which determines the reported caller.
+// The caller must hold c.mu.
+func (c *Config) auditEvent(op, key string, oldValue any, hadOld bool, newValue any) *AuditEvent {
+	if c.auditHook == nil {
+		return nil
+	}
+
+	event := &AuditEvent{
+		Time:      c.now(),
+		Operation: op,
+		Key:       c.keys.normalize(key),
+	}
+
+	if op != AuditOpDelete {
+		event.NewValueHash = HashValue(newValue)
+	}
+
+	if hadOld {
+		event.OldValueHash = HashValue(oldValue)
+	}
+
+	// Skip auditEvent and the mutating operation.
+	if _, file, line, ok := runtime.Caller(2); ok {
+		event.Caller = fmt.Sprintf("%s:%d", file, line)
+	}
+
+	return event
+}
+
+// emitAudit calls the audit hook with the given events. It must be called without holding c.mu.
+func (c *Config) emitAudit(events ...*AuditEvent) {
+	c.mu.RLock()
+	hook := c.auditHook
+	c.mu.RUnlock()
+
+	if hook == nil {
+		return
+	}
+
+	for _, event := range events {
+		if event != nil {
+			hook(*event)
+		}
+	}
+}
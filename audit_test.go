@@ -0,0 +1,61 @@
+package gcfg_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_OnAudit(t *testing.T) {
+	t.Parallel()
+
+	var events []gcfg.AuditEvent
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{"host": "localhost"}}).
+		OnAudit(func(event gcfg.AuditEvent) {
+			events = append(events, event)
+		})
+	require.NoError(t, cfg.Load())
+
+	cfg.Set("Host", "db.internal")
+	cfg.SetDefault("port", 5432)
+	cfg.SetDefault("port", 6432) // not applied, not audited
+	require.NoError(t, cfg.SetDefaults(map[string]any{"level": "info"}))
+	cfg.Delete("level")
+	cfg.Delete("level") // nothing to delete, not audited
+
+	require.Len(t, events, 4)
+
+	assert.Equal(t, gcfg.AuditOpSet, events[0].Operation)
+	assert.Equal(t, "host", events[0].Key)
+	assert.Equal(t, gcfg.HashValue("localhost"), events[0].OldValueHash)
+	assert.Equal(t, gcfg.HashValue("db.internal"), events[0].NewValueHash)
+	assert.Contains(t, events[0].Caller, "audit_test.go:")
+	assert.False(t, events[0].Time.IsZero())
+
+	assert.Equal(t, gcfg.AuditOpSetDefault, events[1].Operation)
+	assert.Equal(t, "port", events[1].Key)
+	assert.Empty(t, events[1].OldValueHash)
+	assert.Equal(t, gcfg.HashValue(5432), events[1].NewValueHash)
+	assert.Contains(t, events[1].Caller, "audit_test.go:")
+
+	assert.Equal(t, gcfg.AuditOpSetDefaults, events[2].Operation)
+	assert.Equal(t, "level", events[2].Key)
+	assert.Contains(t, events[2].Caller, "audit_test.go:")
+
+	assert.Equal(t, gcfg.AuditOpDelete, events[3].Operation)
+	assert.Equal(t, "level", events[3].Key)
+	assert.Equal(t, gcfg.HashValue("info"), events[3].OldValueHash)
+	assert.Empty(t, events[3].NewValueHash)
+	assert.Contains(t, events[3].Caller, "audit_test.go:")
+}
+
+func TestHashValue(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, gcfg.HashValue("value"), gcfg.HashValue("value"))
+	assert.NotEqual(t, gcfg.HashValue("5432"), gcfg.HashValue(5432))
+	assert.Len(t, gcfg.HashValue(nil), 64)
+}
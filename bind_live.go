@@ -0,0 +1,76 @@
+package gcfg
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// ErrLiveBindFailed indicates failure to refresh a live binding after a reload.
+var ErrLiveBindFailed = errors.New("failed to refresh live binding")
+
+// BindLive binds the configuration to a new T and returns a pointer to it that is atomically
+// swapped with a freshly bound T after every reload (Load or a watched provider update), so
+// services can read hot-reloadable typed configuration with Load on the returned pointer.
+//
+// Returns an error if the initial binding fails. If binding fails after a reload, the previous
+// value is kept and the error is reported to the handler registered with OnReloadError.
+func BindLive[T any](c *Config, options ...BindOption) (*atomic.Pointer[T], error) {
+	opts := BindOptions{
+		validate: true,
+	}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	live := &atomic.Pointer[T]{}
+
+	bind := func(values map[string]any) ([]deprecatedKey, error) {
+		dest := new(T)
+
+		deprecated, err := c.bindValues("", values, dest, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		live.Store(dest)
+
+		return deprecated, nil
+	}
+
+	c.mu.Lock()
+
+	deprecated, err := bind(c.values)
+	if err != nil {
+		c.mu.Unlock()
+
+		return nil, err
+	}
+
+	c.liveBindings = append(c.liveBindings, func(values map[string]any) error {
+		_, err := bind(values)
+
+		return err
+	})
+
+	c.mu.Unlock()
+
+	c.warnDeprecated(deprecated)
+
+	return live, nil
+}
+
+// rebindLive refreshes the live bindings registered with BindLive and returns the errors of
+// those that failed. The caller must hold c.mu.
+func (c *Config) rebindLive() []error {
+	var errs []error
+
+	for _, bind := range c.liveBindings {
+		if err := bind(c.values); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrLiveBindFailed, err))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,69 @@
+package gcfg_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindLive(t *testing.T) {
+	t.Parallel()
+
+	type DatabaseConfig struct {
+		Host     string `validate:"required"`
+		PoolSize int
+	}
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"host":     "localhost",
+		"poolsize": 10,
+	}}
+
+	var reloadErrs []error
+
+	cfg := gcfg.New(mockP).OnReloadError(func(err error) {
+		reloadErrs = append(reloadErrs, err)
+	})
+	require.NoError(t, cfg.Load())
+
+	live, err := gcfg.BindLive[DatabaseConfig](cfg)
+	require.NoError(t, err)
+
+	first := live.Load()
+	assert.Equal(t, &DatabaseConfig{Host: "localhost", PoolSize: 10}, first)
+
+	mockP.data = map[string]any{"host": "db.internal", "poolsize": 20}
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, &DatabaseConfig{Host: "db.internal", PoolSize: 20}, live.Load())
+	// Previously loaded values are never mutated.
+	assert.Equal(t, &DatabaseConfig{Host: "localhost", PoolSize: 10}, first)
+
+	// Invalid configurations keep the previous value.
+	mockP.data = map[string]any{"host": "", "poolsize": 30}
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, &DatabaseConfig{Host: "db.internal", PoolSize: 20}, live.Load())
+	require.Len(t, reloadErrs, 1)
+	assert.ErrorIs(t, reloadErrs[0], gcfg.ErrLiveBindFailed)
+}
+
+func TestBindLive_InitialBindFails(t *testing.T) {
+	t.Parallel()
+
+	type Config struct {
+		Host string `validate:"required"`
+	}
+
+	cfg := gcfg.New(&mockProvider{name: "mock"})
+	require.NoError(t, cfg.Load())
+
+	_, err := gcfg.BindLive[Config](cfg)
+	require.Error(t, err)
+
+	live, err := gcfg.BindLive[Config](cfg, gcfg.WithValidate(false))
+	require.NoError(t, err)
+	assert.Equal(t, &Config{}, live.Load())
+}
@@ -0,0 +1,79 @@
+package gcfg
+
+import (
+	"time"
+)
+
+// ReloadRecord describes the outcome of a single successful configuration load.
+type ReloadRecord struct {
+	// Time is the time at which the load completed.
+	Time time.Time
+	// Changes lists the keys changed by the load, along with the providers that supplied them.
+	Changes []Change
+}
+
+// changeLog is a fixed-size ring buffer of reload records.
+type changeLog struct {
+	records []ReloadRecord
+	next    int
+	full    bool
+}
+
+func newChangeLog(size int) *changeLog {
+	return &changeLog{
+		records: make([]ReloadRecord, size),
+	}
+}
+
+// add appends a record, evicting the oldest one if the buffer is full.
+func (l *changeLog) add(record ReloadRecord) {
+	l.records[l.next] = record
+
+	l.next = (l.next + 1) % len(l.records)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// list returns the records from oldest to newest.
+func (l *changeLog) list() []ReloadRecord {
+	if !l.full {
+		return append([]ReloadRecord{}, l.records[:l.next]...)
+	}
+
+	out := make([]ReloadRecord, 0, len(l.records))
+	out = append(out, l.records[l.next:]...)
+	out = append(out, l.records[:l.next]...)
+
+	return out
+}
+
+// WithChangeLog enables recording of the diffs and provenance of the last size loads,
+// which can be retrieved with ChangeLog. A size of zero or less disables recording.
+func (c *Config) WithChangeLog(size int) *Config {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if size <= 0 {
+		c.changeLog = nil
+
+		return c
+	}
+
+	c.changeLog = newChangeLog(size)
+
+	return c
+}
+
+// ChangeLog returns the recorded loads from oldest to newest.
+// Returns nil unless recording was enabled with WithChangeLog.
+func (c *Config) ChangeLog() []ReloadRecord {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.changeLog == nil {
+		return nil
+	}
+
+	return c.changeLog.list()
+}
@@ -0,0 +1,100 @@
+package gcfg_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_ChangeLog_Disabled(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{"key": "value"}})
+
+	require.NoError(t, cfg.Load())
+
+	assert.Nil(t, cfg.ChangeLog())
+}
+
+func TestConfig_ChangeLog_RecordsChanges(t *testing.T) {
+	t.Parallel()
+
+	mockP1 := &mockProvider{name: "mock1", data: map[string]any{
+		"database": map[string]any{"host": "localhost", "port": 5432},
+	}}
+	mockP2 := &mockProvider{name: "mock2", data: map[string]any{}}
+
+	cfg := gcfg.New(mockP1, mockP2).WithChangeLog(10)
+
+	require.NoError(t, cfg.Load())
+
+	mockP2.data = map[string]any{
+		"database": map[string]any{"host": "remotehost"},
+		"cache":    map[string]any{"enabled": true},
+	}
+
+	require.NoError(t, cfg.Load())
+
+	records := cfg.ChangeLog()
+	require.Len(t, records, 2)
+
+	assert.False(t, records[1].Time.Before(records[0].Time))
+
+	// Ignore env variables in the first record.
+	var first []gcfg.Change
+
+	for _, change := range records[0].Changes {
+		if change.Provider == "mock1" {
+			first = append(first, change)
+		}
+	}
+
+	assert.Equal(t, []gcfg.Change{
+		{Key: "database.host", Type: gcfg.ChangeAdded, NewValue: "localhost", Provider: "mock1"},
+		{Key: "database.port", Type: gcfg.ChangeAdded, NewValue: 5432, Provider: "mock1"},
+	}, first)
+
+	assert.Equal(t, []gcfg.Change{
+		{Key: "cache.enabled", Type: gcfg.ChangeAdded, NewValue: true, Provider: "mock2"},
+		{
+			Key:      "database.host",
+			Type:     gcfg.ChangeUpdated,
+			OldValue: "localhost",
+			NewValue: "remotehost",
+			Provider: "mock2",
+		},
+	}, records[1].Changes)
+}
+
+func TestConfig_ChangeLog_KeepsLastN(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{}}
+	cfg := gcfg.New(mockP).WithChangeLog(2)
+
+	for _, value := range []string{"a", "b", "c"} {
+		mockP.data = map[string]any{"key": value}
+
+		require.NoError(t, cfg.Load())
+	}
+
+	records := cfg.ChangeLog()
+	require.Len(t, records, 2)
+
+	require.Len(t, records[0].Changes, 1)
+	assert.Equal(t, "b", records[0].Changes[0].NewValue)
+
+	require.Len(t, records[1].Changes, 1)
+	assert.Equal(t, "c", records[1].Changes[0].NewValue)
+}
+
+func TestChangeType_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "added", gcfg.ChangeAdded.String())
+	assert.Equal(t, "updated", gcfg.ChangeUpdated.String())
+	assert.Equal(t, "removed", gcfg.ChangeRemoved.String())
+	assert.Equal(t, "unknown", gcfg.ChangeType(0).String())
+}
@@ -0,0 +1,166 @@
+package gcfg
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/ahmedkamalio/gcfg/internal/reflection"
+)
+
+// ChangeType describes the kind of change applied to a configuration key.
+type ChangeType int
+
+const (
+	// ChangeAdded indicates that a key was added.
+	ChangeAdded ChangeType = iota + 1
+	// ChangeUpdated indicates that the value of an existing key changed.
+	ChangeUpdated
+	// ChangeRemoved indicates that a key was removed.
+	ChangeRemoved
+)
+
+// String implements the fmt.Stringer interface.
+func (t ChangeType) String() string {
+	switch t {
+	case ChangeAdded:
+		return "added"
+	case ChangeUpdated:
+		return "updated"
+	case ChangeRemoved:
+		return "removed"
+	default:
+		return "unknown"
+	}
+}
+
+// Change describes a single key-level difference between two configuration states.
+type Change struct {
+	// Key is the dotted path of the changed leaf value (e.g., "database.host").
+	Key string
+	// Type is the kind of change.
+	Type ChangeType
+	// OldValue is the previous value, nil if the key was added.
+	OldValue any
+	// NewValue is the current value, nil if the key was removed.
+	NewValue any
+	// Provider is the name of the provider that supplied the new value,
+	// or the old value if the key was removed. Empty if unknown.
+	Provider string
+}
+
+// ChangeSet lists the key-level changes applied by a reload, sorted by key.
+type ChangeSet []Change
+
+// Added returns the changes adding a key.
+func (s ChangeSet) Added() []Change {
+	return s.ofType(ChangeAdded)
+}
+
+// Updated returns the changes updating the value of an existing key.
+func (s ChangeSet) Updated() []Change {
+	return s.ofType(ChangeUpdated)
+}
+
+// Removed returns the changes removing a key.
+func (s ChangeSet) Removed() []Change {
+	return s.ofType(ChangeRemoved)
+}
+
+// Keys returns the changed keys.
+func (s ChangeSet) Keys() []string {
+	keys := make([]string, 0, len(s))
+	for _, change := range s {
+		keys = append(keys, change.Key)
+	}
+
+	return keys
+}
+
+func (s ChangeSet) ofType(changeType ChangeType) []Change {
+	var changes []Change
+
+	for _, change := range s {
+		if change.Type == changeType {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+// cloneChanges returns a deep copy of changes.
+func cloneChanges(changes []Change) ChangeSet {
+	cloned := make(ChangeSet, len(changes))
+	for i, change := range changes {
+		change.OldValue = reflection.Clone(change.OldValue)
+		change.NewValue = reflection.Clone(change.NewValue)
+		cloned[i] = change
+	}
+
+	return cloned
+}
+
+// diffValues computes the leaf-level differences between two configuration trees, sorted by key.
+// Keys are joined with sep.
+func diffValues(oldValues, newValues map[string]any, sep string) []Change {
+	oldFlat := maps.Flatten(oldValues, sep)
+	newFlat := maps.Flatten(newValues, sep)
+
+	var changes []Change
+
+	for key, newValue := range newFlat {
+		oldValue, exists := oldFlat[key]
+
+		switch {
+		case !exists:
+			changes = append(changes, Change{
+				Key:      key,
+				Type:     ChangeAdded,
+				NewValue: reflection.Clone(newValue),
+			})
+		case !reflect.DeepEqual(oldValue, newValue):
+			changes = append(changes, Change{
+				Key:      key,
+				Type:     ChangeUpdated,
+				OldValue: reflection.Clone(oldValue),
+				NewValue: reflection.Clone(newValue),
+			})
+		}
+	}
+
+	for key, oldValue := range oldFlat {
+		if _, exists := newFlat[key]; !exists {
+			changes = append(changes, Change{
+				Key:      key,
+				Type:     ChangeRemoved,
+				OldValue: reflection.Clone(oldValue),
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+
+	return changes
+}
+
+// attributedDiff computes the differences between two configuration trees, attributing added and
+// updated values to their origin in newOrigins, and removed values to their origin in oldOrigins.
+func attributedDiff(
+	oldValues, newValues map[string]any,
+	oldOrigins, newOrigins map[string]Origin,
+	sep string,
+) []Change {
+	changes := diffValues(oldValues, newValues, sep)
+	for i := range changes {
+		if changes[i].Type == ChangeRemoved {
+			changes[i].Provider = oldOrigins[changes[i].Key].Provider
+		} else {
+			changes[i].Provider = newOrigins[changes[i].Key].Provider
+		}
+	}
+
+	return changes
+}
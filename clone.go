@@ -0,0 +1,97 @@
+package gcfg
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/ahmedkamalio/gcfg/internal/reflection"
+)
+
+// CloneOptions defines options for cloning a Config.
+type CloneOptions struct {
+	providers bool
+}
+
+// CloneOption is a functional option for configuring Clone behavior by modifying CloneOptions.
+type CloneOption func(*CloneOptions)
+
+// WithCloneProviders sets whether Clone copies the registered providers.
+func WithCloneProviders(providers bool) CloneOption {
+	return func(o *CloneOptions) {
+		o.providers = providers
+	}
+}
+
+// Clone returns an independent deep copy of the configuration values, defaults and overrides,
+// which can be modified without affecting c.
+//
+// The clone shares the validators, clock, logger and metrics sink of c, and its restart-required
+// keys, file access restrictions, secret keys, decode hooks, secret resolvers, schema and watch debounce window. Extensions,
+// components, listeners, live bindings and hooks are not copied. Providers are not copied either unless
+// WithCloneProviders(true) is given, in which case the clone shares the provider instances but keeps its own copy of the values they last loaded;
+// without providers, a Load of the clone only retains its defaults and overrides.
+func (c *Config) Clone(options ...CloneOption) *Config {
+	opts := CloneOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	clone := &Config{
+		values:        reflection.Clone(c.values),
+		defaults:      reflection.Clone(c.defaults),
+		overrides:     reflection.Clone(c.overrides),
+		origins:       maps.Clone(c.origins),
+		providers:     make([]*providerEntry, 0, len(c.providers)),
+		loaded:        c.loaded,
+		loadedAt:      c.loadedAt,
+		rev:           c.rev,
+		restartKeys:   slices.Clone(c.restartKeys),
+		watchDebounce: c.watchDebounce,
+		validate:      c.validate,
+		keys:          c.keys,
+		keySpellings:  maps.Clone(c.keySpellings),
+		clock:         c.clock,
+
+		structValidator:             c.structValidator,
+		withoutDefaultEnv:           c.withoutDefaultEnv,
+		interpolation:               c.interpolation,
+		envExpansion:                c.envExpansion,
+		fileReferences:              c.fileReferences,
+		fileReferencesBaseDir:       c.fileReferencesBaseDir,
+		fileReferencesAllowSymlinks: c.fileReferencesAllowSymlinks,
+		baseDir:                     c.baseDir,
+		allowSymlinks:               c.allowSymlinks,
+		maxFileSize:                 c.maxFileSize,
+		decryptionKey:               c.decryptionKey,
+		sensitiveKeys:               c.sensitiveKeys,
+		schema:                      c.schema,
+		schemaErr:                   c.schemaErr,
+	}
+
+	if opts.providers {
+		for _, p := range c.providers {
+			clone.providers = append(clone.providers, &providerEntry{
+				Provider:   p.Provider,
+				values:     reflection.Clone(p.values),
+				loadedAt:   p.loadedAt,
+				lastErr:    p.lastErr,
+				errCount:   p.errCount,
+				defaultEnv: p.defaultEnv,
+			})
+		}
+	}
+
+	if holder, ok := c.metrics.Load().(metricsSinkHolder); ok {
+		clone.metrics.Store(holder)
+	}
+
+	clone.logger.Store(c.logger.Load())
+	clone.secrets.Store(c.secrets.Load())
+	clone.decodeHooks.Store(c.decodeHooks.Load())
+	clone.secretResolvers.Store(c.secretResolvers.Load())
+
+	return clone
+}
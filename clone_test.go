@@ -0,0 +1,62 @@
+package gcfg_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Clone(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"server": map[string]any{"port": 8080},
+	}})
+	cfg.SetDefault("level", "info")
+	require.NoError(t, cfg.Load())
+	cfg.Set("server.host", "0.0.0.0")
+
+	clone := cfg.Clone()
+
+	assert.Equal(t, cfg.Values(), clone.Values())
+	assert.Equal(t, cfg.Version(), clone.Version())
+	assert.Empty(t, clone.Providers())
+
+	origin, ok := clone.Origin("server.port")
+	require.True(t, ok)
+	assert.Equal(t, "mock", origin.Provider)
+
+	clone.Set("server.port", 9090)
+	clone.SetDefault("timeout", "30s")
+
+	assert.Equal(t, 8080, cfg.Get("server.port"))
+	assert.False(t, cfg.IsSet("timeout"))
+
+	// Without providers, a load only retains the defaults and overrides.
+	require.NoError(t, clone.Load())
+	assert.Equal(t, map[string]any{
+		"level":   "info",
+		"timeout": "30s",
+		"server":  map[string]any{"host": "0.0.0.0", "port": 9090},
+	}, clone.Values())
+}
+
+func TestConfig_Clone_WithProviders(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{"host": "localhost"}}
+
+	cfg := gcfg.New(provider)
+	require.NoError(t, cfg.Load())
+
+	clone := cfg.Clone(gcfg.WithCloneProviders(true))
+	assert.Equal(t, cfg.Providers(), clone.Providers())
+
+	provider.data = map[string]any{"host": "db.internal"}
+	require.NoError(t, clone.Load())
+
+	assert.Equal(t, "db.internal", clone.Get("host"))
+	assert.Equal(t, "localhost", cfg.Get("host"))
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ahmedkamalio/gcfg"
+)
+
+// errUnsupportedFile indicates that a configuration file has an unknown extension.
+var errUnsupportedFile = errors.New("unsupported configuration file (expected .json or .env)")
+
+// loadFlags are the flags common to all commands, defining the providers to load.
+type loadFlags struct {
+	profile      string
+	env          bool
+	envPrefix    string
+	envSeparator string
+}
+
+// register registers the flags on fs.
+func (f *loadFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.profile, "profile", "", "also load the `name` profile variant of each file, e.g. config.name.json")
+	fs.BoolVar(&f.env, "env", false, "load the environment variables after the files")
+	fs.StringVar(&f.envPrefix, "env-prefix", "", "only load the environment variables with this `prefix` (implies -env)")
+	fs.StringVar(&f.envSeparator, "env-separator", "__", "`separator` of the keys of nested environment variables")
+}
+
+// providers returns the providers loading files, followed by their profile variants and the
+// environment variables, as requested by the flags.
+func (f *loadFlags) providers(files []string) ([]gcfg.Provider, error) {
+	providers := make([]gcfg.Provider, 0, 2*len(files)+1)
+
+	for _, file := range files {
+		p, err := fileProvider(file)
+		if err != nil {
+			return nil, err
+		}
+
+		providers = append(providers, p)
+
+		if f.profile == "" {
+			continue
+		}
+
+		ext := filepath.Ext(file)
+		variant := strings.TrimSuffix(file, ext) + "." + f.profile + ext
+
+		if _, err = os.Stat(variant); err == nil {
+			if p, err = fileProvider(variant); err != nil {
+				return nil, err
+			}
+
+			providers = append(providers, p)
+		}
+	}
+
+	if f.env || f.envPrefix != "" {
+		providers = append(providers, gcfg.NewEnvProvider(
+			gcfg.WithEnvPrefix(f.envPrefix),
+			gcfg.WithEnvSeparator(f.envSeparator),
+		))
+	}
+
+	return providers, nil
+}
+
+// load loads files into a new Config, as requested by the flags.
+func (f *loadFlags) load(files []string, options ...gcfg.Option) (*gcfg.Config, error) {
+	providers, err := f.providers(files)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]gcfg.Provider, 0, len(providers)+len(options)+1)
+	args = append(args, providers...)
+	args = append(args, gcfg.WithoutDefaultEnv())
+
+	for _, opt := range options {
+		args = append(args, opt)
+	}
+
+	cfg := gcfg.New(args...)
+
+	return cfg, cfg.Load()
+}
+
+// fileProvider returns the provider loading file, according to its extension. Files are read from
+// their directory, so that files outside the working directory can be loaded.
+func fileProvider(file string) (gcfg.Provider, error) {
+	dir, name := filepath.Split(file)
+	if dir == "" {
+		dir = "."
+	}
+
+	fsys := os.DirFS(dir)
+
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		return gcfg.NewJSONProvider(gcfg.WithJSONFilePath(name), gcfg.WithJSONFileFS(fsys)), nil
+	case ".env":
+		return gcfg.NewDotEnvProvider(
+			gcfg.WithDotEnvFilePath(name),
+			gcfg.WithDotEnvFileFS(fsys),
+			gcfg.WithDotEnvFileAppendToOSEnv(false),
+			gcfg.WithDotEnvNormalizeVarNames(false),
+		), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedFile, file)
+	}
+}
+
+// newFlagSet returns the flag set of the command name, writing its usage to stderr.
+func newFlagSet(name, args string, stderr io.Writer, flags *loadFlags) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: gcfg %s [flags] %s\n\nFlags:\n", name, args)
+		fs.PrintDefaults()
+	}
+
+	flags.register(fs)
+
+	return fs
+}
+
+// parseFlags parses args with fs, and returns the exit code to return if parsing failed or too few
+// arguments remain.
+func parseFlags(fs *flag.FlagSet, args []string, minArgs int) (int, bool) {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK, false
+		}
+
+		return exitUsage, false
+	}
+
+	if fs.NArg() < minArgs {
+		fs.Usage()
+
+		return exitUsage, false
+	}
+
+	return exitOK, true
+}
+
+// runValidate checks that the files load and conform to the schema given with -schema.
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	var (
+		flags  loadFlags
+		schema string
+	)
+
+	fs := newFlagSet("validate", "file...", stderr, &flags)
+	fs.StringVar(&schema, "schema", "", "JSON Schema `file` the merged configuration must conform to")
+
+	if code, ok := parseFlags(fs, args, 1); !ok {
+		return code
+	}
+
+	var options []gcfg.Option
+
+	if schema != "" {
+		data, err := os.ReadFile(schema)
+		if err != nil {
+			fmt.Fprintf(stderr, "gcfg: %v\n", err)
+
+			return exitFailure
+		}
+
+		options = append(options, gcfg.WithSchema(data))
+	}
+
+	if _, err := flags.load(fs.Args(), options...); err != nil {
+		fmt.Fprintf(stderr, "gcfg: %v\n", err)
+
+		return exitFailure
+	}
+
+	fmt.Fprintln(stdout, "configuration is valid")
+
+	return exitOK
+}
+
+// runRender prints the merged configuration as JSON.
+func runRender(args []string, stdout, stderr io.Writer) int {
+	var flags loadFlags
+
+	fs := newFlagSet("render", "file...", stderr, &flags)
+
+	if code, ok := parseFlags(fs, args, 1); !ok {
+		return code
+	}
+
+	cfg, err := flags.load(fs.Args())
+	if err != nil {
+		fmt.Fprintf(stderr, "gcfg: %v\n", err)
+
+		return exitFailure
+	}
+
+	data, err := json.MarshalIndent(cfg.Values(), "", "  ")
+	if err != nil {
+		fmt.Fprintf(stderr, "gcfg: %v\n", err)
+
+		return exitFailure
+	}
+
+	fmt.Fprintln(stdout, string(data))
+
+	return exitOK
+}
+
+// runExplain prints the value of a key, its origin and the values it overrides.
+func runExplain(args []string, stdout, stderr io.Writer) int {
+	var flags loadFlags
+
+	fs := newFlagSet("explain", "key file...", stderr, &flags)
+
+	if code, ok := parseFlags(fs, args, 2); !ok {
+		return code
+	}
+
+	key, files := fs.Arg(0), fs.Args()[1:]
+
+	cfg, err := flags.load(files)
+	if err != nil {
+		fmt.Fprintf(stderr, "gcfg: %v\n", err)
+
+		return exitFailure
+	}
+
+	origin, ok := cfg.Origin(key)
+	if !ok {
+		fmt.Fprintf(stderr, "gcfg: key %s is not set\n", key)
+
+		return exitFailure
+	}
+
+	fmt.Fprintf(stdout, "%s = %s\n", key, formatValue(cfg, key))
+	fmt.Fprintf(stdout, "  from %s\n", formatOrigin(origin))
+
+	// the values of the key in the providers overridden by its origin, most recent first
+	providers, err := flags.providers(files)
+	if err != nil {
+		fmt.Fprintf(stderr, "gcfg: %v\n", err)
+
+		return exitFailure
+	}
+
+	var overridden []string
+
+	for _, p := range providers {
+		single := gcfg.New(p, gcfg.WithoutDefaultEnv())
+		if err = single.Load(); err != nil || !single.IsSet(key) {
+			continue
+		}
+
+		if o := providerOrigin(p); o != origin {
+			overridden = append([]string{fmt.Sprintf("%s from %s", formatValue(single, key), formatOrigin(o))},
+				overridden...)
+		}
+	}
+
+	if len(overridden) > 0 {
+		fmt.Fprintln(stdout, "  overrides:")
+
+		for _, line := range overridden {
+			fmt.Fprintf(stdout, "    %s\n", line)
+		}
+	}
+
+	return exitOK
+}
+
+// providerOrigin returns the origin of the values loaded by p.
+func providerOrigin(p gcfg.Provider) gcfg.Origin {
+	origin := gcfg.Origin{Provider: p.Name()}
+
+	if sp, ok := p.(gcfg.SourceProvider); ok {
+		origin.Source = sp.Source()
+	}
+
+	return origin
+}
+
+// formatOrigin formats origin as the provider name followed by its source, if any.
+func formatOrigin(origin gcfg.Origin) string {
+	if origin.Source == "" {
+		return origin.Provider
+	}
+
+	return origin.Provider + " (" + origin.Source + ")"
+}
+
+// formatValue formats the value of key in cfg as JSON.
+func formatValue(cfg *gcfg.Config, key string) string {
+	value, _ := cfg.Find(key)
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+
+	return string(data)
+}
@@ -0,0 +1,84 @@
+// Command gcfg inspects configurations loaded with gcfg, for debugging deployments without
+// writing throwaway Go programs.
+//
+// Usage:
+//
+//	gcfg validate [flags] -schema schema.json file...
+//	gcfg render   [flags] file...
+//	gcfg explain  [flags] key file...
+//
+// Files are loaded in the given order, later files overriding earlier ones; .json files are loaded
+// with the JSON provider and .env files with the dotenv provider. The common flags are:
+//
+//	-profile name    also load, after each file, its profile variant if it exists, e.g.
+//	                 config.prod.json for config.json with -profile prod
+//	-env             load the environment variables, after the files
+//	-env-prefix p    only load the environment variables starting with p (implies -env)
+//	-env-separator s separator of the keys of nested environment variables (default "__")
+//
+// validate checks that the files load and that the merged configuration conforms to the JSON
+// Schema given with -schema, if any. render prints the merged configuration as JSON. explain
+// prints the value of a key, the source it comes from and the values it overrides.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// exit codes
+const (
+	exitOK = iota
+	exitFailure
+	exitUsage
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run executes the command line args, writing the output to stdout and the errors to stderr, and
+// returns the exit code.
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+
+		return exitUsage
+	}
+
+	var cmd func(args []string, stdout, stderr io.Writer) int
+
+	switch args[0] {
+	case "validate":
+		cmd = runValidate
+	case "render":
+		cmd = runRender
+	case "explain":
+		cmd = runExplain
+	case "help", "-h", "-help", "--help":
+		usage(stdout)
+
+		return exitOK
+	default:
+		fmt.Fprintf(stderr, "gcfg: unknown command %q\n", args[0])
+		usage(stderr)
+
+		return exitUsage
+	}
+
+	return cmd(args[1:], stdout, stderr)
+}
+
+// usage writes the list of commands to w.
+func usage(w io.Writer) {
+	fmt.Fprint(w, `Usage: gcfg <command> [flags] [arguments]
+
+Commands:
+  validate  check that configuration files load and conform to a JSON Schema
+  render    print the merged configuration as JSON
+  explain   print where the value of a key comes from
+
+Run "gcfg <command> -h" for the flags of a command.
+`)
+}
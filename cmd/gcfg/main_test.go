@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeFiles writes the files to a temporary directory and returns their paths by name.
+func writeFiles(t *testing.T, files map[string]string) map[string]string {
+	t.Helper()
+
+	dir := t.TempDir()
+	paths := make(map[string]string, len(files))
+
+	for name, content := range files {
+		paths[name] = filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(paths[name], []byte(content), 0o600))
+	}
+
+	return paths
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	paths := writeFiles(t, map[string]string{
+		"config.json":      `{"server": {"host": "localhost", "port": 8080}}`,
+		"config.prod.json": `{"server": {"port": 9090}}`,
+		"override.env":     "SERVER__HOST=example.com\n",
+		"schema.json":      `{"properties": {"server": {"properties": {"port": {"maximum": 9000}}}}}`,
+		"config.yaml":      "server: {}\n",
+	})
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{
+			name:     "render",
+			args:     []string{"render", "-profile", "prod", paths["config.json"], paths["override.env"]},
+			wantCode: exitOK,
+			wantStdout: `{
+  "server": {
+    "host": "example.com",
+    "port": 9090
+  }
+}
+`,
+		},
+		{
+			name:     "explain",
+			args:     []string{"explain", "-profile", "prod", "server.port", paths["config.json"]},
+			wantCode: exitOK,
+			wantStdout: "server.port = 9090\n" +
+				"  from JSON (config.prod.json)\n" +
+				"  overrides:\n" +
+				"    8080 from JSON (config.json)\n",
+		},
+		{
+			name:       "explain missing key",
+			args:       []string{"explain", "server.tls", paths["config.json"]},
+			wantCode:   exitFailure,
+			wantStderr: "gcfg: key server.tls is not set\n",
+		},
+		{
+			name:       "validate",
+			args:       []string{"validate", "-schema", paths["schema.json"], paths["config.json"]},
+			wantCode:   exitOK,
+			wantStdout: "configuration is valid\n",
+		},
+		{
+			name:       "validate schema violation",
+			args:       []string{"validate", "-schema", paths["schema.json"], "-profile", "prod", paths["config.json"]},
+			wantCode:   exitFailure,
+			wantStderr: "gcfg: configuration violates schema: key server.port: must be <= 9000, got 9090\n",
+		},
+		{
+			name:     "unsupported file",
+			args:     []string{"render", paths["config.yaml"]},
+			wantCode: exitFailure,
+			wantStderr: "gcfg: unsupported configuration file (expected .json or .env): " +
+				paths["config.yaml"] + "\n",
+		},
+		{
+			name:     "unknown command",
+			args:     []string{"lint"},
+			wantCode: exitUsage,
+		},
+		{
+			name:     "missing arguments",
+			args:     []string{"explain", "server.port"},
+			wantCode: exitUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+
+			code := run(tt.args, &stdout, &stderr)
+			assert.Equal(t, tt.wantCode, code, stderr.String())
+
+			if tt.wantCode != exitUsage {
+				assert.Equal(t, tt.wantStdout, stdout.String())
+				assert.Equal(t, tt.wantStderr, stderr.String())
+			}
+		})
+	}
+}
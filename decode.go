@@ -0,0 +1,100 @@
+package gcfg
+
+import (
+	"reflect"
+	"slices"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+)
+
+// Unmarshaler is implemented by types decoding themselves from configuration values. Bind calls
+// UnmarshalConfig with the value at the key of the field (e.g., a map[string]any for a section)
+// instead of binding it, so third-party types can define their own decoding. The value is shared
+// with the configuration and must not be modified.
+//
+// Types implementing json.Unmarshaler are bound likewise, from the value marshaled to JSON.
+type Unmarshaler interface {
+	UnmarshalConfig(value any) error
+}
+
+// DecodeHook converts a configuration value of type from before it is bound to a struct field (or
+// map value, slice item, ...) of type to, pointers dereferenced. It returns the value to bind,
+// either converted or unchanged, so custom types can be bound without forking the binder:
+//
+//	func(from, to reflect.Type, value any) (any, error) {
+//		if from.Kind() != reflect.String || to != reflect.TypeFor[slog.Level]() {
+//			return value, nil
+//		}
+//
+//		var level slog.Level
+//		err := level.UnmarshalText([]byte(value.(string)))
+//
+//		return level, err
+//	}
+type DecodeHook func(from, to reflect.Type, value any) (any, error)
+
+// RegisterDecodeHook registers a decode hook applied by Bind, BindKey, BindLive, the components and
+// the typed getters (see Get) to every value before it is bound, after the hooks registered before
+// it and before the hooks given with WithDecodeHook.
+func (c *Config) RegisterDecodeHook(hook DecodeHook) *Config {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var hooks []DecodeHook
+	if current := c.decodeHooks.Load(); current != nil {
+		hooks = slices.Clone(*current)
+	}
+
+	hooks = append(hooks, hook)
+	c.decodeHooks.Store(&hooks)
+
+	return c
+}
+
+// RegisterConverter registers a converter building the values of typ (e.g., a LogLevel or a Color
+// domain type) from configuration values, applied by Bind, BindKey, BindLive, the components and
+// the typed getters to the values bound to fields of type typ or *typ, unless they already are of
+// type typ. convert must return a value assignable to typ.
+//
+// Converters are decode hooks, see RegisterDecodeHook.
+func (c *Config) RegisterConverter(typ reflect.Type, convert func(value any) (any, error)) *Config {
+	return c.RegisterDecodeHook(func(from, to reflect.Type, value any) (any, error) {
+		if to != typ || from == typ {
+			return value, nil
+		}
+
+		return convert(value)
+	})
+}
+
+// WithDecodeHook adds a decode hook applied to every value bound by this call, after the hooks
+// registered with Config.RegisterDecodeHook.
+func WithDecodeHook(hook DecodeHook) BindOption {
+	return func(c *BindOptions) {
+		c.decodeHooks = append(c.decodeHooks, hook)
+	}
+}
+
+// binderDecodeHooks returns the decode hooks of the config followed by the given ones, preceded by
+// the hook resolving secret references if secret resolvers are registered.
+func (c *Config) binderDecodeHooks(hooks []DecodeHook) []maps.DecodeHook {
+	var registered []DecodeHook
+	if current := c.decodeHooks.Load(); current != nil {
+		registered = *current
+	}
+
+	if c.hasSecretResolvers() {
+		registered = slices.Concat([]DecodeHook{c.secretDecodeHook()}, registered)
+	}
+
+	if len(registered) == 0 && len(hooks) == 0 {
+		return nil
+	}
+
+	out := make([]maps.DecodeHook, 0, len(registered)+len(hooks))
+	for _, hook := range slices.Concat(registered, hooks) {
+		out = append(out, maps.DecodeHook(hook))
+	}
+
+	return out
+}
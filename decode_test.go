@@ -0,0 +1,176 @@
+package gcfg_test
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_DecodeHooks(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"log":    map[string]any{"level": "warn"},
+		"server": map[string]any{"version": "1.2.3"},
+	}}, gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	levelHook := func(from, to reflect.Type, value any) (any, error) {
+		if from.Kind() != reflect.String || to != reflect.TypeFor[slog.Level]() {
+			return value, nil
+		}
+
+		var level slog.Level
+		err := level.UnmarshalText([]byte(value.(string)))
+
+		return level, err
+	}
+
+	type version struct {
+		Major, Minor, Patch int
+	}
+
+	versionHook := func(from, to reflect.Type, value any) (any, error) {
+		if from.Kind() != reflect.String || to != reflect.TypeFor[version]() {
+			return value, nil
+		}
+
+		var v version
+		_, err := fmt.Sscanf(value.(string), "%d.%d.%d", &v.Major, &v.Minor, &v.Patch)
+
+		return v, err
+	}
+
+	cfg.RegisterDecodeHook(levelHook)
+
+	var obj struct {
+		Log struct {
+			Level slog.Level
+		}
+		Server struct {
+			Version version
+		}
+	}
+
+	require.NoError(t, cfg.BindKey("log", &obj.Log))
+	assert.Equal(t, slog.LevelWarn, obj.Log.Level)
+
+	require.Error(t, cfg.Bind(&obj))
+
+	require.NoError(t, cfg.Bind(&obj, gcfg.WithDecodeHook(versionHook)))
+	assert.Equal(t, slog.LevelWarn, obj.Log.Level)
+	assert.Equal(t, version{Major: 1, Minor: 2, Patch: 3}, obj.Server.Version)
+
+	errInvalid := errors.New("invalid")
+
+	err := cfg.BindKey("log", &obj.Log, gcfg.WithDecodeHook(func(_, _ reflect.Type, value any) (any, error) {
+		// the registered hooks are applied first
+		if value == slog.LevelWarn {
+			return nil, errInvalid
+		}
+
+		return value, nil
+	}))
+	require.ErrorIs(t, err, errInvalid)
+}
+
+// hostPort unmarshals itself from a "host:port" string or a {host, port} section.
+type hostPort struct {
+	Host string
+	Port string
+}
+
+var errUnexpectedValue = errors.New("unexpected value")
+
+func (h *hostPort) UnmarshalConfig(value any) error {
+	switch v := value.(type) {
+	case string:
+		var err error
+
+		h.Host, h.Port, err = net.SplitHostPort(v)
+
+		return err
+	case map[string]any:
+		h.Host, h.Port = fmt.Sprint(v["host"]), fmt.Sprint(v["port"])
+
+		return nil
+	default:
+		return fmt.Errorf("%w %T", errUnexpectedValue, value)
+	}
+}
+
+var _ gcfg.Unmarshaler = (*hostPort)(nil)
+
+func TestConfig_Bind_Unmarshaler(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"primary": "db1:5432",
+		"replica": map[string]any{"host": "db2", "port": 5433},
+	}}, gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	var obj struct {
+		Primary hostPort
+		Replica *hostPort
+	}
+
+	require.NoError(t, cfg.Bind(&obj))
+	assert.Equal(t, hostPort{Host: "db1", Port: "5432"}, obj.Primary)
+	assert.Equal(t, &hostPort{Host: "db2", Port: "5433"}, obj.Replica)
+}
+
+// color is an RGB color configured as "#rrggbb" or as a {r, g, b} section.
+type color struct {
+	R, G, B uint8
+}
+
+func TestConfig_RegisterConverter(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"theme": map[string]any{
+			"primary":   "#ff8000",
+			"secondary": map[string]any{"r": 1, "g": 2, "b": 3},
+			"invalid":   "orange",
+		},
+	}}, gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	cfg.RegisterConverter(reflect.TypeFor[color](), func(value any) (any, error) {
+		str, ok := value.(string)
+		if !ok {
+			// bound field by field
+			return value, nil
+		}
+
+		var c color
+		if _, err := fmt.Sscanf(str, "#%02x%02x%02x", &c.R, &c.G, &c.B); err != nil {
+			return nil, fmt.Errorf("%w %q: %w", errUnexpectedValue, str, err)
+		}
+
+		return c, nil
+	})
+
+	var theme struct {
+		Primary   color
+		Secondary *color
+	}
+
+	require.NoError(t, cfg.BindKey("theme", &theme))
+	assert.Equal(t, color{R: 0xff, G: 0x80}, theme.Primary)
+	assert.Equal(t, &color{R: 1, G: 2, B: 3}, theme.Secondary)
+
+	var invalid struct {
+		Invalid color
+	}
+
+	require.ErrorIs(t, cfg.BindKey("theme", &invalid), errUnexpectedValue)
+}
@@ -0,0 +1,48 @@
+package gcfg
+
+// deprecatedKey is a key bound to a struct field tagged `deprecated:"<message>"`.
+type deprecatedKey struct {
+	key     string
+	message string
+}
+
+// OnDeprecated registers a hook invoked with the key and the deprecation message of every key
+// bound to a struct field tagged `deprecated:"<message>"` (by Bind, BindKey or BindLive), so
+// users can be migrated off legacy keys gradually:
+//
+//	type DatabaseConfig struct {
+//		DSN string
+//		URL string `deprecated:"use database.dsn instead"`
+//	}
+//
+// Deprecated keys are also logged as warnings.
+func (c *Config) OnDeprecated(hook func(key, message string)) *Config {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.deprecationHook = hook
+
+	return c
+}
+
+// warnDeprecated logs the deprecated keys and reports them to the hook registered with
+// OnDeprecated. The caller must not hold c.mu.
+func (c *Config) warnDeprecated(keys []deprecatedKey) {
+	if len(keys) == 0 {
+		return
+	}
+
+	c.mu.RLock()
+	hook := c.deprecationHook
+	c.mu.RUnlock()
+
+	logger := c.log()
+
+	for _, dk := range keys {
+		logger.Warn("gcfg: deprecated key", "key", dk.key, "message", dk.message)
+
+		if hook != nil {
+			hook(dk.key, dk.message)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package gcfg_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_OnDeprecated(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{"url": "postgres://db"},
+	}}, gcfg.WithLogger(logger), gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	type DatabaseConfig struct {
+		DSN  string
+		URL  string `deprecated:"use database.dsn instead"`
+		Host string `deprecated:"use database.dsn instead"`
+	}
+
+	var warnings []string
+
+	cfg.OnDeprecated(func(key, message string) {
+		warnings = append(warnings, key+": "+message)
+	})
+
+	var dbCfg DatabaseConfig
+
+	require.NoError(t, cfg.BindKey("database", &dbCfg))
+	assert.Equal(t, "postgres://db", dbCfg.URL)
+	assert.Equal(t, []string{"database.url: use database.dsn instead"}, warnings)
+	assert.Contains(t, buf.String(), `msg="gcfg: deprecated key" key=database.url message="use database.dsn instead"`)
+
+	var appCfg struct {
+		Database DatabaseConfig
+	}
+
+	require.NoError(t, cfg.Bind(&appCfg))
+	assert.Len(t, warnings, 2)
+
+	cfg.Set("database.dsn", "postgres://db")
+	cfg.Delete("database.url")
+
+	require.NoError(t, cfg.Bind(&appCfg))
+	assert.Len(t, warnings, 2)
+}
@@ -1,13 +1,14 @@
 package gcfg
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
 	"os"
+	"time"
 
 	"github.com/ahmedkamalio/gcfg/internal/dotenv"
-	"github.com/ahmedkamalio/gcfg/internal/env"
 	"github.com/ahmedkamalio/gcfg/internal/providers"
 )
 
@@ -38,9 +39,24 @@ type DotEnvProvider struct {
 	panicFileNotFound bool
 	// flag to append variables from the .env file to the OS's env vars.
 	appendToOSEnv bool
+	// flag to render the .env file as a text/template before parsing it.
+	template bool
+	// flag to expand the variable references of the .env values.
+	expand bool
+	// decrypts the .env file before parsing it, nil if it is not encrypted.
+	decrypt DecryptFunc
+	// verifies the signature of the .env file, nil if it is not signed.
+	signature *fileSignature
+
+	watch         bool
+	watchInterval time.Duration
 }
 
-var _ Provider = (*DotEnvProvider)(nil)
+var (
+	_ WatchableProvider   = (*DotEnvProvider)(nil)
+	_ SourceProvider      = (*DotEnvProvider)(nil)
+	_ ValidatableProvider = (*DotEnvProvider)(nil)
+)
 
 // DotEnvOption is a function that configures a DotEnvProvider.
 type DotEnvOption func(*DotEnvProvider)
@@ -76,6 +92,74 @@ func WithDotEnvNormalizeVarNames(normalized bool) DotEnvOption {
 	}
 }
 
+// WithDotEnvTypedValues sets a flag to parse the values that look like booleans, integers, floats,
+// or JSON objects or arrays into typed values. See WithEnvTypedValues.
+//
+// Default: false.
+func WithDotEnvTypedValues(typed bool) DotEnvOption {
+	return func(p *DotEnvProvider) {
+		p.typedValues = typed
+	}
+}
+
+// WithDotEnvUnsafeVarFilter sets a flag to filter out the variables deemed unsafe to load as
+// configuration, such as PATH, HOME, HOSTNAME or CI. See WithEnvUnsafeVarFilter.
+//
+// Default: true.
+func WithDotEnvUnsafeVarFilter(enabled bool) DotEnvOption {
+	return func(p *DotEnvProvider) {
+		p.unsafeVarFilter = enabled
+	}
+}
+
+// WithDotEnvBlockedVars filters out the variables with the given names, in addition to the unsafe
+// ones. See WithEnvBlockedVars.
+func WithDotEnvBlockedVars(names ...string) DotEnvOption {
+	return func(p *DotEnvProvider) {
+		p.blockedVars = addVarNames(p.blockedVars, names)
+	}
+}
+
+// WithDotEnvAllowedVars keeps the unsafe variables with the given names. See WithEnvAllowedVars.
+func WithDotEnvAllowedVars(names ...string) DotEnvOption {
+	return func(p *DotEnvProvider) {
+		p.allowedVars = addVarNames(p.allowedVars, names)
+	}
+}
+
+// WithDotEnvBaseDir sets the directory the file must be within, relative paths being resolved
+// against it, e.g. "/etc/myapp". It applies to the default sysfs.SysFS only, not to an fs set with
+// WithDotEnvFileFS.
+//
+// Default: the base directory set with WithBaseDir, or the working directory.
+func WithDotEnvBaseDir(dir string) DotEnvOption {
+	return func(p *DotEnvProvider) {
+		p.SetBaseDir(dir)
+	}
+}
+
+// WithDotEnvAllowSymlinks sets whether the file may be a symlink, whose target must be within the
+// base directory too. It applies to the default sysfs.SysFS only, not to an fs set with
+// WithDotEnvFileFS.
+//
+// Default: the setting of WithAllowSymlinks, or false.
+func WithDotEnvAllowSymlinks(allow bool) DotEnvOption {
+	return func(p *DotEnvProvider) {
+		p.SetAllowSymlinks(allow)
+	}
+}
+
+// WithDotEnvMaxFileSize sets the size limit of the file in bytes, failing to load larger files. A
+// negative size disables the limit. It applies to the default sysfs.SysFS only, not to an fs set
+// with WithDotEnvFileFS.
+//
+// Default: the limit set with WithMaxFileSize, or 1 MB.
+func WithDotEnvMaxFileSize(size int64) DotEnvOption {
+	return func(p *DotEnvProvider) {
+		p.SetMaxFileSize(size)
+	}
+}
+
 // WithDotEnvFileFS sets the fs of which to read the .env file from.
 //
 // Default: sysfs.SysFS.
@@ -105,6 +189,75 @@ func WithDotEnvFileAppendToOSEnv(appendToOSEnv bool) DotEnvOption {
 	}
 }
 
+// WithDotEnvWatch sets the flag to watch the .env file for changes, see Config.Watch.
+// The file is checked periodically (see WithDotEnvWatchInterval), replacing it with an atomic
+// rename is supported, and bursts of writes are debounced into a single reload.
+//
+// Default: false.
+func WithDotEnvWatch(watch bool) DotEnvOption {
+	return func(p *DotEnvProvider) {
+		p.watch = watch
+	}
+}
+
+// WithDotEnvWatchInterval sets the interval at which the watched .env file is checked for changes.
+//
+// Default: 1s.
+func WithDotEnvWatchInterval(interval time.Duration) DotEnvOption {
+	return func(p *DotEnvProvider) {
+		p.watchInterval = interval
+	}
+}
+
+// WithDotEnvExpansion sets the flag to expand the ${NAME} and $NAME references of unquoted and
+// double-quoted values to the variables defined earlier in the file or, if not defined there, to
+// the environment variables, like docker-compose does:
+//
+//	DB_HOST=localhost
+//	DB_URL=postgres://${DB_HOST}:${DB_PORT:-5432}/app
+//
+// ${NAME:-fallback} expands to fallback if NAME is unset or empty, and ${NAME-fallback} only if it
+// is unset; other unset variables expand to an empty string. "$$" and "\$" stand for a literal "$",
+// and single-quoted values are never expanded.
+//
+// Default: false.
+func WithDotEnvExpansion(enabled bool) DotEnvOption {
+	return func(p *DotEnvProvider) {
+		p.expand = enabled
+	}
+}
+
+// WithDotEnvTemplate sets the flag to render the .env file as a text/template before parsing it,
+// e.g. DB_URL=postgres://{{ env "DB_HOST" | default "localhost" }}/app. The functions env, default,
+// required and b64dec are available, see WithJSONTemplate.
+//
+// Default: false.
+func WithDotEnvTemplate(enabled bool) DotEnvOption {
+	return func(p *DotEnvProvider) {
+		p.template = enabled
+	}
+}
+
+// WithDotEnvDecrypt sets the function decrypting the .env file in memory before parsing it, see
+// WithJSONDecrypt.
+//
+// Default: nil, the file is not encrypted.
+func WithDotEnvDecrypt(decrypt DecryptFunc) DotEnvOption {
+	return func(p *DotEnvProvider) {
+		p.decrypt = decrypt
+	}
+}
+
+// WithDotEnvSignature sets the verifier of the detached signature of the .env file, see
+// WithJSONSignature.
+//
+// Default: nil, the signature is not verified.
+func WithDotEnvSignature(sigPath string, verify SignatureVerifier) DotEnvOption {
+	return func(p *DotEnvProvider) {
+		p.signature = &fileSignature{path: sigPath, verify: verify}
+	}
+}
+
 // NewDotEnvProvider creates .env provider with options.
 func NewDotEnvProvider(opts ...DotEnvOption) *DotEnvProvider {
 	p := &DotEnvProvider{
@@ -138,7 +291,21 @@ func (p *DotEnvProvider) Load() (map[string]any, error) {
 		return nil, fmt.Errorf("%w %s: %w", ErrDotEnvFileReadFailed, p.filePath, err)
 	}
 
-	vars, err := dotenv.Parse(file)
+	if err = verifyFileSignature(p.FSProvider, p.filePath, file, p.signature); err != nil {
+		return nil, err
+	}
+
+	if file, err = decryptFile(p.filePath, file, p.decrypt); err != nil {
+		return nil, err
+	}
+
+	if p.template {
+		if file, err = renderFileTemplate(p.filePath, file); err != nil {
+			return nil, err
+		}
+	}
+
+	vars, err := dotenv.ParseWith(file, dotenv.Options{Expand: p.expand, LookupEnv: os.LookupEnv})
 	if err != nil {
 		return nil, fmt.Errorf("%w %s: %w", ErrDotEnvParseFailed, p.filePath, err)
 	}
@@ -151,7 +318,41 @@ func (p *DotEnvProvider) Load() (map[string]any, error) {
 		}
 	}
 
-	return env.ParseVariables(vars, p.prefix, p.separator, p.normalizeVarNames), nil
+	p.logSkippedVars(vars)
+
+	return p.parseVariables(vars), nil
+}
+
+// Validate implements the ValidatableProvider interface.
+func (p *DotEnvProvider) Validate() error {
+	if p.filePath == "" {
+		return ErrDotEnvFilePathNotSet
+	}
+
+	if err := p.EnvProvider.Validate(); err != nil {
+		return err
+	}
+
+	return validateFileWatch(p.watch, p.watchInterval)
+}
+
+// Watch implements the WatchableProvider interface.
+// Returns ErrWatchNotSupported unless watching is enabled with WithDotEnvWatch.
+func (p *DotEnvProvider) Watch(ctx context.Context) (<-chan map[string]any, error) {
+	if !p.watch {
+		return nil, ErrWatchNotSupported
+	}
+
+	if p.filePath == "" {
+		return nil, ErrDotEnvFilePathNotSet
+	}
+
+	return watchFile(ctx, p.FSProvider, p.filePath, p.watchInterval, p.Load), nil
+}
+
+// Source implements the SourceProvider interface.
+func (p *DotEnvProvider) Source() string {
+	return p.filePath
 }
 
 // Name implements the Provider interface.
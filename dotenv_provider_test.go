@@ -1,9 +1,12 @@
 package gcfg_test
 
 import (
+	"context"
 	"os"
+	"path/filepath"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	"github.com/ahmedkamalio/gcfg"
 	"github.com/stretchr/testify/assert"
@@ -49,6 +52,52 @@ func TestDotEnvProvider_WithDotEnvFile(t *testing.T) {
 	assert.Equal(t, "test_value", values["testkey"])
 }
 
+func TestDotEnvProvider_FileAccess(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "target.env"), []byte("TEST_KEY=test_value\n"), 0o600))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "target.env"), filepath.Join(dir, ".env")))
+
+	load := func(opts ...gcfg.DotEnvOption) error {
+		p := gcfg.NewDotEnvProvider(append([]gcfg.DotEnvOption{
+			gcfg.WithDotEnvFilePath(".env"),
+			gcfg.WithDotEnvBaseDir(dir),
+			gcfg.WithDotEnvFileAppendToOSEnv(false),
+		}, opts...)...)
+
+		return gcfg.New(p, gcfg.WithoutDefaultEnv(), gcfg.WithAllowSymlinks(false), gcfg.WithMaxFileSize(-1)).Load()
+	}
+
+	require.ErrorContains(t, load(), "symlink detected")
+	require.NoError(t, load(gcfg.WithDotEnvAllowSymlinks(true)))
+	require.ErrorContains(t, load(gcfg.WithDotEnvAllowSymlinks(true), gcfg.WithDotEnvMaxFileSize(8)),
+		"config file too large")
+}
+
+func TestDotEnvProvider_WithDotEnvTemplate(t *testing.T) {
+	t.Setenv("GCFG_TEMPLATE_DB_HOST", "db.internal")
+
+	fsys := fstest.MapFS{
+		".env": &fstest.MapFile{
+			Data: []byte(`
+				DB_URL=postgres://{{ env "GCFG_TEMPLATE_DB_HOST" }}:{{ env "GCFG_TEMPLATE_DB_PORT" | default "5432" }}/app
+			`),
+		},
+	}
+
+	p := gcfg.NewDotEnvProvider(
+		gcfg.WithDotEnvFilePath(".env"),
+		gcfg.WithDotEnvFileFS(&fsys),
+		gcfg.WithDotEnvFileAppendToOSEnv(false),
+		gcfg.WithDotEnvTemplate(true),
+	)
+
+	values, err := p.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://db.internal:5432/app", values["db_url"])
+}
+
 func TestDotEnvProvider_WithEnvSeparator(t *testing.T) {
 	t.Parallel()
 
@@ -118,6 +167,96 @@ func TestDotEnvProvider_Syntax(t *testing.T) {
 	assert.Equal(t, "test_value2", values["testkey2"])
 }
 
+func TestDotEnvProvider_WithDotEnvExpansion(t *testing.T) {
+	t.Setenv("GCFG_TEST_DB_PORT", "6432")
+
+	fsys := fstest.MapFS{
+		".env": &fstest.MapFile{
+			Data: []byte(`
+				DB_HOST=localhost
+				DB_URL="postgres://${DB_HOST}:${GCFG_TEST_DB_PORT:-5432}/$DB_NAME"
+				DB_TIMEOUT=${DB_TIMEOUT-30s}
+				LITERAL='${DB_HOST}'
+				PRICE=$$5 or \$5
+				TEMPLATE=${not a var}
+			`),
+		},
+	}
+
+	p := gcfg.NewDotEnvProvider(
+		gcfg.WithDotEnvFilePath(".env"),
+		gcfg.WithDotEnvFileFS(&fsys),
+		gcfg.WithDotEnvFileAppendToOSEnv(false),
+		gcfg.WithDotEnvExpansion(true),
+	)
+
+	values, err := p.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "postgres://localhost:6432/", values["dburl"])
+	assert.Equal(t, "30s", values["dbtimeout"])
+	assert.Equal(t, "${DB_HOST}", values["literal"])
+	assert.Equal(t, "$5 or $5", values["price"])
+	assert.Equal(t, "${not a var}", values["template"])
+}
+
+func TestDotEnvProvider_ShellSyntax(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".env": &fstest.MapFile{
+			Data: []byte("export SERVER_HOST=localhost\r\n" +
+				"export\tPORT=8080\r\n" +
+				"EXPORTED=yes\r\n" +
+				`BANNER="line1\nline2\tend"` + "\r\n" +
+				`QUOTED="say \"hi\" # not a comment"` + "\r\n" +
+				`RAW='line1\nline2'` + "\r\n" +
+				`WINDOWS_PATH="C:\Program Files\\app"` + "\r\n" +
+				"MULTI=\"first\\tline\r\nsecond line\"\r\n"),
+		},
+	}
+
+	p := gcfg.NewDotEnvProvider(
+		gcfg.WithDotEnvFilePath(".env"),
+		gcfg.WithDotEnvFileFS(&fsys),
+		gcfg.WithDotEnvFileAppendToOSEnv(false),
+	)
+
+	values, err := p.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", values["serverhost"])
+	assert.Equal(t, "8080", values["port"])
+	assert.Equal(t, "yes", values["exported"])
+	assert.Equal(t, "line1\nline2\tend", values["banner"])
+	assert.Equal(t, `say "hi" # not a comment`, values["quoted"])
+	assert.Equal(t, `line1\nline2`, values["raw"])
+	assert.Equal(t, `C:\Program Files\app`, values["windowspath"])
+	assert.Equal(t, "first\tline\nsecond line", values["multi"])
+}
+
+func TestDotEnvProvider_WithDotEnvTypedValues(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".env": &fstest.MapFile{
+			Data: []byte("DEBUG=true\nWORKERS=4\nLIMITS='{\"rps\": 100}'\nNAME=app"),
+		},
+	}
+
+	p := gcfg.NewDotEnvProvider(
+		gcfg.WithDotEnvFilePath(".env"),
+		gcfg.WithDotEnvFileFS(&fsys),
+		gcfg.WithDotEnvFileAppendToOSEnv(false),
+		gcfg.WithDotEnvTypedValues(true),
+	)
+
+	values, err := p.Load()
+	require.NoError(t, err)
+	assert.Equal(t, true, values["debug"])
+	assert.Equal(t, 4, values["workers"])
+	assert.Equal(t, map[string]any{"rps": 100.0}, values["limits"])
+	assert.Equal(t, "app", values["name"])
+}
+
 func TestDotEnvProvider_WithDotEnvFile_FileNotFoundNoPanic(t *testing.T) {
 	t.Parallel()
 
@@ -169,3 +308,66 @@ func TestDotEnvProvider_NoAppendToOSEnv(t *testing.T) {
 	assert.Equal(t, "test_value", values["mykey"])
 	assert.Empty(t, os.Getenv("MY_KEY"), "Expected os.Getenv(\"MY_KEY\") to be empty")
 }
+
+func TestDotEnvProvider_UnsafeVarFilter(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		".env": &fstest.MapFile{
+			Data: []byte("HOSTNAME=web-1\nCI=true\nMY_KEY=test_value"),
+		},
+	}
+
+	p := gcfg.NewDotEnvProvider(
+		gcfg.WithDotEnvFilePath(".env"),
+		gcfg.WithDotEnvFileFS(&fsys),
+		gcfg.WithDotEnvFileAppendToOSEnv(false),
+		gcfg.WithDotEnvAllowedVars("HOSTNAME"),
+		gcfg.WithDotEnvBlockedVars("MY_KEY"),
+	)
+
+	values, err := p.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "web-1", values["hostname"])
+	assert.NotContains(t, values, "ci")
+	assert.NotContains(t, values, "mykey")
+}
+
+func TestDotEnvProvider_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, gcfg.NewDotEnvProvider().Validate())
+	require.ErrorIs(t, gcfg.NewDotEnvProvider(gcfg.WithDotEnvFilePath("")).Validate(), gcfg.ErrDotEnvFilePathNotSet)
+	require.ErrorIs(t, gcfg.NewDotEnvProvider(gcfg.WithDotEnvSeparator("")).Validate(), gcfg.ErrEnvSeparatorNotSet)
+	require.ErrorIs(t, gcfg.NewDotEnvProvider(gcfg.WithDotEnvWatchInterval(time.Second)).Validate(),
+		gcfg.ErrConflictingOptions)
+}
+
+func TestDotEnvProvider_Watch(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+	require.NoError(t, os.WriteFile(path, []byte("WATCHED_LEVEL=info\n"), 0o600))
+
+	cfg := gcfg.New(gcfg.NewDotEnvProvider(
+		gcfg.WithDotEnvFilePath(".env"),
+		gcfg.WithDotEnvFileFS(os.DirFS(dir)),
+		gcfg.WithDotEnvFileAppendToOSEnv(false),
+		gcfg.WithDotEnvWatch(true),
+		gcfg.WithDotEnvWatchInterval(10*time.Millisecond),
+	))
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, "info", cfg.Get("watched_level"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, cfg.Watch(ctx))
+
+	require.NoError(t, os.WriteFile(path, []byte("WATCHED_LEVEL=debug\n"), 0o600))
+
+	assert.Eventually(t, func() bool {
+		return cfg.Get("watched_level") == "debug"
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,155 @@
+package gcfg
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+var (
+	// ErrDecryptionKeyFailed indicates failure to obtain the key decrypting ENC(...) values.
+	ErrDecryptionKeyFailed = errors.New("failed to obtain decryption key")
+
+	// ErrDecryptValueFailed indicates failure to decrypt an ENC(...) value.
+	ErrDecryptValueFailed = errors.New("failed to decrypt value")
+)
+
+// encryptedValue matches the values encrypted with EncryptValue.
+var encryptedValue = regexp.MustCompile(`^ENC\(AES256_GCM,data:([A-Za-z0-9+/=]+),iv:([A-Za-z0-9+/=]+)\)$`)
+
+// WithDecryptionKey enables the decryption of the encrypted values loaded by providers, so only the
+// sensitive values of otherwise plaintext files need to be encrypted:
+//
+//	{"database": {"host": "db.internal", "password": "ENC(AES256_GCM,data:...,iv:...)"}}
+//
+// key is a 256-bit AES key, and values are encrypted with EncryptValue. Values are decrypted on
+// every load, before the values of providers are merged, and a Load fails with
+// ErrDecryptValueFailed if one can't be decrypted. Consider marking the keys holding secrets with
+// WithSecret so their values are masked.
+func WithDecryptionKey(key []byte) Option {
+	return WithDecryptionKeyFunc(func() ([]byte, error) {
+		return key, nil
+	})
+}
+
+// WithDecryptionKeyFunc is like WithDecryptionKey, with the key obtained from fn, e.g. from a KMS.
+// fn is called on the first load with encrypted values, and again on later loads until it
+// succeeds; a Load fails with ErrDecryptionKeyFailed if it fails.
+func WithDecryptionKeyFunc(fn func() ([]byte, error)) Option {
+	return func(c *Config) {
+		c.decryptionKey = &decryptionKey{fetch: fn}
+	}
+}
+
+// decryptionKey caches the key decrypting ENC(...) values.
+type decryptionKey struct {
+	fetch func() ([]byte, error)
+
+	mu  sync.Mutex
+	gcm func(nonceSize int) (cipher.AEAD, error)
+}
+
+// cipher returns the cipher constructor of the key, fetching the key if needed.
+func (k *decryptionKey) cipher() (func(nonceSize int) (cipher.AEAD, error), error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.gcm != nil {
+		return k.gcm, nil
+	}
+
+	key, err := k.fetch()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryptionKeyFailed, err)
+	}
+
+	if k.gcm, err = newGCM(key); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDecryptionKeyFailed, err)
+	}
+
+	return k.gcm, nil
+}
+
+// EncryptValue encrypts plaintext with the 256-bit AES key, for the values of configuration files
+// decrypted with WithDecryptionKey. It returns a value of the form
+// "ENC(AES256_GCM,data:<base64>,iv:<base64>)".
+func EncryptValue(key []byte, plaintext string) (string, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	iv := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(iv); err != nil {
+		return "", err
+	}
+
+	data := gcm.Seal(nil, iv, []byte(plaintext), nil)
+
+	return fmt.Sprintf("ENC(AES256_GCM,data:%s,iv:%s)",
+		base64.StdEncoding.EncodeToString(data),
+		base64.StdEncoding.EncodeToString(iv),
+	), nil
+}
+
+// decryptProviderValues decrypts the encrypted values of values, loaded by a provider, in place.
+// See WithDecryptionKey.
+func (c *Config) decryptProviderValues(values map[string]any) error {
+	var errs []error
+
+	walkStrings(values, "", c.keys.sep(), func(_, str string) (any, error) {
+		m := encryptedValue.FindStringSubmatch(str)
+		if m == nil {
+			return str, nil
+		}
+
+		gcm, err := c.decryptionKey.cipher()
+		if err != nil {
+			return nil, err
+		}
+
+		plaintext, err := decryptValue(gcm, m[1], m[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDecryptValueFailed, err)
+		}
+
+		return plaintext, nil
+	}, &errs)
+
+	return errors.Join(errs...)
+}
+
+// decryptValue decrypts the base64 encoded data, sealed with the nonce iv.
+func decryptValue(gcm func(nonceSize int) (cipher.AEAD, error), data, iv string) (string, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return "", err
+	}
+
+	nonce, err := base64.StdEncoding.DecodeString(iv)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := gcm(len(nonce))
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
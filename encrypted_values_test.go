@@ -0,0 +1,90 @@
+package gcfg_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithDecryptionKey(t *testing.T) {
+	t.Parallel()
+
+	key := bytes.Repeat([]byte{7}, 32)
+
+	password, err := gcfg.EncryptValue(key, "s3cr3t")
+	require.NoError(t, err)
+	assert.Regexp(t, `^ENC\(AES256_GCM,data:[A-Za-z0-9+/=]+,iv:[A-Za-z0-9+/=]+\)$`, password)
+
+	token, err := gcfg.EncryptValue(key, "t0k3n")
+	require.NoError(t, err)
+
+	p := &mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{"host": "db.internal", "password": password},
+		"tokens":   []any{token},
+	}}
+
+	cfg := gcfg.New(p, gcfg.WithoutDefaultEnv(), gcfg.WithDecryptionKey(key))
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "s3cr3t", cfg.Get("database.password"))
+	assert.Equal(t, "db.internal", cfg.Get("database.host"))
+	assert.Equal(t, "t0k3n", cfg.Get("tokens.0"))
+
+	// the provider values are left encrypted
+	assert.Equal(t, password, p.data["database"].(map[string]any)["password"])
+
+	// without a key, values are taken literally
+	plain := gcfg.New(p, gcfg.WithoutDefaultEnv())
+	require.NoError(t, plain.Load())
+	assert.Equal(t, password, plain.Get("database.password"))
+}
+
+func TestWithDecryptionKeyFunc(t *testing.T) {
+	t.Parallel()
+
+	key := bytes.Repeat([]byte{7}, 32)
+
+	password, err := gcfg.EncryptValue(key, "s3cr3t")
+	require.NoError(t, err)
+
+	p := &mockProvider{name: "mock", data: map[string]any{"password": password}}
+	errKMS := errors.New("kms unavailable")
+
+	var calls int
+
+	cfg := gcfg.New(p, gcfg.WithoutDefaultEnv(), gcfg.WithDecryptionKeyFunc(func() ([]byte, error) {
+		calls++
+		if calls == 1 {
+			return nil, errKMS
+		}
+
+		return key, nil
+	}))
+
+	err = cfg.Load()
+	require.ErrorIs(t, err, gcfg.ErrDecryptionKeyFailed)
+	require.ErrorIs(t, err, errKMS)
+
+	require.NoError(t, cfg.Load())
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, "s3cr3t", cfg.Get("password"))
+	assert.Equal(t, 2, calls, "the key is cached once fetched")
+}
+
+func TestWithDecryptionKey_WrongKey(t *testing.T) {
+	t.Parallel()
+
+	password, err := gcfg.EncryptValue(bytes.Repeat([]byte{7}, 32), "s3cr3t")
+	require.NoError(t, err)
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{"password": password}},
+		gcfg.WithoutDefaultEnv(), gcfg.WithDecryptionKey(bytes.Repeat([]byte{8}, 32)))
+
+	err = cfg.Load()
+	require.ErrorIs(t, err, gcfg.ErrDecryptValueFailed)
+	assert.Contains(t, err.Error(), "provider mock: key password:")
+}
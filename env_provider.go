@@ -1,6 +1,8 @@
 package gcfg
 
 import (
+	"errors"
+	"log/slog"
 	"os"
 	"strings"
 
@@ -14,14 +16,26 @@ const (
 	envProviderName = "Environment Variables"
 )
 
+// ErrEnvSeparatorNotSet indicates that the separator for nested map values is empty.
+var ErrEnvSeparatorNotSet = errors.New("environment variable separator is not set")
+
 // EnvProvider reads configuration from environment variables.
 type EnvProvider struct {
 	prefix            string
 	separator         string
 	normalizeVarNames bool
+	typedValues       bool
+
+	// unsafeVarFilter enables filtering out the unsafe variables (see env.IsUnsafeVar), except
+	// allowedVars; blockedVars are filtered out regardless.
+	unsafeVarFilter bool
+	blockedVars     map[string]bool
+	allowedVars     map[string]bool
+
+	logger *slog.Logger
 }
 
-var _ Provider = (*EnvProvider)(nil)
+var _ ValidatableProvider = (*EnvProvider)(nil)
 
 // EnvOption is a function that configures an EnvProvider.
 type EnvOption func(*EnvProvider)
@@ -59,11 +73,65 @@ func WithEnvNormalizeVarNames(normalized bool) EnvOption {
 	}
 }
 
+// WithEnvTypedValues sets a flag to parse the values that look like booleans ("true" or "false"),
+// integers, floats, or JSON objects or arrays into bool, int, float64, map[string]any and []any
+// values, so Get("feature.enabled") returns true rather than "true". Numbers with leading zeros
+// (e.g. "0755") and integers overflowing an int are kept as strings.
+//
+// Default: false.
+func WithEnvTypedValues(typed bool) EnvOption {
+	return func(p *EnvProvider) {
+		p.typedValues = typed
+	}
+}
+
+// WithEnvUnsafeVarFilter sets a flag to filter out the variables deemed unsafe to load as
+// configuration, such as PATH, HOME, HOSTNAME or CI. Variables blocked with WithEnvBlockedVars
+// are filtered out regardless.
+//
+// Default: true.
+func WithEnvUnsafeVarFilter(enabled bool) EnvOption {
+	return func(p *EnvProvider) {
+		p.unsafeVarFilter = enabled
+	}
+}
+
+// WithEnvBlockedVars filters out the variables with the given names, in addition to the unsafe
+// ones. Names are matched case-insensitively against the full variable names, prefix included.
+func WithEnvBlockedVars(names ...string) EnvOption {
+	return func(p *EnvProvider) {
+		p.blockedVars = addVarNames(p.blockedVars, names)
+	}
+}
+
+// WithEnvAllowedVars keeps the unsafe variables with the given names, e.g. "HOSTNAME" or "CI",
+// while still filtering out the others. Names are matched case-insensitively against the full
+// variable names, prefix included.
+func WithEnvAllowedVars(names ...string) EnvOption {
+	return func(p *EnvProvider) {
+		p.allowedVars = addVarNames(p.allowedVars, names)
+	}
+}
+
+// addVarNames adds the upper-cased names to set, creating it if nil.
+func addVarNames(set map[string]bool, names []string) map[string]bool {
+	if set == nil {
+		set = make(map[string]bool, len(names))
+	}
+
+	for _, name := range names {
+		set[strings.ToUpper(strings.TrimSpace(name))] = true
+	}
+
+	return set
+}
+
 // NewEnvProvider creates an environment variable provider with options.
 func NewEnvProvider(opts ...EnvOption) *EnvProvider {
 	p := &EnvProvider{
 		separator:         defaultEnvSeparator,
 		normalizeVarNames: true,
+		unsafeVarFilter:   true,
 	}
 
 	for _, opt := range opts {
@@ -87,7 +155,59 @@ func (p *EnvProvider) Load() (map[string]any, error) {
 		vars[parts[0]] = parts[1]
 	}
 
-	return env.ParseVariables(vars, p.prefix, p.separator, p.normalizeVarNames), nil
+	p.logSkippedVars(vars)
+
+	return p.parseVariables(vars), nil
+}
+
+// parseVariables parses vars into nested values, see env.ParseVariables, with their types
+// coerced if enabled.
+func (p *EnvProvider) parseVariables(vars map[string]string) map[string]any {
+	data := env.ParseVariables(vars, p.prefix, p.separator, p.normalizeVarNames, p.isSkippedVar)
+
+	if p.typedValues {
+		env.CoerceValues(data)
+	}
+
+	return data
+}
+
+// setLogger implements the loggerSetter interface.
+func (p *EnvProvider) setLogger(logger *slog.Logger) {
+	p.logger = logger
+}
+
+// isSkippedVar reports whether the variable name is filtered out, as blocked or unsafe.
+func (p *EnvProvider) isSkippedVar(name string) bool {
+	name = strings.ToUpper(strings.TrimSpace(name))
+
+	if p.blockedVars[name] {
+		return true
+	}
+
+	return p.unsafeVarFilter && !p.allowedVars[name] && env.IsUnsafeVar(name)
+}
+
+// logSkippedVars logs the variables skipped by env.ParseVariables.
+func (p *EnvProvider) logSkippedVars(vars map[string]string) {
+	if p.logger == nil {
+		return
+	}
+
+	for name := range vars {
+		if p.isSkippedVar(name) {
+			p.logger.Debug("gcfg: skipped unsafe environment variable", "name", name)
+		}
+	}
+}
+
+// Validate implements the ValidatableProvider interface.
+func (p *EnvProvider) Validate() error {
+	if p.separator == "" {
+		return ErrEnvSeparatorNotSet
+	}
+
+	return nil
 }
 
 // Name implements the Provider interface.
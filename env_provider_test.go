@@ -47,6 +47,13 @@ func TestEnvProvider_WithEnvSeparator(t *testing.T) {
 	assert.Equal(t, "test_value", values["test"].(map[string]any)["key"])
 }
 
+func TestEnvProvider_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, gcfg.NewEnvProvider().Validate())
+	require.ErrorIs(t, gcfg.NewEnvProvider(gcfg.WithEnvSeparator("")).Validate(), gcfg.ErrEnvSeparatorNotSet)
+}
+
 func TestEnvProvider_WithEnvNormalizeVarNames(t *testing.T) {
 	t.Setenv("TEST_KEY", "test_value")
 
@@ -61,3 +68,86 @@ func TestEnvProvider_WithEnvNormalizeVarNames(t *testing.T) {
 	assert.Equal(t, "test_value", values["test_key"])
 	assert.Empty(t, values["testkey"])
 }
+
+func TestEnvProvider_UnsafeVarFilter(t *testing.T) {
+	t.Setenv("HOSTNAME", "web-1")
+	t.Setenv("CI", "true")
+	t.Setenv("INTERNAL_TOKEN", "secret")
+
+	tests := []struct {
+		name    string
+		opts    []gcfg.EnvOption
+		present []string
+		absent  []string
+	}{
+		{
+			name:    "default",
+			present: []string{"internal_token"},
+			absent:  []string{"hostname", "ci"},
+		},
+		{
+			name:    "disabled",
+			opts:    []gcfg.EnvOption{gcfg.WithEnvUnsafeVarFilter(false)},
+			present: []string{"hostname", "ci", "internal_token"},
+		},
+		{
+			name:    "allowed",
+			opts:    []gcfg.EnvOption{gcfg.WithEnvAllowedVars("hostname")},
+			present: []string{"hostname", "internal_token"},
+			absent:  []string{"ci"},
+		},
+		{
+			name:   "blocked",
+			opts:   []gcfg.EnvOption{gcfg.WithEnvBlockedVars("INTERNAL_TOKEN")},
+			absent: []string{"hostname", "ci", "internal_token", "internaltoken"},
+		},
+		{
+			name: "blocked when disabled",
+			opts: []gcfg.EnvOption{
+				gcfg.WithEnvUnsafeVarFilter(false),
+				gcfg.WithEnvBlockedVars("INTERNAL_TOKEN"),
+			},
+			present: []string{"hostname", "ci"},
+			absent:  []string{"internal_token"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := gcfg.NewEnvProvider(tt.opts...).Load()
+			require.NoError(t, err)
+
+			for _, key := range tt.present {
+				assert.Contains(t, values, key)
+			}
+
+			for _, key := range tt.absent {
+				assert.NotContains(t, values, key)
+			}
+		})
+	}
+}
+
+func TestEnvProvider_WithEnvTypedValues(t *testing.T) {
+	t.Setenv("FEATURE__ENABLED", "true")
+	t.Setenv("SERVER__PORT", "8080")
+	t.Setenv("SERVER__RATIO", "0.5")
+	t.Setenv("SERVER__MODE", "0755")
+	t.Setenv("ALLOWED_ORIGINS", `["a.example", "b.example"]`)
+
+	values, err := gcfg.NewEnvProvider(gcfg.WithEnvTypedValues(true)).Load()
+	require.NoError(t, err)
+
+	cfg := gcfg.New(gcfg.WithoutDefaultEnv(), &mockProvider{name: "env", data: values})
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, true, cfg.Get("feature.enabled"))
+	assert.Equal(t, 8080, cfg.Get("server.port"))
+	assert.InDelta(t, 0.5, cfg.Get("server.ratio"), 0)
+	assert.Equal(t, "0755", cfg.Get("server.mode"))
+	assert.Equal(t, []any{"a.example", "b.example"}, cfg.Get("allowed_origins"))
+
+	values, err = gcfg.NewEnvProvider().Load()
+	require.NoError(t, err)
+	assert.Equal(t, "true", values["feature"].(map[string]any)["enabled"])
+}
@@ -0,0 +1,161 @@
+package gcfg
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+)
+
+// EnvVar documents an environment variable a struct field is bound from, see DescribeEnvVars.
+type EnvVar struct {
+	// Name is the name of the variable, e.g. "APP_SERVER__PORT". The keys of map entries and the
+	// indexes of slice elements are given as the <KEY> and <INDEX> placeholders.
+	Name string
+	// Key is the configuration key the variable sets, e.g. "server.port".
+	Key string
+	// Type is the Go type of the field, e.g. "time.Duration".
+	Type string
+	// Default is the value of the `default` tag of the field, if any.
+	Default string
+	// Required reports whether the field is tagged `required:"true"`.
+	Required bool
+	// Description is the `doc` tag of the field, followed by its deprecation message, if any.
+	Description string
+}
+
+// DescribeEnvVars lists the environment variables the fields of dest, a struct or a pointer to one,
+// are bound from, with their types and defaults, so runbooks can be generated rather than written
+// by hand. Fields are keyed the same way as by Bind, and variable names are built with the prefix
+// and separator of the given environment variables provider options:
+//
+//	vars, err := gcfg.DescribeEnvVars(AppConfig{}, gcfg.WithEnvPrefix("APP_"))
+//	fmt.Print(gcfg.FormatEnvVars(vars))
+func DescribeEnvVars(dest any, envOptions ...EnvOption) ([]EnvVar, error) {
+	rv := reflect.ValueOf(dest)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, maps.ErrDestMustPointToStruct
+	}
+
+	env := NewEnvProvider(envOptions...)
+	if err := env.Validate(); err != nil {
+		return nil, err
+	}
+
+	var vars []EnvVar
+
+	describeEnvVars(&vars, maps.FieldTree(rv, maps.BindOptions{}), env.prefix, env.separator, nil)
+
+	return vars, nil
+}
+
+// describeEnvVars appends the variables of nodes, nested under the keys path, to vars.
+func describeEnvVars(vars *[]EnvVar, nodes []maps.FieldNode, prefix, sep string, path []string) {
+	for _, node := range nodes {
+		keyPath := append(slices.Clip(path), node.Key)
+
+		if node.Children != nil {
+			describeEnvVars(vars, node.Children, prefix, sep, keyPath)
+
+			continue
+		}
+
+		// the fields of the structs held by maps and slices are nested under a placeholder
+		if elem, placeholder, ok := containerElem(node.Field.Type); ok {
+			elemPath := append(slices.Clip(keyPath), placeholder)
+			elemNodes := maps.FieldTree(reflect.New(elem).Elem(), maps.BindOptions{})
+
+			if len(elemNodes) > 0 {
+				describeEnvVars(vars, elemNodes, prefix, sep, elemPath)
+
+				continue
+			}
+		}
+
+		def, _ := node.Field.Tag.Lookup("default")
+		required, _ := strconv.ParseBool(node.Field.Tag.Get("required"))
+
+		*vars = append(*vars, EnvVar{
+			Name:        envVarName(prefix, sep, keyPath),
+			Key:         strings.Join(keyPath, "."),
+			Type:        node.Field.Type.String(),
+			Default:     def,
+			Required:    required,
+			Description: strings.Join(envVarDescription(node.Field), " "),
+		})
+	}
+}
+
+// containerElem returns the struct element type of the map or slice type t, along with the
+// placeholder of its keys.
+func containerElem(t reflect.Type) (elem reflect.Type, placeholder string, ok bool) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Map:
+		placeholder = "<key>"
+	case reflect.Slice, reflect.Array:
+		placeholder = "<index>"
+	default:
+		return nil, "", false
+	}
+
+	elem = t.Elem()
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+
+	return elem, placeholder, elem.Kind() == reflect.Struct && !maps.IsValueStruct(elem)
+}
+
+// envVarDescription returns the sentences describing the field sf.
+func envVarDescription(sf reflect.StructField) []string {
+	var parts []string
+
+	if doc := sf.Tag.Get("doc"); doc != "" {
+		parts = append(parts, strings.ReplaceAll(doc, "\n", " "))
+	}
+
+	if msg := sf.Tag.Get("deprecated"); msg != "" {
+		parts = append(parts, "Deprecated: "+msg)
+	}
+
+	return parts
+}
+
+// FormatEnvVars renders vars as a Markdown table with the name, type, default, required flag and
+// description of each variable.
+func FormatEnvVars(vars []EnvVar) string {
+	var sb strings.Builder
+
+	sb.WriteString("| Variable | Type | Default | Required | Description |\n")
+	sb.WriteString("|----------|------|---------|----------|-------------|\n")
+
+	for _, v := range vars {
+		required := ""
+		if v.Required {
+			required = "yes"
+		}
+
+		cells := []string{"`" + v.Name + "`", "`" + v.Type + "`", v.Default, required, v.Description}
+		if v.Default != "" {
+			cells[2] = "`" + v.Default + "`"
+		}
+
+		for i, cell := range cells {
+			cells[i] = strings.ReplaceAll(cell, "|", `\|`)
+		}
+
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,61 @@
+package gcfg_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeEnvVars(t *testing.T) {
+	t.Parallel()
+
+	type upstream struct {
+		URL     string        `gcfg:"url" required:"true"`
+		Timeout time.Duration `gcfg:"timeout" default:"5s"`
+	}
+
+	type appConfig struct {
+		Server struct {
+			Port int `gcfg:"port" default:"8080" doc:"Port the HTTP server listens on"`
+		} `gcfg:"server"`
+		Upstreams map[string]upstream `gcfg:"upstreams"`
+		Hosts     []string            `gcfg:"hosts" deprecated:"use upstreams instead"`
+	}
+
+	vars, err := gcfg.DescribeEnvVars(&appConfig{}, gcfg.WithEnvPrefix("app_"))
+	require.NoError(t, err)
+
+	assert.Equal(t, []gcfg.EnvVar{
+		{
+			Name:        "APP_SERVER__PORT",
+			Key:         "server.port",
+			Type:        "int",
+			Default:     "8080",
+			Description: "Port the HTTP server listens on",
+		},
+		{Name: "APP_UPSTREAMS__<KEY>__URL", Key: "upstreams.<key>.url", Type: "string", Required: true},
+		{Name: "APP_UPSTREAMS__<KEY>__TIMEOUT", Key: "upstreams.<key>.timeout", Type: "time.Duration", Default: "5s"},
+		{Name: "APP_HOSTS", Key: "hosts", Type: "[]string", Description: "Deprecated: use upstreams instead"},
+	}, vars)
+
+	assert.Equal(t, "| Variable | Type | Default | Required | Description |\n"+
+		"|----------|------|---------|----------|-------------|\n"+
+		"| `APP_SERVER__PORT` | `int` | `8080` |  | Port the HTTP server listens on |\n"+
+		"| `APP_UPSTREAMS__<KEY>__URL` | `string` |  | yes |  |\n"+
+		"| `APP_UPSTREAMS__<KEY>__TIMEOUT` | `time.Duration` | `5s` |  |  |\n"+
+		"| `APP_HOSTS` | `[]string` |  |  | Deprecated: use upstreams instead |\n",
+		gcfg.FormatEnvVars(vars))
+}
+
+func TestDescribeEnvVars_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := gcfg.DescribeEnvVars("not a struct")
+	require.Error(t, err)
+
+	_, err = gcfg.DescribeEnvVars(struct{}{}, gcfg.WithEnvSeparator(""))
+	require.ErrorIs(t, err, gcfg.ErrEnvSeparatorNotSet)
+}
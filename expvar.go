@@ -0,0 +1,57 @@
+package gcfg
+
+import (
+	"expvar"
+	"time"
+)
+
+// WithExpvar publishes the configuration metadata as an expvar variable with the given name, so
+// it is served by the expvar handler (/debug/vars) along with the other debug variables.
+//
+// The variable holds the configuration version, the time of the last successful load, and the
+// status of each provider (last load time, last error and error count). Configuration values are
+// not published. Like expvar.Publish, WithExpvar panics if the name is already in use.
+func (c *Config) WithExpvar(name string) *Config {
+	expvar.Publish(name, expvar.Func(c.expvarValue))
+
+	return c
+}
+
+// expvarValue returns the configuration metadata published by WithExpvar.
+func (c *Config) expvarValue() any {
+	c.mu.RLock()
+	version, loadedAt := c.rev, c.loadedAt
+	c.mu.RUnlock()
+
+	statuses := c.ProviderStatuses()
+	providers := make([]map[string]any, 0, len(statuses))
+
+	for _, status := range statuses {
+		provider := map[string]any{
+			"name":       status.Name,
+			"errorCount": status.ErrorCount,
+			"lastLoad":   formatExpvarTime(status.LastLoad),
+		}
+
+		if status.LastError != nil {
+			provider["lastError"] = status.LastError.Error()
+		}
+
+		providers = append(providers, provider)
+	}
+
+	return map[string]any{
+		"version":   version,
+		"lastLoad":  formatExpvarTime(loadedAt),
+		"providers": providers,
+	}
+}
+
+// formatExpvarTime formats t for expvar output, empty if t is zero.
+func formatExpvarTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
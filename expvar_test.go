@@ -0,0 +1,46 @@
+package gcfg_test
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_WithExpvar(t *testing.T) {
+	t.Parallel()
+
+	failing := &mockProvider{name: "failing", err: assert.AnError}
+	cfg := gcfg.New(&mockProvider{name: "Environment Variables"}, failing).WithExpvar("gcfg_test_config")
+
+	require.Error(t, cfg.Load())
+
+	failing.err = nil
+	require.NoError(t, cfg.Load())
+
+	v := expvar.Get("gcfg_test_config")
+	require.NotNil(t, v)
+
+	var vars struct {
+		Version   uint64 `json:"version"`
+		LastLoad  string `json:"lastLoad"`
+		Providers []struct {
+			Name       string `json:"name"`
+			ErrorCount int    `json:"errorCount"`
+		} `json:"providers"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(v.String()), &vars))
+
+	assert.Equal(t, cfg.Version(), vars.Version)
+	assert.NotEmpty(t, vars.LastLoad)
+	require.Len(t, vars.Providers, 2)
+	assert.Equal(t, "failing", vars.Providers[1].Name)
+	assert.Equal(t, 1, vars.Providers[1].ErrorCount)
+
+	assert.Panics(t, func() {
+		gcfg.New().WithExpvar("gcfg_test_config")
+	})
+}
@@ -0,0 +1,108 @@
+package gcfg
+
+import (
+	"io"
+
+	"github.com/ahmedkamalio/gcfg/internal/sysfs"
+)
+
+// fileAccessSetter is implemented by built-in file providers, so they can inherit the file access
+// restrictions of the Config they are registered with. The settings given to a provider itself
+// take precedence.
+type fileAccessSetter interface {
+	BaseDir() string
+	SetBaseDir(dir string)
+	InheritAllowSymlinks(allow bool)
+	InheritMaxFileSize(size int64)
+}
+
+// WithBaseDir sets the directory the built-in file providers read their files within, instead of
+// the working directory, e.g. "/etc/myapp". Relative file paths are resolved against it, and paths
+// outside of it are rejected. Providers given their own directory (see WithJSONBaseDir and
+// WithDotEnvBaseDir) or fs keep it.
+func WithBaseDir(dir string) Option {
+	return func(c *Config) {
+		c.baseDir = dir
+		c.propagateFileAccess()
+	}
+}
+
+// WithAllowSymlinks sets whether the built-in file providers may read symlinks, e.g. the files of
+// Kubernetes ConfigMap volumes. The target of a symlink must be within the base directory too (see
+// WithBaseDir). Providers given their own setting (see WithJSONAllowSymlinks and
+// WithDotEnvAllowSymlinks) keep it.
+//
+// Default: false.
+func WithAllowSymlinks(allow bool) Option {
+	return func(c *Config) {
+		c.allowSymlinks = &allow
+		c.propagateFileAccess()
+	}
+}
+
+// WithMaxFileSize sets the size limit in bytes of the files read by the built-in file providers,
+// which fail to load larger files. A negative size disables the limit. Providers given their own
+// limit (see WithJSONMaxFileSize and WithDotEnvMaxFileSize) keep it.
+//
+// Default: 1 MB.
+func WithMaxFileSize(size int64) Option {
+	return func(c *Config) {
+		c.maxFileSize = size
+		c.propagateFileAccess()
+	}
+}
+
+// propagateFileAccess passes the file access restrictions of c to its providers.
+func (c *Config) propagateFileAccess() {
+	for _, p := range c.providers {
+		c.propagateFileAccessTo(p.Provider)
+	}
+}
+
+// propagateFileAccessTo passes the file access restrictions of c to p if it is a built-in file
+// provider. Only the restrictions that were set are passed, and only to providers without their
+// own.
+func (c *Config) propagateFileAccessTo(p Provider) {
+	fa, ok := asProvider[fileAccessSetter](p)
+	if !ok {
+		return
+	}
+
+	if c.baseDir != "" && fa.BaseDir() == "" {
+		fa.SetBaseDir(c.baseDir)
+	}
+
+	if c.allowSymlinks != nil {
+		fa.InheritAllowSymlinks(*c.allowSymlinks)
+	}
+
+	if c.maxFileSize != 0 {
+		fa.InheritMaxFileSize(c.maxFileSize)
+	}
+}
+
+// readFile reads the file at path with the file access restrictions of c, or those set for file
+// references, see WithFileReferences.
+func (c *Config) readFile(path string) ([]byte, error) {
+	baseDir := c.baseDir
+	if c.fileReferencesBaseDir != "" {
+		baseDir = c.fileReferencesBaseDir
+	}
+
+	allowSymlinks := c.allowSymlinks
+	if c.fileReferencesAllowSymlinks != nil {
+		allowSymlinks = c.fileReferencesAllowSymlinks
+	}
+
+	f, err := sysfs.SafeOpenWith(path, sysfs.Options{
+		BaseDir:       baseDir,
+		AllowSymlinks: allowSymlinks != nil && *allowSymlinks,
+		MaxFileSize:   c.maxFileSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
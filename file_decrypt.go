@@ -0,0 +1,31 @@
+package gcfg
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrFileDecryptFailed indicates failure to decrypt an encrypted configuration file.
+var ErrFileDecryptFailed = errors.New("failed to decrypt config file")
+
+// DecryptFunc decrypts the contents of an encrypted configuration file, e.g. with age identities
+// (see AgeIdentityFile and AgeIdentityEnv), or with any other encryption format:
+//
+//	func(ciphertext []byte) ([]byte, error) {
+//		return kms.Decrypt(ctx, ciphertext)
+//	}
+type DecryptFunc func(ciphertext []byte) ([]byte, error)
+
+// decryptFile decrypts the contents of the file at path with decrypt, if not nil.
+func decryptFile(path string, content []byte, decrypt DecryptFunc) ([]byte, error) {
+	if decrypt == nil {
+		return content, nil
+	}
+
+	plaintext, err := decrypt(content)
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrFileDecryptFailed, path, err)
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,157 @@
+package gcfg
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ahmedkamalio/gcfg/internal/providers"
+	"golang.org/x/crypto/blake2b"
+)
+
+var (
+	// ErrSignatureVerificationFailed indicates that the signature of a configuration file is
+	// missing or invalid.
+	ErrSignatureVerificationFailed = errors.New("failed to verify config file signature")
+
+	// ErrInvalidPublicKey indicates that a public key verifying signatures can't be parsed.
+	ErrInvalidPublicKey = errors.New("invalid public key")
+
+	// errInvalidSignature indicates that a signature doesn't match the signed content.
+	errInvalidSignature = errors.New("invalid signature")
+
+	// errMalformedMinisign indicates that a minisign signature file can't be parsed.
+	errMalformedMinisign = errors.New("malformed minisign signature")
+
+	// errUnsupportedMinisign indicates that a minisign signature uses an unsupported algorithm.
+	errUnsupportedMinisign = errors.New("unsupported minisign signature algorithm")
+
+	// errMinisignKeyMismatch indicates that a minisign signature was made with another key.
+	errMinisignKeyMismatch = errors.New("signature made with another key")
+)
+
+// signatureFileSuffix is appended to the path of a configuration file to get the path of its
+// signature, unless set explicitly.
+const signatureFileSuffix = ".sig"
+
+// SignatureVerifier verifies signature, the contents of the detached signature file of a
+// configuration file, against content, the contents of the file.
+type SignatureVerifier func(content, signature []byte) error
+
+// Ed25519Verifier returns a SignatureVerifier of Ed25519 signatures made with the private key of
+// publicKey. Signature files hold the 64-byte signature, either raw or base64 encoded.
+func Ed25519Verifier(publicKey ed25519.PublicKey) SignatureVerifier {
+	return func(content, signature []byte) error {
+		if decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(signature))); err == nil {
+			signature = decoded
+		}
+
+		if !ed25519.Verify(publicKey, content, signature) {
+			return errInvalidSignature
+		}
+
+		return nil
+	}
+}
+
+// minisign signature algorithms.
+const (
+	minisignAlgorithm          = "Ed"
+	minisignPrehashedAlgorithm = "ED"
+)
+
+// MinisignVerifier returns a SignatureVerifier of minisign signatures (see
+// https://jedisct1.github.io/minisign/) made with the secret key of publicKey, the base64 encoded
+// public key, e.g. "RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3". Both the signature of
+// the file and the signature of its trusted comment are verified.
+//
+// Both pre-hashed signatures, the default of minisign, which sign the BLAKE2b-512 hash of the
+// file, and legacy signatures (minisign -S -l) are supported.
+func MinisignVerifier(publicKey string) (SignatureVerifier, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(publicKey))
+	if err != nil || len(key) != 2+8+ed25519.PublicKeySize || string(key[:2]) != minisignAlgorithm {
+		return nil, fmt.Errorf("%w: not a minisign Ed25519 public key", ErrInvalidPublicKey)
+	}
+
+	keyID, pub := key[2:10], ed25519.PublicKey(key[10:])
+
+	return func(content, signature []byte) error {
+		// untrusted comment, signature, trusted comment and global signature lines
+		lines := strings.Split(strings.TrimSpace(strings.ReplaceAll(string(signature), "\r\n", "\n")), "\n")
+		if len(lines) < 4 {
+			return errMalformedMinisign
+		}
+
+		sig, err := base64.StdEncoding.DecodeString(lines[1])
+		if err != nil || len(sig) != 2+8+ed25519.SignatureSize {
+			return errMalformedMinisign
+		}
+
+		signed := content
+
+		switch string(sig[:2]) {
+		case minisignAlgorithm:
+		case minisignPrehashedAlgorithm:
+			hash := blake2b.Sum512(content)
+			signed = hash[:]
+		default:
+			return fmt.Errorf("%w %q", errUnsupportedMinisign, sig[:2])
+		}
+
+		if !bytes.Equal(sig[2:10], keyID) {
+			return errMinisignKeyMismatch
+		}
+
+		if !ed25519.Verify(pub, signed, sig[10:]) {
+			return errInvalidSignature
+		}
+
+		trustedComment, ok := strings.CutPrefix(lines[2], "trusted comment: ")
+		if !ok {
+			return fmt.Errorf("%w: trusted comment", errMalformedMinisign)
+		}
+
+		globalSig, err := base64.StdEncoding.DecodeString(lines[3])
+		if err != nil || !ed25519.Verify(pub, slices.Concat(sig[10:], []byte(trustedComment)), globalSig) {
+			return fmt.Errorf("%w of trusted comment", errInvalidSignature)
+		}
+
+		return nil
+	}, nil
+}
+
+// fileSignature is the signature verification options of a file provider.
+type fileSignature struct {
+	// path is the path of the signature file, the path of the file followed by
+	// signatureFileSuffix if empty.
+	path   string
+	verify SignatureVerifier
+}
+
+// verifyFileSignature verifies content, the contents of the file at path read from fsp, against
+// its detached signature, if sig is not nil.
+func verifyFileSignature(fsp *providers.FSProvider, path string, content []byte, sig *fileSignature) error {
+	if sig == nil {
+		return nil
+	}
+
+	sigPath := sig.path
+	if sigPath == "" {
+		sigPath = path + signatureFileSuffix
+	}
+
+	signature, err := fsp.ReadFile(sigPath)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrSignatureVerificationFailed, path, err)
+	}
+
+	if err = sig.verify(content, signature); err != nil {
+		return fmt.Errorf("%w %s: %w", ErrSignatureVerificationFailed, path, err)
+	}
+
+	return nil
+}
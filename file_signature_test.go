@@ -0,0 +1,180 @@
+package gcfg_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONProvider_WithJSONSignature(t *testing.T) {
+	t.Parallel()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	content := []byte(`{"feature": true}`)
+	signature := ed25519.Sign(priv, content)
+
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		sigPath string
+		wantErr bool
+	}{
+		{
+			name: "raw signature",
+			files: fstest.MapFS{
+				"config.json":     {Data: content},
+				"config.json.sig": {Data: signature},
+			},
+		},
+		{
+			name: "base64 signature at custom path",
+			files: fstest.MapFS{
+				"config.json":        {Data: content},
+				"signatures/app.sig": {Data: []byte(base64.StdEncoding.EncodeToString(signature) + "\n")},
+			},
+			sigPath: "signatures/app.sig",
+		},
+		{
+			name: "tampered file",
+			files: fstest.MapFS{
+				"config.json":     {Data: []byte(`{"feature": false}`)},
+				"config.json.sig": {Data: signature},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "missing signature",
+			files:   fstest.MapFS{"config.json": {Data: content}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := gcfg.NewJSONProvider(
+				gcfg.WithJSONFilePath("config.json"),
+				gcfg.WithJSONFileFS(tt.files),
+				gcfg.WithJSONSignature(tt.sigPath, gcfg.Ed25519Verifier(pub)),
+			)
+
+			values, err := p.Load()
+			if tt.wantErr {
+				require.ErrorIs(t, err, gcfg.ErrSignatureVerificationFailed)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, true, values["feature"])
+		})
+	}
+}
+
+// minisign returns a minisign public key and a legacy minisign signature of content.
+func minisign(t *testing.T, content []byte, trustedComment string) (string, []byte) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	keyID := []byte{1, 2, 3, 4, 5, 6, 7, 8}
+	signature := ed25519.Sign(priv, content)
+	globalSignature := ed25519.Sign(priv, slices.Concat(signature, []byte(trustedComment)))
+
+	publicKey := base64.StdEncoding.EncodeToString(slices.Concat([]byte("Ed"), keyID, pub))
+	sigFile := fmt.Sprintf("untrusted comment: signature from minisign secret key\n%s\ntrusted comment: %s\n%s\n",
+		base64.StdEncoding.EncodeToString(slices.Concat([]byte("Ed"), keyID, signature)),
+		trustedComment,
+		base64.StdEncoding.EncodeToString(globalSignature),
+	)
+
+	return publicKey, []byte(sigFile)
+}
+
+func TestMinisignVerifier(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("LOG_LEVEL=debug\n")
+	publicKey, signature := minisign(t, content, "timestamp:1700000000\tfile:.env")
+
+	verify, err := gcfg.MinisignVerifier(publicKey)
+	require.NoError(t, err)
+
+	require.NoError(t, verify(content, signature))
+	require.Error(t, verify([]byte("LOG_LEVEL=trace\n"), signature))
+
+	// a forged trusted comment
+	forged := []byte(strings.Replace(string(signature), "timestamp:1700000000", "timestamp:1800000000", 1))
+	require.Error(t, verify(content, forged))
+
+	otherKey, _ := minisign(t, content, "")
+	verifyOther, err := gcfg.MinisignVerifier(otherKey)
+	require.NoError(t, err)
+	require.Error(t, verifyOther(content, signature))
+
+	_, err = gcfg.MinisignVerifier("not a key")
+	require.ErrorIs(t, err, gcfg.ErrInvalidPublicKey)
+
+	// with the dotenv provider
+	p := gcfg.NewDotEnvProvider(
+		gcfg.WithDotEnvFilePath(".env"),
+		gcfg.WithDotEnvFileFS(fstest.MapFS{".env": {Data: content}, ".env.minisig": {Data: signature}}),
+		gcfg.WithDotEnvFileAppendToOSEnv(false),
+		gcfg.WithDotEnvSignature(".env.minisig", verify),
+	)
+
+	values, err := p.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "debug", values["log_level"])
+}
+
+// minisignFixture is a pre-hashed signature of minisignFixtureContent (the default of minisign),
+// made with the secret key of minisignFixtureKey.
+const (
+	minisignFixtureKey     = "RWRaHzyeB7Jk2NpUsA6SIIEQ4NnQs48kn6XTCDuoOdsSC1uCVYXgfhDH"
+	minisignFixtureContent = "{\"log_level\": \"debug\"}\n"
+	minisignFixture        = "untrusted comment: signature from minisign secret key\n" +
+		"RURaHzyeB7Jk2HB9JRBhN0H90M1ktRfLVDLrlB9PvX5PaFc0vCdphOlChj7Ou+sa1s5v/NEuKbnD0QDl0swfNW+yPnnmWRiRLgI=\n" +
+		"trusted comment: timestamp:1714557600\tfile:config.json\thashed\n" +
+		"WswUGEFBxvMcdQHa8Xbyh7c9vRZjFpyjVX9HBtZr+r82qDD0rSoCUOl/esp3xskyuMZmEWC3N5iWemuUMvNHDg==\n"
+)
+
+func TestMinisignVerifier_Prehashed(t *testing.T) {
+	t.Parallel()
+
+	verify, err := gcfg.MinisignVerifier(minisignFixtureKey)
+	require.NoError(t, err)
+
+	require.NoError(t, verify([]byte(minisignFixtureContent), []byte(minisignFixture)))
+	require.Error(t, verify([]byte("{\"log_level\": \"trace\"}\n"), []byte(minisignFixture)))
+
+	// the algorithm can't be downgraded to a legacy signature of the same bytes
+	legacy := strings.Replace(minisignFixture, "RURa", "RWRa", 1)
+	require.Error(t, verify([]byte(minisignFixtureContent), []byte(legacy)))
+
+	p := gcfg.NewJSONProvider(
+		gcfg.WithJSONFilePath("config.json"),
+		gcfg.WithJSONFileFS(fstest.MapFS{
+			"config.json":         {Data: []byte(minisignFixtureContent)},
+			"config.json.minisig": {Data: []byte(minisignFixture)},
+		}),
+		gcfg.WithJSONSignature("config.json.minisig", verify),
+	)
+
+	values, err := p.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "debug", values["log_level"])
+}
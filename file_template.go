@@ -0,0 +1,97 @@
+package gcfg
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"text/template"
+)
+
+var (
+	// ErrTemplateRenderFailed indicates failure to render a templated configuration file.
+	ErrTemplateRenderFailed = errors.New("failed to render config file template")
+
+	// ErrRequiredTemplateValue indicates that a value passed to the required template function is
+	// empty.
+	ErrRequiredTemplateValue = errors.New("required value is empty")
+)
+
+// templateFuncs are the functions available to templated configuration files:
+//
+//	env "NAME"              the value of the environment variable NAME, empty if unset
+//	default "fallback" v    v, or fallback if v is empty
+//	required "message" v    v, or fails the load with message if v is empty
+//	b64dec s                s decoded from standard base64
+var templateFuncs = template.FuncMap{
+	"env":      os.Getenv,
+	"default":  templateDefault,
+	"required": templateRequired,
+	"b64dec":   templateB64Dec,
+}
+
+// renderFileTemplate renders the contents of the file at path as a text/template with the
+// functions of templateFuncs, see WithJSONTemplate and WithDotEnvTemplate.
+func renderFileTemplate(path string, content []byte) ([]byte, error) {
+	tmpl, err := template.New(path).Funcs(templateFuncs).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrTemplateRenderFailed, path, err)
+	}
+
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, nil); err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrTemplateRenderFailed, path, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// isEmptyTemplateValue reports whether v is empty: nil, false, 0, or an empty string, slice or map.
+func isEmptyTemplateValue(v any) bool {
+	if v == nil {
+		return true
+	}
+
+	return reflect.ValueOf(v).IsZero() || isEmptyCollection(reflect.ValueOf(v))
+}
+
+// isEmptyCollection reports whether v is an empty slice, array or map.
+func isEmptyCollection(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len() == 0
+	default:
+		return false
+	}
+}
+
+// templateDefault returns value, or fallback if value is empty. It is meant to be piped into, as
+// in {{ env "PORT" | default "8080" }}.
+func templateDefault(fallback any, value ...any) any {
+	if len(value) == 0 || isEmptyTemplateValue(value[0]) {
+		return fallback
+	}
+
+	return value[0]
+}
+
+// templateRequired returns value, or fails with message if value is empty.
+func templateRequired(message string, value any) (any, error) {
+	if isEmptyTemplateValue(value) {
+		return nil, fmt.Errorf("%w: %s", ErrRequiredTemplateValue, message)
+	}
+
+	return value, nil
+}
+
+// templateB64Dec decodes s from standard base64.
+func templateB64Dec(s string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", fmt.Errorf("b64dec: %w", err)
+	}
+
+	return string(data), nil
+}
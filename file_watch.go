@@ -0,0 +1,44 @@
+package gcfg
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ahmedkamalio/gcfg/internal/providers"
+)
+
+// defaultFileWatchInterval is the default interval at which watched files are checked for changes.
+const defaultFileWatchInterval = time.Second
+
+// validateFileWatch checks the file watching options of a file provider.
+func validateFileWatch(watch bool, interval time.Duration) error {
+	if interval < 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidWatchInterval, interval)
+	}
+
+	if interval > 0 && !watch {
+		return fmt.Errorf("%w: watch interval set but watching is disabled", ErrConflictingOptions)
+	}
+
+	return nil
+}
+
+// watchFile reloads the values with load whenever the file at path changes on fsp, and emits them
+// if they differ from the previously emitted values.
+//
+// Loads that fail (e.g., because the file is being rewritten) are skipped, the values are emitted
+// once the file is valid again. The returned channel is closed once ctx is done.
+func watchFile(
+	ctx context.Context,
+	fsp *providers.FSProvider,
+	path string,
+	interval time.Duration,
+	load func() (map[string]any, error),
+) <-chan map[string]any {
+	if interval <= 0 {
+		interval = defaultFileWatchInterval
+	}
+
+	return emitChangedLoads(ctx, fsp.WatchFile(ctx, path, interval), load)
+}
@@ -0,0 +1,73 @@
+package gcfg
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrConfigFrozen is returned when modifying a configuration frozen with Freeze.
+var ErrConfigFrozen = errors.New("configuration is frozen")
+
+// FreezeOptions defines options for freezing a Config.
+type FreezeOptions struct {
+	panics bool
+}
+
+// FreezeOption is a functional option for configuring Freeze behavior by modifying FreezeOptions.
+type FreezeOption func(*FreezeOptions)
+
+// WithFreezePanic sets whether modifying the frozen configuration panics instead of failing.
+func WithFreezePanic(panics bool) FreezeOption {
+	return func(o *FreezeOptions) {
+		o.panics = panics
+	}
+}
+
+// Freeze makes the configuration read-only, so no code path can modify it once startup completes.
+//
+// Once frozen, Load, LoadWithContext, Watch, Restore, SetDefaults and MergeFrom return
+// ErrConfigFrozen, and updates of watched providers are reported to the OnReloadError handler.
+// Set, SetDefault, Delete and Reset, which don't return errors, are ignored and logged as
+// warnings. With WithFreezePanic(true), all these methods panic instead. A configuration can't be
+// unfrozen; use Clone to get a modifiable copy.
+func (c *Config) Freeze(options ...FreezeOption) {
+	opts := &FreezeOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	c.freeze.Store(opts)
+}
+
+// IsFrozen reports whether the configuration was frozen with Freeze.
+func (c *Config) IsFrozen() bool {
+	return c.freeze.Load() != nil
+}
+
+// checkFrozen returns ErrConfigFrozen for the operation op if the configuration is frozen, or
+// panics with it if the configuration was frozen with WithFreezePanic(true).
+func (c *Config) checkFrozen(op string) error {
+	opts := c.freeze.Load()
+	if opts == nil {
+		return nil
+	}
+
+	err := fmt.Errorf("%w: %s", ErrConfigFrozen, op)
+	if opts.panics {
+		panic(fmt.Errorf("gcfg: %w", err))
+	}
+
+	return err
+}
+
+// ignoreFrozen reports whether the operation op must be ignored because the configuration is
+// frozen, logging a warning if so.
+func (c *Config) ignoreFrozen(op, key string) bool {
+	if err := c.checkFrozen(op); err != nil {
+		c.log().Warn("gcfg: modification of frozen configuration ignored", "operation", op, "key", key)
+
+		return true
+	}
+
+	return false
+}
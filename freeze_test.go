@@ -0,0 +1,61 @@
+package gcfg_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Freeze(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{"host": "localhost"}})
+	require.NoError(t, cfg.Load())
+	assert.False(t, cfg.IsFrozen())
+
+	cfg.Freeze()
+	assert.True(t, cfg.IsFrozen())
+
+	version := cfg.Version()
+
+	require.ErrorIs(t, cfg.Load(), gcfg.ErrConfigFrozen)
+	require.ErrorIs(t, cfg.Watch(context.Background()), gcfg.ErrConfigFrozen)
+	require.ErrorIs(t, cfg.Restore(cfg.Snapshot()), gcfg.ErrConfigFrozen)
+	require.ErrorIs(t, cfg.SetDefaults(map[string]any{"port": 5432}), gcfg.ErrConfigFrozen)
+	require.ErrorIs(t, cfg.MergeFrom(gcfg.New()), gcfg.ErrConfigFrozen)
+
+	cfg.Set("host", "db.internal")
+	cfg.SetDefault("port", 5432)
+	assert.False(t, cfg.Delete("host"))
+	cfg.Reset()
+
+	assert.Equal(t, "localhost", cfg.Get("host"))
+	assert.False(t, cfg.IsSet("port"))
+	assert.Equal(t, version, cfg.Version())
+
+	// Clones are not frozen.
+	clone := cfg.Clone()
+	assert.False(t, clone.IsFrozen())
+	clone.Set("host", "db.internal")
+	assert.Equal(t, "db.internal", clone.Get("host"))
+}
+
+func TestConfig_Freeze_WithPanic(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{"host": "localhost"}})
+	require.NoError(t, cfg.Load())
+
+	cfg.Freeze(gcfg.WithFreezePanic(true))
+
+	assert.PanicsWithError(t, "gcfg: configuration is frozen: Set", func() {
+		cfg.Set("host", "db.internal")
+	})
+	assert.Panics(t, func() { _ = cfg.Load() })
+	assert.Panics(t, func() { cfg.SetDefault("port", 5432) })
+
+	assert.Equal(t, "localhost", cfg.Get("host"))
+}
@@ -6,9 +6,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/ahmedkamalio/gcfg/internal/jsonschema"
 	"github.com/ahmedkamalio/gcfg/internal/maps"
 	"github.com/ahmedkamalio/gcfg/internal/reflection"
 	"github.com/go-playground/validator/v10"
@@ -26,43 +33,249 @@ var (
 
 	// ErrNilValues is returned when a nil value is provided where non-nil input is required.
 	ErrNilValues = errors.New("values cannot be nil")
+
+	// ErrNilProvider is returned by NewE when a nil provider is given.
+	ErrNilProvider = errors.New("provider cannot be nil")
+
+	// ErrDuplicateProvider is returned by NewE when several providers have the same name.
+	ErrDuplicateProvider = errors.New("duplicate provider name")
+
+	// ErrInvalidProvider is returned by NewE when a provider is misconfigured.
+	ErrInvalidProvider = errors.New("invalid provider configuration")
+
+	// ErrConflictingOptions indicates that options given to a provider contradict each other.
+	ErrConflictingOptions = errors.New("conflicting options")
+
+	// ErrMissingRequiredKey is returned by Bind when the key of a field tagged `required:"true"`
+	// is absent from the configuration.
+	ErrMissingRequiredKey = maps.ErrMissingKey
 )
 
+// BindError is an error binding the value at a configuration key into a struct field, returned
+// (joined with the errors of the other invalid keys) by Bind. It carries the dotted key (e.g.,
+// "servers.0.port"), the path of the field (e.g., "Servers[0].Port"), the type of the field and
+// the type of the value, so errors can be matched with errors.As and rendered as diagnostics.
+type BindError = maps.BindError
+
 // Config represents the configuration loaded from various providers.
+//
+// The configuration values are layered: defaults (SetDefault, SetDefaults) are overridden by
+// runtime overrides (Set), which are in turn overridden by the values the providers supply on the
+// next load. Values the providers no longer supply are kept until their provider is removed (see
+// RemoveProvider) or they are deleted (see Delete).
 type Config struct {
-	providers []Provider
+	providers []*providerEntry
 
 	extensions []Extension
 
 	values map[string]any
-	mu     sync.RWMutex
+	// defaults holds the values set via SetDefault and SetDefaults.
+	defaults map[string]any
+	// overrides holds the values set via Set.
+	overrides map[string]any
+	// origins maps dotted leaf keys to the origin of their value.
+	origins map[string]Origin
+	mu      sync.RWMutex
+
+	// loaded reports whether the configuration has been loaded at least once.
+	loaded bool
+	// loadedAt is the time of the last successful load.
+	loadedAt time.Time
+	// rev is incremented on every modification of the configuration values, see Version.
+	rev uint64
+	// loadMu serializes loads.
+	loadMu sync.Mutex
+
+	changeLog *changeLog
+
+	restartKeys    []string
+	pendingRestart []Change
+
+	components []*componentEntry
+
+	liveBindings []func(values map[string]any) error
+
+	keyListeners    []*keyListener
+	changeListeners []func(changes ChangeSet)
+
+	reloadErrorHandler func(err error)
+	auditHook          func(event AuditEvent)
+	deprecationHook    func(key, message string)
+	watchDebounce      time.Duration
+
+	// metrics holds a metricsSinkHolder; it is read while c.mu is held in any mode.
+	metrics atomic.Value
+	logger  atomic.Pointer[slog.Logger]
+	// freeze holds the options the configuration was frozen with, nil if not frozen.
+	freeze atomic.Pointer[FreezeOptions]
+	// secrets holds the normalized keys marked as secret, see WithSecret.
+	secrets atomic.Pointer[[]string]
+	// sensitiveKeys holds the patterns of the keys treated as secret, see WithSensitiveKeys.
+	sensitiveKeys []*regexp.Regexp
+	// decodeHooks holds the hooks registered with RegisterDecodeHook.
+	decodeHooks atomic.Pointer[[]DecodeHook]
+	// secretResolvers holds the resolvers registered with RegisterSecretResolver and
+	// RegisterSecretLeaseResolver, by backend.
+	secretResolvers atomic.Pointer[map[string]SecretLeaseResolver]
+	// secretCache holds the secrets resolved by secretResolvers.
+	secretCache secretCache
 
 	validate *validator.Validate
+	// structValidator, if set, validates bound structs instead of validate, see WithValidator.
+	structValidator Validator
+	// keys defines how keys are normalized, see WithKeyNormalizer.
+	keys keyFormat
+	// keySpellings maps normalized dotted keys to the original spelling of their last segment,
+	// nil unless WithPreserveKeyCase is given.
+	keySpellings map[string]string
+	// clock returns the current time, time.Now if nil, see WithClock.
+	clock func() time.Time
+	// withoutDefaultEnv disables the default environment variables provider, see WithoutDefaultEnv.
+	withoutDefaultEnv bool
+	// interpolation enables the resolution of references to other keys, see WithInterpolation.
+	interpolation bool
+	// envExpansion enables the expansion of environment variables, see WithEnvExpansion.
+	envExpansion bool
+	// fileReferences enables the expansion of references to files, see WithFileReferences.
+	fileReferences bool
+	// fileReferencesBaseDir is the directory referenced files must be within, see
+	// WithFileReferencesBaseDir.
+	fileReferencesBaseDir string
+	// fileReferencesAllowSymlinks allows referenced files to be symlinks, nil unless
+	// WithFileReferencesAllowSymlinks is given.
+	fileReferencesAllowSymlinks *bool
+	// baseDir is the directory built-in file providers read their files within, see WithBaseDir.
+	baseDir string
+	// allowSymlinks allows built-in file providers to read symlinks, nil unless WithAllowSymlinks
+	// is given.
+	allowSymlinks *bool
+	// maxFileSize is the size limit of the files read by built-in file providers, see WithMaxFileSize.
+	maxFileSize int64
+	// decryptionKey decrypts the encrypted values of providers, nil unless WithDecryptionKey or
+	// WithDecryptionKeyFunc is given.
+	decryptionKey *decryptionKey
+	// schema validates every loaded configuration if not nil, see WithSchema.
+	schema *jsonschema.Schema
+	// schemaErr is the error compiling the schema given to WithSchema, returned by every load.
+	schemaErr error
 }
 
-// New creates a new config instance with given providers.
+// providerEntry is a registered provider along with the values it last loaded.
+type providerEntry struct {
+	Provider
+
+	values map[string]any
+
+	// loadedAt is the time of the last successful load.
+	loadedAt time.Time
+	// lastErr is the error of the last load, nil if it succeeded.
+	lastErr error
+	// errCount is the number of failed loads.
+	errCount int
+	// defaultEnv reports whether the provider is the environment variables provider registered by
+	// default, whose values aren't expanded (see WithEnvExpansion).
+	defaultEnv bool
+}
+
+// New creates a new config instance with given providers and options (see Option).
+// Providers are merged in ascending priority order (see WithPriority), and in registration
+// order for providers with the same priority. Unless WithoutDefaultEnv is given, an environment
+// variables provider is registered if none is given.
 func New(providers ...Provider) *Config {
-	pvd := append([]Provider{}, providers...)
+	pvd := make([]*providerEntry, 0, len(providers)+1)
+
+	var options []Option
 
 	hasEnvProvider := false
 
 	for _, p := range providers {
+		// options are applied to the Config, not registered as providers
+		if opt, ok := p.(Option); ok {
+			if opt != nil {
+				options = append(options, opt)
+			}
+
+			continue
+		}
+
 		if p.Name() == envProviderName {
 			hasEnvProvider = true
-
-			break
 		}
+
+		pvd = append(pvd, &providerEntry{Provider: p})
 	}
 
-	if !hasEnvProvider {
-		pvd = append([]Provider{NewEnvProvider()}, pvd...)
+	c := &Config{
+		values:        make(map[string]any),
+		defaults:      make(map[string]any),
+		overrides:     make(map[string]any),
+		origins:       make(map[string]Origin),
+		providers:     pvd,
+		watchDebounce: defaultWatchDebounce,
+		validate:      validator.New(),
 	}
 
-	return &Config{
-		values:    make(map[string]any),
-		providers: pvd,
-		validate:  validator.New(),
+	for _, opt := range options {
+		opt(c)
+	}
+
+	for _, p := range c.providers {
+		propagateKeyDelimiter(p.Provider, c.keys.sep())
 	}
+
+	if !hasEnvProvider && !c.withoutDefaultEnv {
+		c.providers = append([]*providerEntry{c.defaultEnvProvider()}, c.providers...)
+	}
+
+	sortProviders(c.providers)
+
+	return c
+}
+
+// defaultEnvProvider returns the environment variables provider registered by default.
+func (c *Config) defaultEnvProvider() *providerEntry {
+	env := NewEnvProvider()
+	if logger := c.logger.Load(); logger != nil {
+		propagateLogger(env, logger)
+	}
+
+	return &providerEntry{Provider: env, defaultEnv: true}
+}
+
+// NewE creates a new config instance with given providers like New, but validates the providers
+// first, so misconfiguration is reported up front rather than by the first load, or not at all.
+//
+// It returns ErrNilProvider for nil providers, ErrDuplicateProvider if several providers have
+// the same name (which would make RemoveProvider and the provider statuses ambiguous), and
+// ErrInvalidProvider wrapping the error of providers implementing ValidatableProvider that
+// report a misconfiguration (e.g., an empty file path).
+func NewE(providers ...Provider) (*Config, error) {
+	names := make(map[string]struct{}, len(providers))
+
+	for i, p := range providers {
+		if p == nil {
+			return nil, fmt.Errorf("%w at index %d", ErrNilProvider, i)
+		}
+
+		if _, ok := p.(Option); ok {
+			continue
+		}
+
+		name := p.Name()
+		if _, exists := names[name]; exists {
+			return nil, fmt.Errorf("%w %q", ErrDuplicateProvider, name)
+		}
+
+		names[name] = struct{}{}
+
+		if vp, ok := asProvider[ValidatableProvider](p); ok {
+			if err := vp.Validate(); err != nil {
+				return nil, fmt.Errorf("%w %s: %w", ErrInvalidProvider, name, err)
+			}
+		}
+	}
+
+	return New(providers...), nil
 }
 
 // WithExtensions appends one or more extensions to the configuration and returns the updated Config instance.
@@ -72,76 +285,272 @@ func (c *Config) WithExtensions(extensions ...Extension) *Config {
 	return c
 }
 
+// AddProvider registers a provider after the Config was created. Its values are picked up by the
+// next Load, merged according to its priority (see WithPriority), after the existing providers
+// with the same priority. Options (see Option) are only applied by New, and are ignored rather
+// than registered as providers.
+func (c *Config) AddProvider(p Provider) {
+	if _, ok := p.(Option); ok {
+		return
+	}
+
+	if logger := c.logger.Load(); logger != nil {
+		propagateLogger(p, logger)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.propagateFileAccessTo(p)
+	propagateKeyDelimiter(p, c.keys.sep())
+
+	c.providers = append(c.providers, &providerEntry{Provider: p})
+	sortProviders(c.providers)
+}
+
+// RemoveProvider unregisters all providers with the given name and reports whether any was removed.
+// Their values are removed from the configuration by the next Load.
+func (c *Config) RemoveProvider(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	kept := c.providers[:0]
+
+	for _, p := range c.providers {
+		if p.Name() != name {
+			kept = append(kept, p)
+		}
+	}
+
+	removed := len(kept) != len(c.providers)
+
+	// Clear the tail so removed providers can be garbage collected.
+	for i := len(kept); i < len(c.providers); i++ {
+		c.providers[i] = nil
+	}
+
+	c.providers = kept
+
+	return removed
+}
+
+// Providers returns the names of the registered providers in merge order.
+func (c *Config) Providers() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	names := make([]string, 0, len(c.providers))
+	for _, p := range c.providers {
+		names = append(names, p.Name())
+	}
+
+	return names
+}
+
 // SetDefault sets a default value for the specified key in the configuration.
 // It creates nested maps if they do not exist, but does not override existing values.
 func (c *Config) SetDefault(key string, value any) {
-	if key == "" {
+	if key == "" || c.ignoreFrozen(AuditOpSetDefault, key) {
 		return
 	}
 
-	pathParts, finalKey := keyToPathParts(key)
+	pathParts, finalKey := c.keys.split(key)
+
+	var event *AuditEvent
 
 	c.mu.Lock()
-	defer c.mu.Unlock()
+
+	if defaultsMap := maps.FindNestedMap(c.defaults, pathParts, true); defaultsMap != nil {
+		if _, exists := defaultsMap[finalKey]; !exists {
+			defaultsMap[finalKey] = reflection.Clone(value)
+		}
+	}
 
 	finalMap := maps.FindNestedMap(c.values, pathParts, true)
 	if finalMap != nil {
 		// Only set the value if the key doesn't already exist
 		if _, exists := finalMap[finalKey]; !exists {
 			finalMap[finalKey] = value
+			c.recordValueOrigins(key, value, Origin{Provider: defaultsOriginName})
+			c.recordKeySpellings(key, value, false)
+			c.rev++
+
+			event = c.auditEvent(AuditOpSetDefault, key, nil, false, value)
 		}
 	}
+
+	c.mu.Unlock()
+
+	c.emitAudit(event)
 }
 
 // SetDefaults sets default configuration values from a struct or map without overriding existing values.
 // Returns an error if the input is invalid or nil.
 func (c *Config) SetDefaults(values any) error {
+	if err := c.checkFrozen(AuditOpSetDefaults); err != nil {
+		return err
+	}
+
 	if values == nil {
 		return ErrNilValues
 	}
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
+	var defaults map[string]any
+
+	switch val := values.(type) {
+	case map[string]any:
+		defaults = val
+	case *map[string]any:
+		defaults = *val
+	default:
+		defaults = make(map[string]any)
+		if err := maps.Unbind(values, defaults); err != nil {
+			return err
+		}
+	}
 
-	if val, ok := values.(map[string]any); ok {
-		maps.MergeWithoutOverride(c.values, val)
+	var events []*AuditEvent
 
-		return nil
-	}
+	c.mu.Lock()
 
-	if val, ok := values.(*map[string]any); ok {
-		maps.MergeWithoutOverride(c.values, *val)
+	c.keys.mergeWithoutOverride(c.defaults, reflection.Clone(defaults))
 
-		return nil
+	applied := reflection.Clone(c.values)
+	c.keys.mergeWithoutOverride(c.values, reflection.Clone(defaults))
+
+	if c.keySpellings != nil {
+		c.keys.recordSpellings(c.keySpellings, defaults, "", false)
 	}
 
-	tempValues := make(map[string]any)
-	if err := maps.Unbind(values, tempValues); err != nil {
-		return err
+	for _, change := range diffValues(applied, c.values, c.keys.sep()) {
+		c.origins[change.Key] = Origin{Provider: defaultsOriginName}
+		events = append(events, c.auditEvent(AuditOpSetDefaults, change.Key, nil, false, change.NewValue))
 	}
 
-	maps.LowercaseKeys(tempValues)
-	maps.MergeWithoutOverride(c.values, tempValues)
+	c.rev++
+	c.mu.Unlock()
+
+	c.emitAudit(events...)
 
 	return nil
 }
 
 // Set sets a value for the specified key in the configuration, overriding any existing value.
 // It creates nested maps if they do not exist.
+//
+// Values set via Set are kept across loads, unless a provider supplies the key, in which case the
+// next load replaces them with the provider value.
+//
+// Elements of existing arrays can be set by their index (e.g., Set("servers.1.port", 8081)); the
+// whole array, including the modified element, is then kept as an override.
 func (c *Config) Set(key string, value any) {
-	if key == "" {
+	if key == "" || c.ignoreFrozen(AuditOpSet, key) {
 		return
 	}
 
-	pathParts, finalKey := keyToPathParts(key)
+	pathParts, finalKey := c.keys.split(key)
+
+	var event *AuditEvent
 
 	c.mu.Lock()
-	defer c.mu.Unlock()
+
+	if n := maps.SlicePrefix(c.values, append(pathParts, finalKey)); n > 0 {
+		if oldValue, hadOld, ok := c.setIndexed(append(pathParts, finalKey), n, value); ok {
+			event = c.auditEvent(AuditOpSet, key, oldValue, hadOld, value)
+		}
+
+		c.mu.Unlock()
+
+		c.emitAudit(event)
+
+		return
+	}
 
 	finalMap := maps.FindNestedMap(c.values, pathParts, true)
 	if finalMap != nil {
+		oldValue, hadOld := finalMap[finalKey]
+		event = c.auditEvent(AuditOpSet, key, oldValue, hadOld, value)
+
 		finalMap[finalKey] = value
+
+		if overridesMap := maps.FindNestedMap(c.overrides, pathParts, true); overridesMap != nil {
+			overridesMap[finalKey] = reflection.Clone(value)
+		}
+
+		c.recordValueOrigins(key, value, Origin{Provider: overridesOriginName})
+		c.recordKeySpellings(key, value, true)
+		c.rev++
+	}
+
+	c.mu.Unlock()
+
+	c.emitAudit(event)
+}
+
+// setIndexed sets value at the path traversing an array, whose first n segments address the
+// array, and overrides the whole array. It returns the previous value and whether the value was
+// set. The caller must hold c.mu.
+func (c *Config) setIndexed(path []string, n int, value any) (oldValue any, hadOld, ok bool) {
+	oldValue, hadOld = maps.GetNested(c.values, path)
+	oldValue = reflection.Clone(oldValue)
+
+	if !maps.SetNestedIndexed(c.values, path, value) {
+		return nil, false, false
+	}
+
+	array, _ := maps.GetNested(c.values, path[:n])
+	maps.SetNested(c.overrides, path[:n], reflection.Clone(array))
+
+	arrayKey := c.keys.join(path[:n]...)
+	c.recordValueOrigins(arrayKey, array, Origin{Provider: overridesOriginName})
+	c.rev++
+
+	return oldValue, hadOld, true
+}
+
+// Delete removes the value at key, including sections (e.g., Delete("feature.flags.legacy")), and
+// prunes the parent sections left empty. It reports whether a value was removed.
+//
+// The key is removed from the defaults and the runtime overrides as well, so values set via Set
+// or SetDefault are retracted permanently, whereas values supplied by providers are restored by
+// the next load.
+func (c *Config) Delete(key string) bool {
+	if key == "" || c.ignoreFrozen(AuditOpDelete, key) {
+		return false
+	}
+
+	pathParts, finalKey := c.keys.split(key)
+	path := append(pathParts, finalKey)
+
+	var event *AuditEvent
+
+	c.mu.Lock()
+
+	maps.DeleteNested(c.defaults, path)
+	maps.DeleteNested(c.overrides, path)
+
+	oldValue := c.keys.lookup(c.values, key)
+
+	removed := maps.DeleteNested(c.values, path)
+	if removed {
+		normalized := c.keys.normalize(key)
+
+		for k := range c.origins {
+			if k == normalized || strings.HasPrefix(k, normalized+c.keys.sep()) {
+				delete(c.origins, k)
+			}
+		}
+
+		c.rev++
+
+		event = c.auditEvent(AuditOpDelete, key, oldValue, true, nil)
 	}
+
+	c.mu.Unlock()
+
+	c.emitAudit(event)
+
+	return removed
 }
 
 // Load loads configuration from all registered providers and applies pre/post-load hooks
@@ -155,37 +564,55 @@ func (c *Config) Load() error {
 // LoadWithContext loads configuration with the provided context, executing pre-load and post-load
 // hooks for extensions.
 func (c *Config) LoadWithContext(ctx context.Context) error {
-	for _, ext := range c.extensions {
-		if err := ext.PreLoad(ctx, c); err != nil {
-			return fmt.Errorf("%w %s: %w", ErrExtensionPreLoadHookFailed, ext.Name(), err)
-		}
+	if err := c.checkFrozen("Load"); err != nil {
+		return err
 	}
 
-	c.mu.Lock()
+	return c.reload(ctx, c.loadProviders)
+}
+
+// loadProviders loads the values of all registered providers.
+func (c *Config) loadProviders() (map[*providerEntry]map[string]any, error) {
+	c.mu.RLock()
+	providers := append([]*providerEntry{}, c.providers...)
+	c.mu.RUnlock()
+
+	results := make(map[*providerEntry]map[string]any, len(providers))
+
+	logger := c.log()
+
+	for _, p := range providers {
+		start := time.Now()
 
-	for _, p := range c.providers {
 		values, err := p.Load()
 		if err != nil {
+			c.mu.Lock()
+			p.lastErr = err
+			p.errCount++
 			c.mu.Unlock()
 
-			return fmt.Errorf("%w %s: %w", ErrProviderLoadFailed, p.Name(), err)
-		}
-		// Merge values, later providers override
-		maps.Merge(c.values, values)
-	}
+			if sink := c.metricsSink(); sink != nil {
+				sink.IncProviderFailure(p.Name())
+			}
 
-	c.mu.Unlock()
+			logger.Warn("gcfg: provider load failed", "provider", p.Name(), "error", err)
 
-	for _, ext := range c.extensions {
-		if err := ext.PostLoad(ctx, c); err != nil {
-			return fmt.Errorf("%w %s: %w", ErrExtensionPostLoadHookFailed, ext.Name(), err)
+			return nil, fmt.Errorf("%w %s: %w", ErrProviderLoadFailed, p.Name(), err)
 		}
+
+		logger.Debug("gcfg: provider loaded",
+			"provider", p.Name(),
+			"keys", len(maps.Flatten(values, c.keys.sep())),
+			"duration", time.Since(start),
+		)
+
+		results[p] = values
 	}
 
-	return nil
+	return results, nil
 }
 
-// Bind binds the configuration to the provided struct.
+// Bind binds the configuration to the provided struct, or map (e.g., a map[string]ServiceConfig).
 func (c *Config) Bind(dest any, options ...BindOption) error {
 	opts := BindOptions{
 		validate: true,
@@ -196,94 +623,302 @@ func (c *Config) Bind(dest any, options ...BindOption) error {
 	}
 
 	c.mu.RLock()
-	err := maps.Bind(c.values, dest)
+	deprecated, err := c.bindValues("", c.values, dest, opts)
 	c.mu.RUnlock()
 
-	if err != nil {
-		return err
+	c.warnDeprecated(deprecated)
+
+	return err
+}
+
+// Load creates a Config with the given providers, loads it, and binds and validates the
+// configuration into a new T, which must be a struct type.
+// It is a shorthand for the common New, Load and Bind sequence:
+//
+//	cfg, err := gcfg.Load[AppConfig](gcfg.NewJSONProvider(gcfg.WithJSONFilePath("config.json")))
+func Load[T any](providers ...Provider) (T, error) {
+	var dest T
+
+	c := New(providers...)
+
+	if err := c.Load(); err != nil {
+		return dest, err
 	}
 
+	if err := c.Bind(&dest); err != nil {
+		var zero T
+
+		return zero, err
+	}
+
+	return dest, nil
+}
+
+// BindKey binds the configuration section at key (e.g., "server") to the provided struct, the same
+// way as Bind, so modules can own their configuration structs. Arrays and maps can be bound to
+// slices and maps as well, e.g. cfg.BindKey("servers", &servers) with servers a []ServerConfig.
+// It is a shorthand for c.Sub(key).Bind(dest, options...).
+func (c *Config) BindKey(key string, dest any, options ...BindOption) error {
+	return c.Sub(key).Bind(dest, options...)
+}
+
+// bindValues binds values, the section at the dotted key prefix, to dest according to opts and
+// optionally validates the result, joining the errors of all the invalid keys. The fields of dest
+// tagged `secret:"true"` are marked as secret.
+// It returns the deprecated keys that were bound, to be reported with warnDeprecated.
+func (c *Config) bindValues(prefix string, values any, dest any, opts BindOptions) ([]deprecatedKey, error) {
+	c.markSecretFields(prefix, dest, opts)
+
+	var deprecated []deprecatedKey
+
+	binderOpts := opts.binder()
+	binderOpts.KeySeparator = c.keys.sep()
+	binderOpts.KeyPrefix = prefix
+	binderOpts.DecodeHooks = c.binderDecodeHooks(opts.decodeHooks)
+	binderOpts.OnDeprecated = func(key, message string) {
+		deprecated = append(deprecated, deprecatedKey{key: key, message: message})
+	}
+
+	if opts.unusedKeys != nil {
+		*opts.unusedKeys = nil
+		binderOpts.OnUnusedKey = func(key string) {
+			*opts.unusedKeys = append(*opts.unusedKeys, key)
+		}
+	}
+
+	// binding and validation errors are reported together, so that all the invalid keys can be
+	// fixed at once
+	err := maps.BindValue(values, dest, binderOpts)
 	if opts.validate {
-		if vErr := c.validate.Struct(dest); vErr != nil {
-			return vErr
+		err = errors.Join(err, c.validateValue(dest))
+	}
+
+	if opts.unusedKeys != nil {
+		slices.Sort(*opts.unusedKeys)
+	}
+
+	if err != nil {
+		if sink := c.metricsSink(); sink != nil {
+			sink.IncBindError()
 		}
+
+		return nil, err
 	}
 
-	return nil
+	return deprecated, nil
 }
 
-// Get retrieves a configuration value by key. Supports hierarchical paths like "database.host".
+// Get retrieves a configuration value by key. Supports hierarchical paths like "database.host",
+// and array elements addressed by their index like "servers.0.host".
 func (c *Config) Get(key string) any {
 	if key == "" {
 		return nil
 	}
 
-	pathParts, finalKey := keyToPathParts(key)
-
 	c.mu.RLock()
-	defer c.mu.RUnlock()
 
-	finalMap := maps.FindNestedMap(c.values, pathParts, false)
-	if finalMap != nil {
-		return reflection.Clone(finalMap[finalKey])
+	value := c.keys.lookup(c.values, key)
+	if c.hasSecretResolvers() {
+		// secret references are resolved in a copy, once the lock is released
+		value = reflection.Clone(value)
 	}
 
-	return nil
+	c.mu.RUnlock()
+
+	return c.resolveSecretRefs(key, value)
 }
 
 // Find searches for and retrieves a configuration value by key.
-// Supports hierarchical paths like "database.host".
+// Supports hierarchical paths like "database.host", and array elements like "servers.0.host".
 func (c *Config) Find(key string) (value any, exist bool) {
 	if key == "" {
 		return value, exist
 	}
 
-	pathParts, finalKey := keyToPathParts(key)
+	c.mu.RLock()
 
+	if value, exist = c.keys.find(c.values, key); exist {
+		value = reflection.Clone(value)
+	}
+
+	c.mu.RUnlock()
+
+	if exist {
+		value = c.resolveSecretRefs(key, value)
+	}
+
+	return value, exist
+}
+
+// Version returns the generation of the configuration, which is incremented on every successful
+// load and every modification (Set, SetDefault, SetDefaults). Callers can use it to cheaply detect
+// stale state and to cache derived values.
+func (c *Config) Version() uint64 {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	finalMap := maps.FindNestedMap(c.values, pathParts, false)
-	if finalMap != nil {
-		var found any
-		if found, exist = finalMap[finalKey]; exist {
-			value = reflection.Clone(found)
+	return c.rev
+}
 
-			return value, exist
-		}
+// IsSet reports whether key resolves to a value in the configuration, be it a leaf value, a nil
+// value or a section (e.g., IsSet("database") is true if any "database.*" key is set).
+func (c *Config) IsSet(key string) bool {
+	if key == "" {
+		return false
 	}
 
-	return value, exist
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, exists := c.keys.find(c.values, key)
+
+	return exists
+}
+
+// AllKeys returns the dotted paths of all leaf values (e.g., "database.host"), sorted.
+func (c *Config) AllKeys() []string {
+	c.mu.RLock()
+	flat := maps.Flatten(c.values, c.keys.sep())
+	c.mu.RUnlock()
+
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// AllSettings returns a copy of all leaf values keyed by their dotted path (e.g., "database.host"),
+// the flat counterpart of Values.
+func (c *Config) AllSettings() map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return reflection.Clone(maps.Flatten(c.values, c.keys.sep()))
+}
+
+// Walk calls fn for every leaf value with its dotted path, in sorted path order, until fn returns
+// false. fn receives copies of the values and may safely call back into the Config.
+func (c *Config) Walk(fn func(path string, value any) bool) {
+	settings := c.AllSettings()
+
+	paths := make([]string, 0, len(settings))
+	for path := range settings {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		if !fn(path, settings[path]) {
+			return
+		}
+	}
 }
 
-// Values returns the configuration values.
+// Values returns the configuration values, with the values of keys marked as secret (see
+// WithSecret) masked. With WithPreserveKeyCase, keys are returned in their original spelling.
 func (c *Config) Values() map[string]any {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	return reflection.Clone(c.values)
+	return c.exportValues(c.redactValues(reflection.Clone(c.values), "", false))
 }
 
-func keyToPathParts(key string) (pathParts []string, finalKey string) {
-	parts := strings.Split(strings.ToLower(key), ".")
-	for i := range parts {
-		parts[i] = strings.TrimSpace(parts[i])
+// exportValues returns values as returned by Values, restoring the original spelling of keys
+// with WithPreserveKeyCase. values is modified. The caller must hold c.mu.
+func (c *Config) exportValues(values map[string]any) map[string]any {
+	if c.keySpellings != nil {
+		return spell(values, c.keySpellings, "", c.keys.sep())
 	}
 
-	return parts[:len(parts)-1], parts[len(parts)-1]
+	return values
 }
 
 // BindOptions defines options for binding configuration data to a struct.
 type BindOptions struct {
-	validate bool
+	validate       bool
+	sliceSeparator string
+	tagNames       []string
+	decodeHooks    []DecodeHook
+	durationUnit   time.Duration
+	unusedKeys     *[]string
 }
 
 // BindOption is a functional option for configuring Bind behavior by modifying BindOptions.
 type BindOption func(*BindOptions)
 
+// binder returns the options of the underlying binder.
+func (o BindOptions) binder() maps.BindOptions {
+	return maps.BindOptions{
+		SliceSeparator: o.sliceSeparator,
+		TagNames:       o.tagNames,
+		DurationUnit:   o.durationUnit,
+	}
+}
+
 // WithValidate sets the validation flag in the BindOptions.
 func WithValidate(validate bool) BindOption {
 	return func(c *BindOptions) {
 		c.validate = validate
 	}
 }
+
+// WithTagName sets the struct tag naming the configuration key of a field, consulted before the
+// json tag, e.g. WithTagName("config") for `config:"db_host"`. This keeps the configuration key
+// mapping independent of JSON serialization names.
+//
+// Default: "gcfg".
+func WithTagName(name string) BindOption {
+	return WithTagNames(name, "json")
+}
+
+// WithTagNames sets the ordered list of struct tags consulted for the configuration key of a
+// field, before falling back to its case-insensitive name, so structs already annotated for other
+// libraries can be bound without re-tagging:
+//
+//	cfg.Bind(&appCfg, gcfg.WithTagNames("gcfg", "json", "yaml", "toml", "mapstructure"))
+//
+// Default: "gcfg", "json".
+func WithTagNames(names ...string) BindOption {
+	return func(c *BindOptions) {
+		c.tagNames = slices.Clone(names)
+		if c.tagNames == nil {
+			c.tagNames = []string{}
+		}
+	}
+}
+
+// WithSliceSeparator makes Bind split string values bound to slice fields on separator, following
+// the convention for list-valued environment variables (e.g., HOSTS="a,b,c" binds to
+// []string{"a", "b", "c"}). Items are trimmed and empty items are dropped.
+//
+// Default: "" (string values are not split).
+func WithSliceSeparator(separator string) BindOption {
+	return func(c *BindOptions) {
+		c.sliceSeparator = separator
+	}
+}
+
+// WithDurationUnit sets the unit of the bare numbers bound to time.Duration fields, e.g.
+// WithDurationUnit(time.Second) binds TIMEOUT=30 to 30 seconds. Duration strings such as "1m30s"
+// are always parsed with time.ParseDuration.
+//
+// Default: time.Nanosecond.
+func WithDurationUnit(unit time.Duration) BindOption {
+	return func(c *BindOptions) {
+		c.durationUnit = unit
+	}
+}
+
+// WithUnusedKeys makes Bind store in keys the sorted dotted keys of the configuration values that no
+// struct field consumed (e.g., "legacy" or "server.old_timeout"), so stale configuration can be
+// reported. A section without a matching field is reported as a single key.
+func WithUnusedKeys(keys *[]string) BindOption {
+	return func(c *BindOptions) {
+		c.unusedKeys = keys
+	}
+}
@@ -2,7 +2,11 @@ package gcfg_test
 
 import (
 	"errors"
+	"log/slog"
+	"net/netip"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/ahmedkamalio/gcfg"
 	"github.com/stretchr/testify/assert"
@@ -129,6 +133,426 @@ func TestConfig_Bind_WithValidate(t *testing.T) {
 	assert.Contains(t, err.Error(), "max")
 }
 
+func TestConfig_Bind_WithSliceSeparator(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"hosts": "a.example.com, b.example.com",
+		"ports": "8080,8081",
+	}})
+
+	require.NoError(t, cfg.Load())
+
+	obj := struct {
+		Hosts []string
+		Ports []int
+	}{}
+
+	require.Error(t, cfg.Bind(&obj))
+	require.NoError(t, cfg.Bind(&obj, gcfg.WithSliceSeparator(",")))
+
+	assert.Equal(t, []string{"a.example.com", "b.example.com"}, obj.Hosts)
+	assert.Equal(t, []int{8080, 8081}, obj.Ports)
+}
+
+func TestConfig_Bind_Required(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{"host": "localhost"},
+	}})
+
+	require.NoError(t, cfg.Load())
+
+	obj := struct {
+		Database struct {
+			Host     string
+			Password string `required:"true"`
+		}
+	}{}
+
+	err := cfg.Bind(&obj)
+	require.ErrorIs(t, err, gcfg.ErrMissingRequiredKey)
+	assert.Contains(t, err.Error(), "missing key database.password")
+
+	cfg.Set("database.password", "secret")
+	require.NoError(t, cfg.Bind(&obj))
+	assert.Equal(t, "secret", obj.Database.Password)
+}
+
+func TestConfig_Bind_DefaultTag(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"server": map[string]any{"host": "localhost"},
+	}})
+
+	require.NoError(t, cfg.Load())
+
+	obj := struct {
+		Server struct {
+			Host string `default:"0.0.0.0"`
+			Port int    `default:"8080" validate:"min=1024"`
+		}
+	}{}
+
+	require.NoError(t, cfg.Bind(&obj))
+	assert.Equal(t, "localhost", obj.Server.Host)
+	assert.Equal(t, 8080, obj.Server.Port)
+}
+
+func TestConfig_Bind_JoinsErrors(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"server":   map[string]any{"port": "http", "timeout": "soon"},
+		"database": map[string]any{"pool": 0},
+	}})
+
+	require.NoError(t, cfg.Load())
+
+	obj := struct {
+		Server struct {
+			Port    int
+			Timeout time.Duration
+		}
+		Database struct {
+			Host string `validate:"required"`
+			Pool int    `validate:"min=1"`
+		}
+	}{}
+
+	err := cfg.Bind(&obj)
+	require.Error(t, err)
+
+	// conversion and validation failures are reported at once
+	for _, key := range []string{"Port", "Timeout", "Host", "Pool"} {
+		assert.Contains(t, err.Error(), key)
+	}
+}
+
+func TestConfig_BindKey_BindError(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"server": map[string]any{"port": "http"},
+	}})
+
+	require.NoError(t, cfg.Load())
+
+	var srv struct {
+		Port int
+	}
+
+	err := cfg.BindKey("server", &srv)
+
+	var bindErr *gcfg.BindError
+
+	require.ErrorAs(t, err, &bindErr)
+	assert.Equal(t, "server.port", bindErr.Key)
+	assert.Equal(t, "Port", bindErr.Field)
+	assert.Equal(t, reflect.TypeFor[int](), bindErr.Type)
+	assert.Equal(t, reflect.TypeFor[string](), bindErr.SourceType)
+}
+
+func TestConfig_Bind_WithUnusedKeys(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"server": map[string]any{"host": "localhost", "old_timeout": 30},
+		"legacy": map[string]any{"url": "http://old"},
+	}}, gcfg.WithoutDefaultEnv())
+
+	require.NoError(t, cfg.Load())
+
+	var obj struct {
+		Server struct {
+			Host string
+		}
+	}
+
+	unused := []string{"stale"}
+
+	require.NoError(t, cfg.Bind(&obj, gcfg.WithUnusedKeys(&unused)))
+	assert.Equal(t, []string{"legacy", "server.old_timeout"}, unused)
+
+	require.NoError(t, cfg.BindKey("server", &obj.Server, gcfg.WithUnusedKeys(&unused)))
+	assert.Equal(t, []string{"server.old_timeout"}, unused)
+}
+
+func TestConfig_Bind_Alias(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"server": map[string]any{"timeout_seconds": 30},
+	}})
+
+	require.NoError(t, cfg.Load())
+
+	obj := struct {
+		Server struct {
+			Timeout int `gcfg:"timeout" alias:"timeout_seconds,request_timeout" required:"true"`
+		}
+	}{}
+
+	require.NoError(t, cfg.Bind(&obj))
+	assert.Equal(t, 30, obj.Server.Timeout)
+}
+
+func TestConfig_Bind_TextUnmarshaler(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"log":    map[string]any{"level": "warn"},
+		"server": map[string]any{"addr": "127.0.0.1"},
+	}})
+
+	require.NoError(t, cfg.Load())
+
+	obj := struct {
+		Log struct {
+			Level slog.Level
+		}
+		Server struct {
+			Addr netip.Addr
+		}
+	}{}
+
+	require.NoError(t, cfg.Bind(&obj))
+	assert.Equal(t, slog.LevelWarn, obj.Log.Level)
+	assert.Equal(t, netip.MustParseAddr("127.0.0.1"), obj.Server.Addr)
+}
+
+func TestConfig_Bind_WithDurationUnit(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"read_timeout":  "30",
+		"write_timeout": "1m",
+	}})
+
+	require.NoError(t, cfg.Load())
+
+	obj := struct {
+		ReadTimeout  time.Duration `json:"read_timeout"`
+		WriteTimeout time.Duration `json:"write_timeout"`
+	}{}
+
+	require.NoError(t, cfg.Bind(&obj))
+	assert.Equal(t, 30*time.Nanosecond, obj.ReadTimeout)
+	assert.Equal(t, time.Minute, obj.WriteTimeout)
+
+	require.NoError(t, cfg.Bind(&obj, gcfg.WithDurationUnit(time.Second)))
+	assert.Equal(t, 30*time.Second, obj.ReadTimeout)
+	assert.Equal(t, time.Minute, obj.WriteTimeout)
+}
+
+func TestConfig_Bind_TimeLayout(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"maintenance": map[string]any{
+			"starts_at":  "2025-03-01T22:00:00+01:00",
+			"expires_on": "2025-03-02",
+		},
+	}})
+
+	require.NoError(t, cfg.Load())
+
+	obj := struct {
+		Maintenance struct {
+			StartsAt  time.Time `json:"starts_at" tz:"UTC"`
+			ExpiresOn time.Time `json:"expires_on" layout:"2006-01-02"`
+		}
+	}{}
+
+	require.NoError(t, cfg.Bind(&obj))
+	assert.Equal(t, time.Date(2025, 3, 1, 21, 0, 0, 0, time.UTC), obj.Maintenance.StartsAt)
+	assert.Equal(t, time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC), obj.Maintenance.ExpiresOn)
+}
+
+func TestConfig_Bind_WithTagName(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"db_host": "localhost",
+		"db_port": 5432,
+	}})
+
+	require.NoError(t, cfg.Load())
+
+	obj := struct {
+		Host string `gcfg:"db_host" json:"host"`
+		Port int    `config:"db_port" json:"port"`
+	}{}
+
+	require.NoError(t, cfg.Bind(&obj))
+	assert.Equal(t, "localhost", obj.Host)
+	assert.Zero(t, obj.Port)
+
+	require.NoError(t, cfg.Bind(&obj, gcfg.WithTagName("config")))
+	assert.Equal(t, 5432, obj.Port)
+}
+
+func TestConfig_Bind_WithTagNames(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"db_host":   "localhost",
+		"db_port":   5432,
+		"pool_size": 10,
+		"timeout":   30,
+	}})
+
+	require.NoError(t, cfg.Load())
+
+	obj := struct {
+		Host     string `yaml:"db_host"`
+		Port     int    `mapstructure:"db_port" yaml:"port"`
+		PoolSize int    `toml:"pool_size"`
+		Timeout  int    `yaml:"-"`
+	}{}
+
+	require.NoError(t, cfg.Bind(&obj, gcfg.WithTagNames("gcfg", "json", "yaml", "toml", "mapstructure")))
+	assert.Equal(t, "localhost", obj.Host)
+	assert.Zero(t, obj.Port) // yaml:"port" takes precedence over mapstructure
+	assert.Equal(t, 10, obj.PoolSize)
+	assert.Equal(t, 30, obj.Timeout)
+
+	obj.Port = 0
+	require.NoError(t, cfg.Bind(&obj, gcfg.WithTagNames("mapstructure")))
+	assert.Equal(t, 5432, obj.Port)
+}
+
+func TestNewE(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := gcfg.NewE(
+		gcfg.NewJSONProvider(gcfg.WithJSONFilePath("config.json")),
+		&mockProvider{name: "mock"},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Environment Variables", "JSON", "mock"}, cfg.Providers())
+
+	_, err = gcfg.NewE(&mockProvider{name: "mock"}, nil)
+	require.ErrorIs(t, err, gcfg.ErrNilProvider)
+
+	_, err = gcfg.NewE(&mockProvider{name: "mock"}, gcfg.WithPriority(&mockProvider{name: "mock"}, 1))
+	require.ErrorIs(t, err, gcfg.ErrDuplicateProvider)
+	assert.Contains(t, err.Error(), `"mock"`)
+
+	// Decorated providers are validated too.
+	_, err = gcfg.NewE(gcfg.WithPriority(gcfg.NewJSONProvider(), 1))
+	require.ErrorIs(t, err, gcfg.ErrInvalidProvider)
+	require.ErrorIs(t, err, gcfg.ErrJSONFilePathNotSet)
+	assert.Contains(t, err.Error(), "JSON")
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	type appConfig struct {
+		Name   string `validate:"required"`
+		Server struct {
+			Port int
+		}
+	}
+
+	cfg, err := gcfg.Load[appConfig](&mockProvider{name: "mock", data: map[string]any{
+		"name":   "app",
+		"server": map[string]any{"port": 8080},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "app", cfg.Name)
+	assert.Equal(t, 8080, cfg.Server.Port)
+
+	cfg, err = gcfg.Load[appConfig](&mockProvider{name: "mock", data: map[string]any{
+		"server": map[string]any{"port": 8080},
+	}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Name")
+	assert.Zero(t, cfg)
+
+	//nolint:err113
+	_, err = gcfg.Load[appConfig](&mockProvider{name: "mock", err: errors.New("unreachable")})
+	require.ErrorIs(t, err, gcfg.ErrProviderLoadFailed)
+}
+
+func TestConfig_BindKey(t *testing.T) {
+	t.Parallel()
+
+	mockP1 := &mockProvider{name: "mock", data: map[string]any{
+		"server": map[string]any{
+			"host": "0.0.0.0",
+			"port": 8080,
+		},
+		"name": "app",
+	}}
+
+	cfg := gcfg.New(mockP1)
+
+	require.NoError(t, cfg.Load())
+
+	srv := struct {
+		Host string `validate:"required"`
+		Port int    `validate:"min=1,max=65535"`
+	}{}
+
+	require.NoError(t, cfg.BindKey("server", &srv))
+	assert.Equal(t, "0.0.0.0", srv.Host)
+	assert.Equal(t, 8080, srv.Port)
+
+	cfg.Set("server.port", 70000)
+
+	err := cfg.BindKey("server", &srv)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Port")
+
+	require.NoError(t, cfg.BindKey("server", &srv, gcfg.WithValidate(false)))
+	assert.Equal(t, 70000, srv.Port)
+}
+
+func TestConfig_BindKey_SliceAndMap(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"servers": []any{
+			map[string]any{"host": "a.internal", "port": 80},
+			map[string]any{"host": "b.internal", "port": "443"},
+		},
+		"limits": map[string]any{"api": 100, "admin": "10"},
+	}})
+
+	require.NoError(t, cfg.Load())
+
+	type server struct {
+		Host string `validate:"required"`
+		Port int    `validate:"min=1,max=65535"`
+	}
+
+	var servers []server
+
+	require.NoError(t, cfg.BindKey("servers", &servers))
+	assert.Equal(t, []server{{Host: "a.internal", Port: 80}, {Host: "b.internal", Port: 443}}, servers)
+
+	var limits map[string]int
+
+	require.NoError(t, cfg.BindKey("limits", &limits))
+	assert.Equal(t, map[string]int{"api": 100, "admin": 10}, limits)
+
+	var missing []server
+
+	require.NoError(t, cfg.BindKey("missing", &missing))
+	assert.Nil(t, missing)
+
+	// elements are validated
+	cfg.Set("servers.1.port", 70000)
+
+	err := cfg.BindKey("servers", &servers)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Port")
+}
+
 func TestConfig_Get(t *testing.T) {
 	t.Parallel()
 
@@ -589,6 +1013,45 @@ func TestConfig_Set_OverrideNested(t *testing.T) {
 	assert.Equal(t, 30, cfg.Get("database.timeout"))        // new value added
 }
 
+func TestConfig_Set_ArrayIndex(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"servers": []any{
+			map[string]any{"host": "a", "port": 8080},
+			map[string]any{"host": "b", "port": 8080},
+		},
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "b", cfg.Get("servers.1.host"))
+	assert.True(t, cfg.IsSet("servers.0.port"))
+	assert.False(t, cfg.IsSet("servers.2.port"))
+	assert.Nil(t, cfg.Get("servers.x.host"))
+
+	cfg.Set("servers.1.port", 8081)
+	cfg.Set("servers.2.port", 8082) // out of range, ignored
+
+	assert.Equal(t, 8081, cfg.Get("servers.1.port"))
+	assert.Len(t, cfg.Get("servers"), 2)
+
+	origin, ok := cfg.Origin("servers")
+	require.True(t, ok)
+	assert.Equal(t, "Overrides", origin.Provider)
+
+	// The modified array is kept across loads, until a provider supplies it again.
+	provider.data = map[string]any{}
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, 8081, cfg.Get("servers.1.port"))
+	assert.Equal(t, 8080, cfg.Get("servers.0.port"))
+
+	provider.data = map[string]any{"servers": []any{map[string]any{"host": "c", "port": 8080}}}
+	require.NoError(t, cfg.Load())
+	assert.Len(t, cfg.Get("servers"), 1)
+}
+
 func TestConfig_Find_Basic(t *testing.T) {
 	t.Parallel()
 
@@ -737,3 +1200,230 @@ func TestConfig_SetDefaults_NoOverride_Complex(t *testing.T) {
 	assert.Equal(t, true, cfg.Get("cache.enabled"))
 	assert.Equal(t, 300, cfg.Get("cache.ttl"))
 }
+
+func TestConfig_AddProvider(t *testing.T) {
+	t.Parallel()
+
+	mockP1 := &mockProvider{name: "mock1", data: map[string]any{
+		"remote": map[string]any{"address": "localhost:8500"},
+	}}
+	cfg := gcfg.New(mockP1)
+
+	require.NoError(t, cfg.Load())
+
+	mockP2 := &mockProvider{name: "mock2", data: map[string]any{
+		"feature": "enabled",
+		"remote":  map[string]any{"address": "ignored"},
+	}}
+	cfg.AddProvider(gcfg.WithPriority(mockP2, -1))
+
+	assert.Nil(t, cfg.Get("feature"))
+
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "enabled", cfg.Get("feature"))
+	// The lower priority provider doesn't override existing values.
+	assert.Equal(t, "localhost:8500", cfg.Get("remote.address"))
+	assert.Equal(t, []string{"mock2", "Environment Variables", "mock1"}, cfg.Providers())
+}
+
+func TestConfig_RemoveProvider(t *testing.T) {
+	t.Parallel()
+
+	mockP1 := &mockProvider{name: "mock1", data: map[string]any{"key1": "value1"}}
+	mockP2 := &mockProvider{name: "mock2", data: map[string]any{"key2": "value2"}}
+	cfg := gcfg.New(mockP1, mockP2)
+
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, "value2", cfg.Get("key2"))
+
+	assert.True(t, cfg.RemoveProvider("mock2"))
+	assert.False(t, cfg.RemoveProvider("mock2"))
+
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "value1", cfg.Get("key1"))
+	assert.Nil(t, cfg.Get("key2"))
+}
+
+func TestConfig_LayersSurviveLoad(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"host": "provider",
+		"port": 8080,
+	}}
+	cfg := gcfg.New(mockP)
+
+	cfg.SetDefault("host", "default")
+	cfg.SetDefault("timeout", 30)
+	cfg.Set("level", "debug")
+
+	require.NoError(t, cfg.Load())
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "provider", cfg.Get("host"))
+	assert.Equal(t, 30, cfg.Get("timeout"))
+	assert.Equal(t, "debug", cfg.Get("level"))
+
+	// Set values are replaced by the values the providers supply on the next load.
+	cfg.Set("port", 9090)
+	assert.Equal(t, 9090, cfg.Get("port"))
+
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, 8080, cfg.Get("port"))
+
+	origin, ok := cfg.Origin("port")
+	require.True(t, ok)
+	assert.Equal(t, "mock", origin.Provider)
+
+	// Keys the providers no longer supply are kept.
+	mockP.data = map[string]any{"host": "provider"}
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, 8080, cfg.Get("port"))
+}
+
+func TestConfig_Version(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{"key": "value"}})
+	assert.Equal(t, uint64(0), cfg.Version())
+
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, uint64(1), cfg.Version())
+
+	cfg.Set("key", "override")
+	assert.Equal(t, uint64(2), cfg.Version())
+
+	cfg.SetDefault("other", "default")
+	assert.Equal(t, uint64(3), cfg.Version())
+
+	// Failed loads don't change the version.
+	failing := gcfg.New(&mockProvider{name: "failing", err: assert.AnError})
+	require.Error(t, failing.Load())
+	assert.Equal(t, uint64(0), failing.Version())
+}
+
+func TestConfig_IsSet(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{
+			"host":    "localhost",
+			"options": map[string]any{},
+		},
+		"debug":    false,
+		"optional": nil,
+	}})
+	require.NoError(t, cfg.Load())
+
+	assert.True(t, cfg.IsSet("database"))
+	assert.True(t, cfg.IsSet("Database.Host"))
+	assert.True(t, cfg.IsSet("database.options"))
+	assert.True(t, cfg.IsSet("debug"))
+	assert.True(t, cfg.IsSet("optional"))
+
+	assert.False(t, cfg.IsSet(""))
+	assert.False(t, cfg.IsSet("missing"))
+	assert.False(t, cfg.IsSet("database.port"))
+	assert.False(t, cfg.IsSet("debug.nested"))
+	assert.False(t, cfg.IsSet("database.host.nested"))
+}
+
+func TestConfig_AllKeys(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "Environment Variables", data: map[string]any{
+		"server":   map[string]any{"port": 8080, "host": "0.0.0.0"},
+		"database": map[string]any{"host": "localhost"},
+		"debug":    true,
+	}})
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, []string{"database.host", "debug", "server.host", "server.port"}, cfg.AllKeys())
+	assert.Empty(t, gcfg.New(&mockProvider{name: "Environment Variables"}).AllKeys())
+}
+
+func TestConfig_AllSettings(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "Environment Variables", data: map[string]any{
+		"server": map[string]any{"port": 8080, "hosts": []any{"a", "b"}},
+		"debug":  true,
+	}})
+	require.NoError(t, cfg.Load())
+
+	settings := cfg.AllSettings()
+	assert.Equal(t, map[string]any{
+		"server.port":  8080,
+		"server.hosts": []any{"a", "b"},
+		"debug":        true,
+	}, settings)
+
+	// The returned values are copies.
+	settings["server.hosts"].([]any)[0] = "changed"
+	assert.Equal(t, []any{"a", "b"}, cfg.Get("server.hosts"))
+}
+
+func TestConfig_Walk(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "Environment Variables", data: map[string]any{
+		"server":   map[string]any{"port": 8080, "host": "0.0.0.0"},
+		"database": map[string]any{"host": "localhost"},
+		"debug":    true,
+	}})
+	require.NoError(t, cfg.Load())
+
+	var visited []string
+
+	cfg.Walk(func(path string, value any) bool {
+		visited = append(visited, path)
+		assert.Equal(t, cfg.Get(path), value)
+
+		return true
+	})
+	assert.Equal(t, []string{"database.host", "debug", "server.host", "server.port"}, visited)
+
+	visited = nil
+
+	cfg.Walk(func(path string, _ any) bool {
+		visited = append(visited, path)
+
+		return len(visited) < 2
+	})
+	assert.Equal(t, []string{"database.host", "debug"}, visited)
+}
+
+func TestConfig_Delete(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "Environment Variables", data: map[string]any{
+		"feature": map[string]any{"flags": map[string]any{"legacy": true}},
+		"server":  map[string]any{"port": 8080},
+	}})
+	require.NoError(t, cfg.Load())
+
+	cfg.Set("server.host", "0.0.0.0")
+
+	version := cfg.Version()
+
+	assert.True(t, cfg.Delete("Feature.Flags.Legacy"))
+	assert.False(t, cfg.IsSet("feature.flags.legacy"))
+	assert.False(t, cfg.IsSet("feature"), "empty parents are pruned")
+	assert.Greater(t, cfg.Version(), version)
+
+	_, ok := cfg.Origin("feature.flags.legacy")
+	assert.False(t, ok)
+
+	assert.False(t, cfg.Delete("feature.flags.legacy"))
+	assert.False(t, cfg.Delete(""))
+
+	// Deleted overrides are retracted for good, provider values come back on the next load.
+	assert.True(t, cfg.Delete("server"))
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, 8080, cfg.Get("server.port"))
+	assert.False(t, cfg.IsSet("server.host"))
+	assert.True(t, cfg.IsSet("feature.flags.legacy"))
+}
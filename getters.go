@@ -0,0 +1,239 @@
+package gcfg
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+)
+
+// ErrValueConversionFailed indicates failure to convert a configuration value to the requested type.
+var ErrValueConversionFailed = errors.New("failed to convert value")
+
+// sliceSeparator separates the items of slice values given as strings (e.g., from env vars).
+const sliceSeparator = ","
+
+// GetString retrieves a configuration value by key as a string.
+// Returns an empty string if the key is absent or the value cannot be converted.
+func (c *Config) GetString(key string) string {
+	return getAs[string](c, key)
+}
+
+// GetInt retrieves a configuration value by key as an int, converting numeric strings
+// (e.g., "5432") and floats. Returns 0 if the key is absent or the value cannot be converted.
+func (c *Config) GetInt(key string) int {
+	return getAs[int](c, key)
+}
+
+// GetBool retrieves a configuration value by key as a bool, converting strings (e.g., "true")
+// and numbers. Returns false if the key is absent or the value cannot be converted.
+func (c *Config) GetBool(key string) bool {
+	return getAs[bool](c, key)
+}
+
+// GetFloat64 retrieves a configuration value by key as a float64, converting numeric strings
+// and integers. Returns 0 if the key is absent or the value cannot be converted.
+func (c *Config) GetFloat64(key string) float64 {
+	return getAs[float64](c, key)
+}
+
+// GetSize retrieves a configuration value by key as a number of bytes, parsing sizes with a unit
+// suffix (e.g., "10MB" is 10,000,000 bytes and "1GiB" is 1,073,741,824 bytes).
+// Returns 0 if the key is absent or the value cannot be converted.
+func (c *Config) GetSize(key string) int64 {
+	value, exists := c.Find(key)
+	if !exists {
+		return 0
+	}
+
+	if str, ok := value.(string); ok {
+		size, err := maps.ParseByteSize(str)
+		if err != nil {
+			return 0
+		}
+
+		value = size
+	}
+
+	var out int64
+	if err := c.convert(value, &out); err != nil {
+		return 0
+	}
+
+	return out
+}
+
+// GetOr retrieves a configuration value by key, or returns fallback if the key is absent.
+func (c *Config) GetOr(key string, fallback any) any {
+	if value, exists := c.Find(key); exists {
+		return value
+	}
+
+	return fallback
+}
+
+// GetStringOr is like GetString, but returns fallback if the key is absent or the value cannot be converted.
+func (c *Config) GetStringOr(key, fallback string) string {
+	return getAsOr(c, key, fallback)
+}
+
+// GetIntOr is like GetInt, but returns fallback if the key is absent or the value cannot be converted.
+func (c *Config) GetIntOr(key string, fallback int) int {
+	return getAsOr(c, key, fallback)
+}
+
+// GetBoolOr is like GetBool, but returns fallback if the key is absent or the value cannot be converted.
+func (c *Config) GetBoolOr(key string, fallback bool) bool {
+	return getAsOr(c, key, fallback)
+}
+
+// GetFloat64Or is like GetFloat64, but returns fallback if the key is absent or the value cannot be converted.
+func (c *Config) GetFloat64Or(key string, fallback float64) float64 {
+	return getAsOr(c, key, fallback)
+}
+
+// GetStringSlice retrieves a configuration value by key as a []string. Arrays (e.g., from JSON),
+// comma-separated strings (e.g., "a, b" from env vars) and single scalars are accepted.
+// Returns nil if the key is absent or the value cannot be converted.
+func (c *Config) GetStringSlice(key string) []string {
+	return getSliceAs[string](c, key)
+}
+
+// GetIntSlice retrieves a configuration value by key as an []int. Arrays (e.g., from JSON),
+// comma-separated strings (e.g., "1, 2" from env vars) and single scalars are accepted.
+// Returns nil if the key is absent or the value cannot be converted.
+func (c *Config) GetIntSlice(key string) []int {
+	return getSliceAs[int](c, key)
+}
+
+// GetStringMap retrieves a configuration section by key as a map[string]any.
+// Returns nil if the key is absent or is not a section.
+func (c *Config) GetStringMap(key string) map[string]any {
+	value, _ := c.Find(key)
+
+	section, _ := value.(map[string]any)
+
+	return section
+}
+
+// GetStringMapString retrieves a configuration section by key as a map[string]string, e.g. to
+// pass labels or annotations to downstream libraries. All leaves are converted to strings, and
+// the leaves of nested sections are keyed by their dotted path relative to key.
+// Returns nil if the key is absent or is not a section.
+func (c *Config) GetStringMapString(key string) map[string]string {
+	section := c.GetStringMap(key)
+	if section == nil {
+		return nil
+	}
+
+	flat := maps.Flatten(section, c.keys.sep())
+	out := make(map[string]string, len(flat))
+
+	for k, v := range flat {
+		// Skip empty sections.
+		if _, isSection := v.(map[string]any); isSection {
+			continue
+		}
+
+		var str string
+		if err := c.convert(v, &str); err != nil {
+			continue
+		}
+
+		out[k] = str
+	}
+
+	return out
+}
+
+// Get retrieves a configuration value by key converted to T, using the same conversion rules as
+// Bind (e.g., the string "5432" can be retrieved as an int, and sections can be retrieved as structs),
+// including the registered converters and decode hooks (see RegisterConverter and RegisterDecodeHook).
+//
+// Returns ErrKeyNotFound if the key is absent, and ErrValueConversionFailed if the value cannot
+// be converted to T.
+func Get[T any](c *Config, key string) (T, error) {
+	var out T
+
+	value, exists := c.Find(key)
+	if !exists {
+		return out, fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+
+	if err := c.convert(value, &out); err != nil {
+		var zero T
+
+		return zero, fmt.Errorf("%w %s to %T: %w", ErrValueConversionFailed, key, zero, err)
+	}
+
+	return out, nil
+}
+
+// convert converts value into the value pointed to by dest like Bind, with the registered decode
+// hooks.
+func (c *Config) convert(value, dest any) error {
+	return maps.ConvertWith(value, dest, maps.BindOptions{
+		DecodeHooks:  c.binderDecodeHooks(nil),
+		KeySeparator: c.keys.sep(),
+	})
+}
+
+// getAs retrieves the value of key converted to T, or the zero value of T if the key is absent
+// or the value cannot be converted.
+func getAs[T any](c *Config, key string) T {
+	out, _ := Get[T](c, key)
+
+	return out
+}
+
+// getAsOr retrieves the value of key converted to T, or fallback if the key is absent or the
+// value cannot be converted.
+func getAsOr[T any](c *Config, key string, fallback T) T {
+	out, err := Get[T](c, key)
+	if err != nil {
+		return fallback
+	}
+
+	return out
+}
+
+// getSliceAs retrieves the value of key converted to []T, or nil if the key is absent or the
+// value cannot be converted. See GetStringSlice for the accepted values.
+func getSliceAs[T any](c *Config, key string) []T {
+	value, exists := c.Find(key)
+	if !exists || value == nil {
+		return nil
+	}
+
+	var out []T
+
+	if err := c.convert(toSliceValue(value), &out); err != nil {
+		return nil
+	}
+
+	return out
+}
+
+// toSliceValue returns value as a slice: slices and arrays are returned as is, strings are split
+// on sliceSeparator, and other values are wrapped into a single item slice.
+func toSliceValue(value any) any {
+	if str, ok := value.(string); ok {
+		var items []any
+
+		for _, item := range strings.Split(str, sliceSeparator) {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+
+		return items
+	}
+
+	if kind := reflect.TypeOf(value).Kind(); kind == reflect.Slice || kind == reflect.Array {
+		return value
+	}
+
+	return []any{value}
+}
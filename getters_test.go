@@ -0,0 +1,200 @@
+package gcfg_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGettersConfig(t *testing.T) *gcfg.Config {
+	t.Helper()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{
+			"host": "localhost",
+			"port": "5432",
+		},
+		"debug":   "true",
+		"ratio":   0.75,
+		"workers": float64(8),
+		"invalid": "not a number",
+		"hosts":   []any{"a.internal", "b.internal"},
+		"ports":   []any{"80", 443.0},
+		"tags":    "web, api ,,edge",
+		"single":  7,
+	}})
+	require.NoError(t, cfg.Load())
+
+	return cfg
+}
+
+func TestConfig_TypedGetters(t *testing.T) {
+	t.Parallel()
+
+	cfg := newGettersConfig(t)
+
+	assert.Equal(t, "localhost", cfg.GetString("database.host"))
+	assert.Equal(t, "5432", cfg.GetString("database.port"))
+	assert.Equal(t, 5432, cfg.GetInt("database.port"))
+	assert.Equal(t, 8, cfg.GetInt("workers"))
+	assert.True(t, cfg.GetBool("debug"))
+	assert.InEpsilon(t, 0.75, cfg.GetFloat64("ratio"), 0)
+	assert.InEpsilon(t, 5432.0, cfg.GetFloat64("database.port"), 0)
+
+	// Absent keys and invalid values return zero values.
+	assert.Empty(t, cfg.GetString("missing"))
+	assert.Zero(t, cfg.GetInt("missing"))
+	assert.False(t, cfg.GetBool("missing"))
+	assert.Zero(t, cfg.GetFloat64("missing"))
+	assert.Zero(t, cfg.GetInt("invalid"))
+	assert.False(t, cfg.GetBool("invalid"))
+}
+
+func TestConfig_GetSize(t *testing.T) {
+	t.Parallel()
+
+	cfg := newGettersConfig(t)
+	cfg.Set("limits.body", "10MB")
+	cfg.Set("limits.cache", "1.5 GiB")
+	cfg.Set("limits.huge", "16EiB")
+
+	assert.Equal(t, int64(10_000_000), cfg.GetSize("limits.body"))
+	assert.Equal(t, int64(1536<<20), cfg.GetSize("limits.cache"))
+	assert.Equal(t, int64(5432), cfg.GetSize("database.port"))
+	assert.Equal(t, int64(8), cfg.GetSize("workers"))
+
+	assert.Zero(t, cfg.GetSize("limits.huge"))
+	assert.Zero(t, cfg.GetSize("invalid"))
+	assert.Zero(t, cfg.GetSize("missing"))
+}
+
+func TestConfig_SliceGetters(t *testing.T) {
+	t.Parallel()
+
+	cfg := newGettersConfig(t)
+	cfg.Set("typed", []string{"x", "y"})
+
+	assert.Equal(t, []string{"a.internal", "b.internal"}, cfg.GetStringSlice("hosts"))
+	assert.Equal(t, []string{"web", "api", "edge"}, cfg.GetStringSlice("tags"))
+	assert.Equal(t, []string{"7"}, cfg.GetStringSlice("single"))
+	assert.Equal(t, []string{"x", "y"}, cfg.GetStringSlice("typed"))
+
+	assert.Equal(t, []int{80, 443}, cfg.GetIntSlice("ports"))
+	assert.Equal(t, []int{7}, cfg.GetIntSlice("single"))
+	assert.Equal(t, []int{5432}, cfg.GetIntSlice("database.port"))
+
+	assert.Nil(t, cfg.GetStringSlice("missing"))
+	assert.Nil(t, cfg.GetIntSlice("tags"))
+}
+
+func TestConfig_MapGetters(t *testing.T) {
+	t.Parallel()
+
+	cfg := newGettersConfig(t)
+	cfg.Set("labels", map[string]any{
+		"team":     "platform",
+		"tier":     1,
+		"critical": true,
+		"owner":    map[string]any{"email": "ops@example.com"},
+	})
+
+	assert.Equal(t, map[string]any{"host": "localhost", "port": "5432"}, cfg.GetStringMap("database"))
+	assert.Equal(t, map[string]string{
+		"team":        "platform",
+		"tier":        "1",
+		"critical":    "true",
+		"owner.email": "ops@example.com",
+	}, cfg.GetStringMapString("labels"))
+
+	assert.Nil(t, cfg.GetStringMap("missing"))
+	assert.Nil(t, cfg.GetStringMap("debug"))
+	assert.Nil(t, cfg.GetStringMapString("debug"))
+}
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+
+	cfg := newGettersConfig(t)
+
+	port, err := gcfg.Get[int](cfg, "database.port")
+	require.NoError(t, err)
+	assert.Equal(t, 5432, port)
+
+	ports, err := gcfg.Get[[]uint16](cfg, "ports")
+	require.NoError(t, err)
+	assert.Equal(t, []uint16{80, 443}, ports)
+
+	type Database struct {
+		Host string
+		Port int
+	}
+
+	db, err := gcfg.Get[Database](cfg, "database")
+	require.NoError(t, err)
+	assert.Equal(t, Database{Host: "localhost", Port: 5432}, db)
+
+	_, err = gcfg.Get[int](cfg, "missing")
+	require.ErrorIs(t, err, gcfg.ErrKeyNotFound)
+
+	_, err = gcfg.Get[int](cfg, "invalid")
+	require.ErrorIs(t, err, gcfg.ErrValueConversionFailed)
+	assert.Contains(t, err.Error(), "invalid to int")
+}
+
+func TestGet_RegisteredConverter(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"theme":  map[string]any{"primary": "#ff8000"},
+		"level":  "WARN",
+		"levels": "DEBUG, INFO",
+	}}, gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	cfg.RegisterConverter(reflect.TypeFor[color](), func(value any) (any, error) {
+		var c color
+		if _, err := fmt.Sscanf(fmt.Sprint(value), "#%02x%02x%02x", &c.R, &c.G, &c.B); err != nil {
+			return nil, fmt.Errorf("%w %v: %w", errUnexpectedValue, value, err)
+		}
+
+		return c, nil
+	})
+	cfg.RegisterDecodeHook(func(_ reflect.Type, to reflect.Type, value any) (any, error) {
+		if str, ok := value.(string); ok && to.Kind() == reflect.String {
+			return strings.ToLower(str), nil
+		}
+
+		return value, nil
+	})
+
+	primary, err := gcfg.Get[color](cfg, "theme.primary")
+	require.NoError(t, err)
+	assert.Equal(t, color{R: 0xff, G: 0x80}, primary)
+
+	assert.Equal(t, "warn", cfg.GetString("level"))
+	assert.Equal(t, []string{"debug", "info"}, cfg.GetStringSlice("levels"))
+}
+
+func TestConfig_FallbackGetters(t *testing.T) {
+	t.Parallel()
+
+	cfg := newGettersConfig(t)
+
+	assert.Equal(t, "localhost", cfg.GetOr("database.host", "fallback"))
+	assert.Equal(t, "fallback", cfg.GetOr("missing", "fallback"))
+
+	assert.Equal(t, "localhost", cfg.GetStringOr("database.host", "fallback"))
+	assert.Equal(t, "fallback", cfg.GetStringOr("missing", "fallback"))
+	assert.Equal(t, 5432, cfg.GetIntOr("database.port", 1))
+	assert.Equal(t, 1, cfg.GetIntOr("missing", 1))
+	assert.Equal(t, 1, cfg.GetIntOr("invalid", 1))
+	assert.True(t, cfg.GetBoolOr("debug", false))
+	assert.True(t, cfg.GetBoolOr("missing", true))
+	assert.InEpsilon(t, 0.75, cfg.GetFloat64Or("ratio", 0.5), 0)
+	assert.InEpsilon(t, 0.5, cfg.GetFloat64Or("missing", 0.5), 0)
+}
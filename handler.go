@@ -0,0 +1,80 @@
+package gcfg
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// handlerResponse is the JSON document served by Handler.
+type handlerResponse struct {
+	Version   uint64                  `json:"version"`
+	Hash      string                  `json:"hash"`
+	Values    map[string]any          `json:"values"`
+	Providers []handlerProviderStatus `json:"providers"`
+}
+
+// handlerProviderStatus is the JSON representation of a ProviderStatus.
+type handlerProviderStatus struct {
+	Name       string     `json:"name"`
+	Source     string     `json:"source,omitempty"`
+	Priority   int        `json:"priority"`
+	LastLoad   *time.Time `json:"lastLoad,omitempty"`
+	LastError  string     `json:"lastError,omitempty"`
+	ErrorCount int        `json:"errorCount"`
+}
+
+// Handler returns an http.Handler serving the merged configuration as JSON for live inspection,
+// e.g. on an internal admin port. The values of keys that look like secrets (passwords, tokens,
+// API keys, etc.) or are marked as secret (see WithSecret) are redacted. The response also includes
+// the configuration version and hash (see Config.Hash), and the status of the registered providers.
+func Handler(c *Config) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		snapshot := c.Snapshot()
+		statuses := c.ProviderStatuses()
+
+		resp := handlerResponse{
+			Version:   snapshot.version,
+			Values:    c.redactValues(snapshot.values, "", true),
+			Providers: make([]handlerProviderStatus, 0, len(statuses)),
+		}
+		resp.Hash = hashValues(resp.Values)
+
+		for _, status := range statuses {
+			ps := handlerProviderStatus{
+				Name:       status.Name,
+				Source:     status.Source,
+				Priority:   status.Priority,
+				ErrorCount: status.ErrorCount,
+			}
+
+			if !status.LastLoad.IsZero() {
+				ps.LastLoad = &status.LastLoad
+			}
+
+			if status.LastError != nil {
+				ps.LastError = status.LastError.Error()
+			}
+
+			resp.Providers = append(resp.Providers, ps)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+
+		if r.Method == http.MethodHead {
+			return
+		}
+
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(resp)
+	})
+}
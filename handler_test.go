@@ -0,0 +1,101 @@
+package gcfg_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "Environment Variables", data: map[string]any{
+		"database": map[string]any{
+			"host":     "localhost",
+			"password": "hunter2",
+		},
+		"secrets": map[string]any{"signing": "abc"},
+		"api_key": "xyz",
+	}})
+	require.NoError(t, cfg.Load())
+
+	rec := httptest.NewRecorder()
+	gcfg.Handler(cfg).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/config", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var resp struct {
+		Version   uint64         `json:"version"`
+		Hash      string         `json:"hash"`
+		Values    map[string]any `json:"values"`
+		Providers []struct {
+			Name      string `json:"name"`
+			LastLoad  string `json:"lastLoad"`
+			LastError string `json:"lastError"`
+		} `json:"providers"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+
+	assert.Equal(t, cfg.Version(), resp.Version)
+	assert.Equal(t, cfg.Hash(), resp.Hash)
+	assert.Equal(t, map[string]any{
+		"database": map[string]any{
+			"host":     "localhost",
+			"password": "[REDACTED]",
+		},
+		"secrets": "[REDACTED]",
+		"api_key": "[REDACTED]",
+	}, resp.Values)
+
+	require.Len(t, resp.Providers, 1)
+	assert.Equal(t, "Environment Variables", resp.Providers[0].Name)
+	assert.NotEmpty(t, resp.Providers[0].LastLoad)
+	assert.Empty(t, resp.Providers[0].LastError)
+
+	// The configuration itself is not redacted.
+	assert.Equal(t, "hunter2", cfg.Get("database.password"))
+}
+
+func TestHandler_MethodNotAllowed(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	gcfg.Handler(gcfg.New()).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/config", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestConfig_ProviderStatuses(t *testing.T) {
+	t.Parallel()
+
+	failing := &mockProvider{name: "failing", err: assert.AnError}
+	cfg := gcfg.New(&mockProvider{name: "Environment Variables"}, gcfg.WithPriority(failing, 5))
+
+	require.Error(t, cfg.Load())
+
+	statuses := cfg.ProviderStatuses()
+	require.Len(t, statuses, 2)
+
+	assert.Equal(t, "Environment Variables", statuses[0].Name)
+	assert.True(t, statuses[0].LastLoad.IsZero())
+	assert.NoError(t, statuses[0].LastError)
+
+	assert.Equal(t, "failing", statuses[1].Name)
+	assert.Equal(t, 5, statuses[1].Priority)
+	assert.ErrorIs(t, statuses[1].LastError, assert.AnError)
+	assert.Equal(t, 1, statuses[1].ErrorCount)
+
+	failing.err = nil
+	require.NoError(t, cfg.Load())
+
+	statuses = cfg.ProviderStatuses()
+	assert.False(t, statuses[1].LastLoad.IsZero())
+	assert.NoError(t, statuses[1].LastError)
+	assert.Equal(t, 1, statuses[1].ErrorCount)
+}
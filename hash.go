@@ -0,0 +1,38 @@
+package gcfg
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ahmedkamalio/gcfg/internal/reflection"
+)
+
+// Hash returns a stable digest of the merged configuration, e.g.
+// "sha256:6f1ed002ab5595859014ebf0951522d9...", so deployments can detect configuration drift
+// between instances, e.g. by exposing it in health endpoints (see Handler). Equal configurations
+// have equal hashes regardless of the order and the providers the values were loaded from.
+//
+// The hash is computed over the values redacted like RedactedValues, so it doesn't leak secrets,
+// and changes of secret values don't change it.
+func (c *Config) Hash() string {
+	c.mu.RLock()
+	values := c.redactValues(reflection.Clone(c.values), "", true)
+	c.mu.RUnlock()
+
+	return hashValues(values)
+}
+
+// hashValues returns the digest of values, hashing their JSON encoding, whose map keys are sorted.
+func hashValues(values map[string]any) string {
+	data, err := json.Marshal(values)
+	if err != nil {
+		// values that can't be encoded as JSON (e.g. set with Set) are hashed by representation
+		data = fmt.Appendf(nil, "%#v", values)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,40 @@
+package gcfg_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Hash(t *testing.T) {
+	t.Parallel()
+
+	load := func(providers ...gcfg.Provider) *gcfg.Config {
+		t.Helper()
+
+		cfg := gcfg.New(append(providers, gcfg.WithoutDefaultEnv())...)
+		require.NoError(t, cfg.Load())
+
+		return cfg
+	}
+
+	cfg := load(&mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{"host": "db.internal", "port": 5432, "password": "s3cr3t"},
+	}})
+
+	hash := cfg.Hash()
+	assert.Regexp(t, `^sha256:[0-9a-f]{64}$`, hash)
+	assert.Equal(t, hash, cfg.Hash())
+
+	// the same values loaded differently
+	same := load(
+		&mockProvider{name: "base", data: map[string]any{"database": map[string]any{"host": "localhost", "port": 5432.0}}},
+		&mockProvider{name: "override", data: map[string]any{"database": map[string]any{"host": "db.internal", "password": "other"}}},
+	)
+	assert.Equal(t, hash, same.Hash(), "secrets, number types and providers don't change the hash")
+
+	cfg.Set("database.port", 5433)
+	assert.NotEqual(t, hash, cfg.Hash())
+}
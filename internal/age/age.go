@@ -0,0 +1,388 @@
+// Package age decrypts files encrypted with age (https://age-encryption.org/v1) to X25519
+// recipients, in binary or ASCII-armored form, with the identities generated by age-keygen.
+// Passphrase-encrypted files and plugin or SSH identities are not supported.
+package age
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+var (
+	// ErrInvalidIdentity indicates that an identity can't be parsed.
+	ErrInvalidIdentity = errors.New("invalid age identity")
+
+	// ErrMalformed indicates that a file isn't a well-formed age file.
+	ErrMalformed = errors.New("malformed age file")
+
+	// ErrNoIdentityMatched indicates that none of the identities is a recipient of a file.
+	ErrNoIdentityMatched = errors.New("no identity matched any of the recipients")
+
+	// ErrHeaderMismatch indicates that the header of a file was modified.
+	ErrHeaderMismatch = errors.New("age header MAC mismatch")
+
+	// ErrPayloadCorrupted indicates that the payload of a file was modified or truncated.
+	ErrPayloadCorrupted = errors.New("age payload corrupted")
+)
+
+const (
+	// intro is the first line of age files.
+	intro = "age-encryption.org/v1\n"
+
+	// armorType is the type of the PEM block of ASCII-armored age files.
+	armorType = "AGE ENCRYPTED FILE"
+
+	// identityPrefix is the Bech32 human-readable part of X25519 identities.
+	identityPrefix = "age-secret-key-"
+
+	// x25519Label is the label of X25519 recipient stanzas, and of their key derivation.
+	x25519Label = "X25519"
+
+	// columns is the length of the lines of base64 encoded stanza bodies, but the last.
+	columns = 64
+
+	// fileKeySize is the size of the file key wrapped in stanzas.
+	fileKeySize = 16
+
+	// nonceSize is the size of the nonce preceding the payload.
+	nonceSize = 16
+
+	// chunkSize is the size of the plaintext chunks of the payload.
+	chunkSize = 64 * 1024
+)
+
+// Identity is an X25519 identity decrypting the files encrypted to its recipient.
+type Identity struct {
+	key *ecdh.PrivateKey
+}
+
+// ParseIdentities parses the identities of an identity file, one "AGE-SECRET-KEY-1..." per line.
+// Empty lines and comments starting with # are ignored.
+func ParseIdentities(data []byte) ([]*Identity, error) {
+	var identities []*Identity
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		identity, err := ParseIdentity(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+
+		identities = append(identities, identity)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(identities) == 0 {
+		return nil, fmt.Errorf("%w: no identity found", ErrInvalidIdentity)
+	}
+
+	return identities, nil
+}
+
+// ParseIdentity parses an X25519 identity, e.g. "AGE-SECRET-KEY-1...".
+func ParseIdentity(s string) (*Identity, error) {
+	hrp, data, err := decodeBech32(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIdentity, err)
+	}
+
+	if hrp != identityPrefix {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidIdentity, hrp)
+	}
+
+	key, err := ecdh.X25519().NewPrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidIdentity, err)
+	}
+
+	return &Identity{key: key}, nil
+}
+
+// unwrap returns the file key wrapped in s for the identity, and whether s is addressed to it.
+func (i *Identity) unwrap(s *stanza) ([]byte, bool, error) {
+	if s.typ != x25519Label {
+		return nil, false, nil
+	}
+
+	if len(s.args) != 1 || len(s.body) != fileKeySize+chacha20poly1305.Overhead {
+		return nil, false, fmt.Errorf("%w: invalid %s stanza", ErrMalformed, x25519Label)
+	}
+
+	share, err := base64.RawStdEncoding.Strict().DecodeString(s.args[0])
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: invalid %s stanza: %w", ErrMalformed, x25519Label, err)
+	}
+
+	ephemeral, err := ecdh.X25519().NewPublicKey(share)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: invalid %s stanza: %w", ErrMalformed, x25519Label, err)
+	}
+
+	// fails for low-order shares, whose shared secret is all zeros
+	shared, err := i.key.ECDH(ephemeral)
+	if err != nil {
+		return nil, false, fmt.Errorf("%w: invalid %s stanza: %w", ErrMalformed, x25519Label, err)
+	}
+
+	salt := append(share[:len(share):len(share)], i.key.PublicKey().Bytes()...)
+
+	wrapKey, err := hkdf.Key(sha256.New, shared, salt, "age-encryption.org/v1/"+x25519Label, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, false, err
+	}
+
+	aead, err := chacha20poly1305.New(wrapKey)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// stanzas addressed to other recipients can't be opened
+	fileKey, err := aead.Open(nil, make([]byte, chacha20poly1305.NonceSize), s.body, nil)
+
+	return fileKey, err == nil, nil
+}
+
+// Decrypt decrypts the age file data with one of the identities.
+func Decrypt(data []byte, identities []*Identity) ([]byte, error) {
+	data, err := dearmor(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return decrypt(data, identities)
+}
+
+// decrypt decrypts the binary age file data with one of the identities.
+func decrypt(data []byte, identities []*Identity) ([]byte, error) {
+	h, err := parseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	fileKey, err := unwrapFileKey(h.stanzas, identities)
+	if err != nil {
+		return nil, err
+	}
+
+	macKey, err := hkdf.Key(sha256.New, fileKey, nil, "header", sha256.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(h.signed)
+
+	if !hmac.Equal(mac.Sum(nil), h.mac) {
+		return nil, ErrHeaderMismatch
+	}
+
+	if len(h.payload) < nonceSize {
+		return nil, fmt.Errorf("%w: missing nonce", ErrPayloadCorrupted)
+	}
+
+	payloadKey, err := hkdf.Key(sha256.New, fileKey, h.payload[:nonceSize], "payload", chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	return decryptPayload(payloadKey, h.payload[nonceSize:])
+}
+
+// unwrapFileKey returns the file key wrapped in the stanzas for one of the identities.
+func unwrapFileKey(stanzas []*stanza, identities []*Identity) ([]byte, error) {
+	for _, s := range stanzas {
+		for _, identity := range identities {
+			fileKey, ok, err := identity.unwrap(s)
+			if err != nil {
+				return nil, err
+			}
+
+			if ok {
+				return fileKey, nil
+			}
+		}
+	}
+
+	return nil, ErrNoIdentityMatched
+}
+
+// decryptPayload decrypts the STREAM-encrypted payload: chunks of chunkSize bytes, each sealed
+// with a nonce made of the big-endian chunk counter and a flag marking the last chunk.
+func decryptPayload(key, payload []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext := make([]byte, 0, len(payload))
+	nonce := make([]byte, chacha20poly1305.NonceSize)
+
+	for counter := uint64(0); ; counter++ {
+		size := min(len(payload), chunkSize+aead.Overhead())
+		last := size == len(payload)
+
+		binary.BigEndian.PutUint64(nonce[3:11], counter)
+
+		if last {
+			nonce[11] = 1
+		}
+
+		chunk, oErr := aead.Open(nil, nonce, payload[:size], nil)
+		if oErr != nil {
+			return nil, fmt.Errorf("%w: chunk %d", ErrPayloadCorrupted, counter)
+		}
+
+		// only empty files end with an empty chunk
+		if last && len(chunk) == 0 && counter > 0 {
+			return nil, fmt.Errorf("%w: empty last chunk", ErrPayloadCorrupted)
+		}
+
+		plaintext = append(plaintext, chunk...)
+		payload = payload[size:]
+
+		if last {
+			return plaintext, nil
+		}
+	}
+}
+
+// dearmor returns the binary form of the ASCII-armored age file data, or data as-is if it isn't
+// armored.
+func dearmor(data []byte) ([]byte, error) {
+	trimmed := bytes.TrimSpace(data)
+	if !bytes.HasPrefix(trimmed, []byte("-----BEGIN "+armorType+"-----")) {
+		return data, nil
+	}
+
+	block, rest := pem.Decode(trimmed)
+	if block == nil || block.Type != armorType || len(bytes.TrimSpace(rest)) != 0 {
+		return nil, fmt.Errorf("%w: invalid armor", ErrMalformed)
+	}
+
+	return block.Bytes, nil
+}
+
+// header is the parsed header of an age file.
+type header struct {
+	stanzas []*stanza
+
+	// signed is the part of the header authenticated by mac, up to and including "---".
+	signed []byte
+	mac    []byte
+
+	// payload is the nonce and the encrypted payload following the header.
+	payload []byte
+}
+
+// stanza is a recipient stanza of an age header.
+type stanza struct {
+	typ  string
+	args []string
+	body []byte
+}
+
+// parseHeader parses the header of the binary age file data.
+func parseHeader(data []byte) (*header, error) {
+	if !bytes.HasPrefix(data, []byte(intro)) {
+		return nil, fmt.Errorf("%w: unknown format or version", ErrMalformed)
+	}
+
+	h := &header{}
+	rest := data[len(intro):]
+
+	for {
+		line, next, ok := bytes.Cut(rest, []byte("\n"))
+		if !ok {
+			return nil, fmt.Errorf("%w: truncated header", ErrMalformed)
+		}
+
+		if mac, isMAC := bytes.CutPrefix(line, []byte("--- ")); isMAC {
+			decoded, err := base64.RawStdEncoding.Strict().DecodeString(string(mac))
+			if err != nil || len(decoded) != sha256.Size {
+				return nil, fmt.Errorf("%w: invalid header MAC", ErrMalformed)
+			}
+
+			h.signed = data[:len(data)-len(rest)+len("---")]
+			h.mac = decoded
+			h.payload = next
+
+			if len(h.stanzas) == 0 {
+				return nil, fmt.Errorf("%w: no recipients", ErrMalformed)
+			}
+
+			return h, nil
+		}
+
+		args, isStanza := bytes.CutPrefix(line, []byte("-> "))
+		if !isStanza {
+			return nil, fmt.Errorf("%w: unexpected line %q", ErrMalformed, line)
+		}
+
+		fields := strings.Split(string(args), " ")
+		if len(fields) == 0 || fields[0] == "" {
+			return nil, fmt.Errorf("%w: stanza without type", ErrMalformed)
+		}
+
+		s := &stanza{typ: fields[0], args: fields[1:]}
+
+		body, bodyRest, err := parseStanzaBody(next)
+		if err != nil {
+			return nil, err
+		}
+
+		s.body = body
+		h.stanzas = append(h.stanzas, s)
+		rest = bodyRest
+	}
+}
+
+// parseStanzaBody parses the base64 encoded body of a stanza at the beginning of data, made of
+// lines of columns characters ended by a shorter, possibly empty line.
+func parseStanzaBody(data []byte) ([]byte, []byte, error) {
+	var encoded []byte
+
+	for {
+		line, next, ok := bytes.Cut(data, []byte("\n"))
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: truncated stanza", ErrMalformed)
+		}
+
+		if len(line) > columns {
+			return nil, nil, fmt.Errorf("%w: stanza line too long", ErrMalformed)
+		}
+
+		encoded = append(encoded, line...)
+		data = next
+
+		if len(line) < columns {
+			break
+		}
+	}
+
+	body, err := base64.RawStdEncoding.Strict().DecodeString(string(encoded))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: invalid stanza body: %w", ErrMalformed, err)
+	}
+
+	return body, data, nil
+}
@@ -0,0 +1,305 @@
+package age_test
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg/internal/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Identities of the X25519 scalars made of 32 bytes 0x01 and 0x02 respectively.
+const (
+	identity1 = "AGE-SECRET-KEY-1QYQSZQGPQYQSZQGPQYQSZQGPQYQSZQGPQYQSZQGPQYQSZQGPQYQSZ9K4CN"
+	identity2 = "AGE-SECRET-KEY-1QGPQYQSZQGPQYQSZQGPQYQSZQGPQYQSZQGPQYQSZQGPQYQSZQGPQ0N3JDM"
+)
+
+// recipient returns the X25519 recipient of the identity of the scalar made of 32 bytes b.
+func recipient(t *testing.T, b byte) *ecdh.PublicKey {
+	t.Helper()
+
+	key, err := ecdh.X25519().NewPrivateKey(bytes.Repeat([]byte{b}, 32))
+	require.NoError(t, err)
+
+	return key.PublicKey()
+}
+
+// encrypt encrypts plaintext to recipient as specified by age-encryption.org/v1, with extra
+// stanzas inserted in the header.
+func encrypt(t *testing.T, to *ecdh.PublicKey, plaintext []byte, extraStanzas string) []byte {
+	t.Helper()
+
+	b64 := base64.RawStdEncoding.EncodeToString
+	fileKey := make([]byte, 16)
+	_, err := rand.Read(fileKey)
+	require.NoError(t, err)
+
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	shared, err := ephemeral.ECDH(to)
+	require.NoError(t, err)
+
+	share := ephemeral.PublicKey().Bytes()
+
+	wrapKey, err := hkdf.Key(sha256.New, shared, append(share, to.Bytes()...), "age-encryption.org/v1/X25519", 32)
+	require.NoError(t, err)
+
+	aead, err := chacha20poly1305.New(wrapKey)
+	require.NoError(t, err)
+
+	body := aead.Seal(nil, make([]byte, chacha20poly1305.NonceSize), fileKey, nil)
+	header := "age-encryption.org/v1\n-> X25519 " + b64(share) + "\n" + b64(body) + "\n" + extraStanzas + "---"
+
+	macKey, err := hkdf.Key(sha256.New, fileKey, nil, "header", 32)
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write([]byte(header))
+
+	out := []byte(header + " " + b64(mac.Sum(nil)) + "\n")
+
+	nonce := make([]byte, 16)
+	_, err = rand.Read(nonce)
+	require.NoError(t, err)
+
+	payloadKey, err := hkdf.Key(sha256.New, fileKey, nonce, "payload", 32)
+	require.NoError(t, err)
+
+	aead, err = chacha20poly1305.New(payloadKey)
+	require.NoError(t, err)
+
+	out = append(out, nonce...)
+	chunkNonce := make([]byte, chacha20poly1305.NonceSize)
+
+	for counter := uint64(0); ; counter++ {
+		size := min(len(plaintext), 64*1024)
+		last := size == len(plaintext)
+
+		binary.BigEndian.PutUint64(chunkNonce[3:11], counter)
+
+		if last {
+			chunkNonce[11] = 1
+		}
+
+		out = aead.Seal(out, chunkNonce, plaintext[:size], nil)
+		plaintext = plaintext[size:]
+
+		if last {
+			return out
+		}
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	t.Parallel()
+
+	large := bytes.Repeat([]byte("0123456789abcdef"), 64*1024/16*2+1)
+
+	tests := []struct {
+		name       string
+		ciphertext func(t *testing.T) []byte
+		want       []byte
+		wantErr    error
+	}{
+		{
+			name: "small",
+			ciphertext: func(t *testing.T) []byte {
+				t.Helper()
+
+				return encrypt(t, recipient(t, 1), []byte(`{"port": 8080}`), "")
+			},
+			want: []byte(`{"port": 8080}`),
+		},
+		{
+			name: "empty",
+			ciphertext: func(t *testing.T) []byte {
+				t.Helper()
+
+				return encrypt(t, recipient(t, 1), []byte{}, "")
+			},
+			want: []byte{},
+		},
+		{
+			name: "several chunks",
+			ciphertext: func(t *testing.T) []byte {
+				t.Helper()
+
+				return encrypt(t, recipient(t, 1), large, "")
+			},
+			want: large,
+		},
+		{
+			name: "full last chunk",
+			ciphertext: func(t *testing.T) []byte {
+				t.Helper()
+
+				return encrypt(t, recipient(t, 1), large[:64*1024], "")
+			},
+			want: large[:64*1024],
+		},
+		{
+			name: "armored",
+			ciphertext: func(t *testing.T) []byte {
+				t.Helper()
+
+				return pem.EncodeToMemory(&pem.Block{
+					Type:  "AGE ENCRYPTED FILE",
+					Bytes: encrypt(t, recipient(t, 1), []byte("armored"), ""),
+				})
+			},
+			want: []byte("armored"),
+		},
+		{
+			name: "other recipient stanzas",
+			ciphertext: func(t *testing.T) []byte {
+				t.Helper()
+
+				return encrypt(t, recipient(t, 2), []byte("second identity"), "-> scrypt c2FsdA 18\nYm9keQ\n")
+			},
+			want: []byte("second identity"),
+		},
+		{
+			name: "no matching identity",
+			ciphertext: func(t *testing.T) []byte {
+				t.Helper()
+
+				return encrypt(t, recipient(t, 3), []byte("secret"), "")
+			},
+			wantErr: age.ErrNoIdentityMatched,
+		},
+		{
+			name: "modified header",
+			ciphertext: func(t *testing.T) []byte {
+				t.Helper()
+
+				ciphertext := encrypt(t, recipient(t, 1), []byte("secret"), "-> other\n\n")
+
+				return bytes.Replace(ciphertext, []byte("-> other"), []byte("-> evil!"), 1)
+			},
+			wantErr: age.ErrHeaderMismatch,
+		},
+		{
+			name: "modified payload",
+			ciphertext: func(t *testing.T) []byte {
+				t.Helper()
+
+				ciphertext := encrypt(t, recipient(t, 1), []byte("secret"), "")
+				ciphertext[len(ciphertext)-1] ^= 1
+
+				return ciphertext
+			},
+			wantErr: age.ErrPayloadCorrupted,
+		},
+		{
+			name: "truncated payload",
+			ciphertext: func(t *testing.T) []byte {
+				t.Helper()
+
+				ciphertext := encrypt(t, recipient(t, 1), large, "")
+
+				return ciphertext[:len(ciphertext)-(len(large)%(64*1024)+16)]
+			},
+			wantErr: age.ErrPayloadCorrupted,
+		},
+		{
+			name: "not an age file",
+			ciphertext: func(*testing.T) []byte {
+				return []byte(`{"port": 8080}`)
+			},
+			wantErr: age.ErrMalformed,
+		},
+	}
+
+	identities, err := age.ParseIdentities([]byte(identity1 + "\n" + identity2))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := age.Decrypt(tt.ciphertext(t), identities)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseIdentities(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    string
+		want    int
+		wantErr bool
+	}{
+		{
+			name: "age-keygen output",
+			data: "# created: 2024-05-01T10:00:00Z\n# public key: age1...\n" + identity1 + "\n",
+			want: 1,
+		},
+		{
+			name: "several identities",
+			data: identity1 + "\n\n" + identity2,
+			want: 2,
+		},
+		{
+			name: "lower case",
+			data: "age-secret-key-1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqsz9k4cn",
+			want: 1,
+		},
+		{
+			name:    "invalid checksum",
+			data:    identity1[:len(identity1)-1] + "M",
+			wantErr: true,
+		},
+		{
+			name:    "mixed case",
+			data:    "age-secret-key-1QYQSZQGPQYQSZQGPQYQSZQGPQYQSZQGPQYQSZQGPQYQSZQGPQYQSZ9K4CN",
+			wantErr: true,
+		},
+		{
+			name:    "recipient",
+			data:    "age1qyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqszqgpqyqs3290gq",
+			wantErr: true,
+		},
+		{
+			name:    "no identity",
+			data:    "# empty\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := age.ParseIdentities([]byte(tt.data))
+			if tt.wantErr {
+				require.ErrorIs(t, err, age.ErrInvalidIdentity)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, got, tt.want)
+		})
+	}
+}
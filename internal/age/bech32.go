@@ -0,0 +1,119 @@
+package age
+
+import (
+	"errors"
+	"strings"
+)
+
+// bech32Charset is the alphabet of the Bech32 data part.
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// bech32ChecksumSize is the number of characters of the Bech32 checksum.
+const bech32ChecksumSize = 6
+
+var (
+	errBech32Mixed    = errors.New("mixed case")
+	errBech32Format   = errors.New("malformed Bech32 string")
+	errBech32Char     = errors.New("invalid Bech32 character")
+	errBech32Checksum = errors.New("invalid Bech32 checksum")
+	errBech32Padding  = errors.New("invalid Bech32 padding")
+)
+
+// decodeBech32 decodes the Bech32 string s (BIP 173, without its length limit, like age) into
+// its lower-case human-readable part and data.
+func decodeBech32(s string) (string, []byte, error) {
+	lower := strings.ToLower(s)
+	if lower != s && strings.ToUpper(s) != s {
+		return "", nil, errBech32Mixed
+	}
+
+	sep := strings.LastIndexByte(lower, '1')
+	if sep < 1 || sep+bech32ChecksumSize+1 > len(lower) {
+		return "", nil, errBech32Format
+	}
+
+	hrp := lower[:sep]
+
+	values := make([]byte, 0, len(lower)-sep-1)
+
+	for _, c := range []byte(lower[sep+1:]) {
+		v := strings.IndexByte(bech32Charset, c)
+		if v < 0 {
+			return "", nil, errBech32Char
+		}
+
+		values = append(values, byte(v))
+	}
+
+	if bech32Polymod(append(bech32ExpandHRP(hrp), values...)) != 1 {
+		return "", nil, errBech32Checksum
+	}
+
+	data, err := convertBits(values[:len(values)-bech32ChecksumSize])
+	if err != nil {
+		return "", nil, err
+	}
+
+	return hrp, data, nil
+}
+
+// bech32ExpandHRP expands the human-readable part hrp for the checksum computation.
+func bech32ExpandHRP(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+
+	for _, c := range []byte(hrp) {
+		expanded = append(expanded, c>>5)
+	}
+
+	expanded = append(expanded, 0)
+
+	for _, c := range []byte(hrp) {
+		expanded = append(expanded, c&31)
+	}
+
+	return expanded
+}
+
+// bech32Polymod computes the Bech32 checksum of values.
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+
+		for i, g := range generator {
+			if (top>>i)&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+
+	return chk
+}
+
+// convertBits regroups 5-bit values into bytes, rejecting incomplete or non-zero padding.
+func convertBits(values []byte) ([]byte, error) {
+	var (
+		acc  uint32
+		bits uint
+		out  = make([]byte, 0, len(values)*5/8)
+	)
+
+	for _, v := range values {
+		acc = acc<<5 | uint32(v)
+		bits += 5
+
+		if bits >= 8 {
+			bits -= 8
+			out = append(out, byte(acc>>bits))
+		}
+	}
+
+	if bits >= 5 || (acc<<(8-bits))&0xff != 0 {
+		return nil, errBech32Padding
+	}
+
+	return out, nil
+}
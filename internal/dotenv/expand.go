@@ -0,0 +1,99 @@
+package dotenv
+
+import "strings"
+
+// expandVariables replaces the $NAME, ${NAME}, ${NAME:-fallback} and ${NAME-fallback} references
+// in value with the values of the variables given by lookup, like docker-compose does.
+// ${NAME:-fallback} expands to fallback if NAME is unset or empty, and ${NAME-fallback} only if it
+// is unset; other unset variables expand to an empty string. "$$" stands for a literal "$".
+func expandVariables(value string, lookup func(name string) (string, bool)) string {
+	if !strings.Contains(value, "$") {
+		return value
+	}
+
+	var sb strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		switch {
+		case c != '$' || i+1 == len(value):
+			sb.WriteByte(c)
+		case value[i+1] == '$':
+			sb.WriteByte('$')
+
+			i++
+		case value[i+1] == '{':
+			end := strings.IndexByte(value[i+2:], '}')
+			if end < 0 {
+				// unterminated, kept as-is
+				sb.WriteString(value[i:])
+
+				return sb.String()
+			}
+
+			sb.WriteString(expandReference(value[i+2:i+2+end], lookup))
+
+			i += 2 + end
+		default:
+			n := variableNameLen(value[i+1:])
+			if n == 0 {
+				sb.WriteByte(c)
+
+				continue
+			}
+
+			v, _ := lookup(value[i+1 : i+1+n])
+			sb.WriteString(v)
+
+			i += n
+		}
+	}
+
+	return sb.String()
+}
+
+// expandReference returns the value of the ${...} reference ref, see expandVariables. References
+// that are not a variable name are kept as-is.
+func expandReference(ref string, lookup func(name string) (string, bool)) string {
+	n := variableNameLen(ref)
+	if n == 0 {
+		return "${" + ref + "}"
+	}
+
+	name, rest := ref[:n], ref[n:]
+	value, set := lookup(name)
+
+	switch {
+	case rest == "":
+		return value
+	case strings.HasPrefix(rest, ":-"):
+		if !set || value == "" {
+			return rest[2:]
+		}
+
+		return value
+	case strings.HasPrefix(rest, "-"):
+		if !set {
+			return rest[1:]
+		}
+
+		return value
+	default:
+		return "${" + ref + "}"
+	}
+}
+
+// variableNameLen returns the length of the variable name at the start of s, zero if none.
+func variableNameLen(s string) int {
+	for i := range len(s) {
+		c := s[i]
+
+		isLetter := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !isLetter && (i == 0 || c < '0' || c > '9') {
+			return i
+		}
+	}
+
+	return len(s)
+}
@@ -1,6 +1,7 @@
 // Package dotenv provides functionality for parsing dotenv-style configuration files.
 // It supports parsing key-value pairs from a byte slice, handling quoted values,
-// multi-line continuations within quotes, and inline comments starting with #.
+// multi-line continuations within quotes, inline comments starting with #, shell-style
+// "export KEY=value" lines, and LF or CRLF line endings.
 // Values can be enclosed in double or single quotes, with proper escape handling
 // and multiline support for complex configuration scenarios.
 package dotenv
@@ -11,11 +12,29 @@ import (
 	"strings"
 )
 
-// Parse parses dotenv-style configuration and returns a map of key->value.
+// Options configures ParseWith.
+type Options struct {
+	// Expand enables the expansion of variable references in unquoted and double-quoted values,
+	// see expandVariables.
+	Expand bool
+
+	// LookupEnv looks up the variables referenced by values that are not defined earlier in the
+	// file, e.g. os.LookupEnv. If nil, such references expand to an empty string.
+	LookupEnv func(name string) (string, bool)
+}
+
+// Parse parses dotenv-style configuration and returns a map of key->value, using the default
+// Options.
+func Parse(data []byte) (map[string]string, error) {
+	return ParseWith(data, Options{})
+}
+
+// ParseWith parses dotenv-style configuration and returns a map of key->value.
 // It supports quoted values and multi-line continuations inside quotes.
 // It also supports inline comments starting with #, which are ignored except when inside quotes.
-func Parse(data []byte) (map[string]string, error) {
+func ParseWith(data []byte, opts Options) (map[string]string, error) {
 	env := make(map[string]string)
+	p := &parser{env: env, opts: opts}
 	scanner := bufio.NewScanner(bytes.NewReader(data))
 
 	var (
@@ -29,7 +48,7 @@ func Parse(data []byte) (map[string]string, error) {
 		line := scanner.Text()
 
 		if inMultiline {
-			handleMultiline(line, &valueBuilder, key, quoteChar, &inMultiline, env)
+			handleMultiline(line, &valueBuilder, key, quoteChar, &inMultiline, p.set)
 
 			continue
 		}
@@ -49,7 +68,7 @@ func Parse(data []byte) (map[string]string, error) {
 			continue
 		}
 
-		env[k] = val
+		p.set(k, val, q)
 	}
 
 	err := scanner.Err()
@@ -60,6 +79,90 @@ func Parse(data []byte) (map[string]string, error) {
 	return env, nil
 }
 
+// parser holds the state of ParseWith.
+type parser struct {
+	env  map[string]string
+	opts Options
+}
+
+// set sets the value of key, unescaping double-quoted values and expanding variable references
+// unless disabled or single-quoted.
+func (p *parser) set(key, value string, quote rune) {
+	switch {
+	case quote == '"':
+		value = unescape(value, p.opts.Expand)
+	case quote == 0 && p.opts.Expand:
+		value = strings.ReplaceAll(value, `\$`, "$$")
+	}
+
+	if p.opts.Expand && quote != '\'' {
+		value = expandVariables(value, p.lookup)
+	}
+
+	p.env[key] = value
+}
+
+// lookup returns the value of the variable name, defined earlier in the file or looked up with
+// Options.LookupEnv.
+func (p *parser) lookup(name string) (string, bool) {
+	if value, ok := p.env[name]; ok {
+		return value, true
+	}
+
+	if p.opts.LookupEnv == nil {
+		return "", false
+	}
+
+	return p.opts.LookupEnv(name)
+}
+
+// escapes maps the characters following a backslash in double-quoted values to the characters
+// they stand for.
+var escapes = map[byte]string{
+	'n':  "\n",
+	't':  "\t",
+	'r':  "\r",
+	'"':  `"`,
+	'\\': `\`,
+	'$':  "$",
+}
+
+// unescape replaces the escape sequences of the double-quoted value, keeping unknown ones as-is.
+// Escaped dollar signs are replaced with "$$" if the value is expanded next, see expandVariables.
+func unescape(value string, expand bool) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+
+	var sb strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			sb.WriteByte(value[i])
+
+			continue
+		}
+
+		c := value[i+1]
+
+		replacement, ok := escapes[c]
+		switch {
+		case !ok:
+			sb.WriteByte('\\')
+
+			continue
+		case c == '$' && expand:
+			replacement = "$$"
+		}
+
+		sb.WriteString(replacement)
+
+		i++
+	}
+
+	return sb.String()
+}
+
 // removeInlineComment removes inline comments starting with #, ignoring those inside quotes.
 func removeInlineComment(line string) string {
 	inQuote := false
@@ -68,9 +171,15 @@ func removeInlineComment(line string) string {
 	var result strings.Builder
 
 loop:
-	for i := range len(line) {
+	for i := 0; i < len(line); i++ {
 		r := rune(line[i])
 		switch {
+		case r == '\\' && inQuote && quoteChar == '"' && i+1 < len(line):
+			// escaped character, e.g. \"
+			result.WriteString(line[i : i+2])
+
+			i++
+
 		case r == '"' || r == '\'':
 			if !inQuote {
 				inQuote = true
@@ -93,7 +202,7 @@ loop:
 }
 
 // parseSingleLine processes a single line when not in multiline mode and extracts key-value pair.
-// It returns key, value, whether it's a multiline start, and the quote character if multiline.
+// It returns key, value, whether it's a multiline start, and the quote character if quoted.
 func parseSingleLine(line string) (string, string, bool, rune) {
 	line = strings.TrimSpace(line)
 	line = removeInlineComment(line)
@@ -110,29 +219,50 @@ func parseSingleLine(line string) (string, string, bool, rune) {
 	key := strings.TrimSpace(parts[0])
 	value := strings.TrimSpace(parts[1])
 
+	// Drop the shell "export" keyword
+	if rest, ok := strings.CutPrefix(key, "export"); ok && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+		key = strings.TrimSpace(rest)
+	}
+
 	// Check for multiline start
-	if (strings.HasPrefix(value, `"`) && !strings.HasSuffix(strings.TrimRight(value, " \t"), `"`)) ||
-		(strings.HasPrefix(value, `'`) && !strings.HasSuffix(strings.TrimRight(value, " \t"), `'`)) {
+	if (strings.HasPrefix(value, `"`) || strings.HasPrefix(value, `'`)) &&
+		(len(value) == 1 || !endsWithQuote(value, rune(value[0]))) {
 		quote := rune(value[0])
 
-		return key, strings.TrimPrefix(value, string(quote)), true, quote
+		return key, value[1:], true, quote
 	}
 
 	// Trim quotes for single-line values
-	value = trimQuotes(value)
+	value, quote := trimQuotes(value)
 
-	return key, value, false, 0
+	return key, value, false, quote
 }
 
-// trimQuotes removes quotes from the value if it starts and ends with matching quotes.
-func trimQuotes(val string) string {
-	if strings.HasPrefix(val, `"`) && strings.HasSuffix(val, `"`) {
-		return strings.Trim(val, `"`)
-	} else if strings.HasPrefix(val, `'`) && strings.HasSuffix(val, `'`) {
-		return strings.Trim(val, `'`)
+// trimQuotes removes quotes from the value if it starts and ends with matching quotes, and returns
+// the quote character, zero if unquoted.
+func trimQuotes(val string) (string, rune) {
+	if len(val) >= 2 && (val[0] == '"' || val[0] == '\'') && val[len(val)-1] == val[0] {
+		return val[1 : len(val)-1], rune(val[0])
 	}
 
-	return val
+	return val, 0
+}
+
+// endsWithQuote reports whether s, with trailing white space trimmed, ends with an unescaped quote.
+func endsWithQuote(s string, quote rune) bool {
+	s = strings.TrimRight(s, " \t")
+	if !strings.HasSuffix(s, string(quote)) {
+		return false
+	}
+
+	if quote != '"' {
+		return true
+	}
+
+	// the quote is escaped if preceded by an odd number of backslashes
+	backslashes := len(s) - 1 - len(strings.TrimRight(s[:len(s)-1], `\`))
+
+	return backslashes%2 == 0
 }
 
 // handleMultiline handles appending to a multiline value and checks for end of multiline.
@@ -142,14 +272,14 @@ func handleMultiline(
 	key string,
 	quote rune,
 	inMulti *bool,
-	env map[string]string,
+	set func(key, value string, quote rune),
 ) {
 	vb.WriteString("\n")
 	vb.WriteString(line)
 
-	if strings.HasSuffix(strings.TrimRight(line, " \t"), string(quote)) {
-		value := strings.Trim(vb.String(), string(quote))
-		env[key] = value
+	if endsWithQuote(line, quote) {
+		value := strings.TrimSuffix(strings.TrimRight(vb.String(), " \t"), string(quote))
+		set(key, value, quote)
 		*inMulti = false
 
 		vb.Reset()
@@ -0,0 +1,111 @@
+package dotenv_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg/internal/dotenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWith_Expand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		data     string
+		env      map[string]string
+		want     map[string]string
+		noExpand bool
+	}{
+		{
+			name: "variables",
+			data: "HOST=db\nURL=postgres://$HOST:${PORT}/app",
+			env:  map[string]string{"PORT": "5432"},
+			want: map[string]string{"HOST": "db", "URL": "postgres://db:5432/app"},
+		},
+		{
+			name: "fallback if unset or empty",
+			data: "EMPTY=\nA=${EMPTY:-x}\nB=${UNSET:-x}\nC=${SET:-x}",
+			env:  map[string]string{"SET": "s"},
+			want: map[string]string{"EMPTY": "", "A": "x", "B": "x", "C": "s"},
+		},
+		{
+			name: "fallback if unset",
+			data: "EMPTY=\nA=${EMPTY-x}\nB=${UNSET-x}\nC=${SET-x}",
+			env:  map[string]string{"SET": "s"},
+			want: map[string]string{"EMPTY": "", "A": "", "B": "x", "C": "s"},
+		},
+		{
+			name: "unset without fallback",
+			data: "A=[${UNSET}][$UNSET]",
+			want: map[string]string{"A": "[][]"},
+		},
+		{
+			name: "double dollar",
+			data: "A=$$HOME\nB=\"cost: $${PRICE}\"\nC='$$'",
+			env:  map[string]string{"HOME": "/root", "PRICE": "1"},
+			want: map[string]string{"A": "$HOME", "B": "cost: ${PRICE}", "C": "$$"},
+		},
+		{
+			name: "escaped dollar",
+			data: "A=\\$HOME\nB=\"\\${HOME}\"",
+			env:  map[string]string{"HOME": "/root"},
+			want: map[string]string{"A": "$HOME", "B": "${HOME}"},
+		},
+		{
+			name: "unterminated reference",
+			data: "A=${HOME\nB=\"x ${HOME\"",
+			env:  map[string]string{"HOME": "/root"},
+			want: map[string]string{"A": "${HOME", "B": "x ${HOME"},
+		},
+		{
+			name: "invalid reference",
+			data: "A=${1X}\nB=${HOME?err}\nC=$ \nD=$",
+			env:  map[string]string{"HOME": "/root"},
+			want: map[string]string{"A": "${1X}", "B": "${HOME?err}", "C": "$", "D": "$"},
+		},
+		{
+			name: "single-quoted values",
+			data: "A='$HOME ${HOME} \\n'",
+			env:  map[string]string{"HOME": "/root"},
+			want: map[string]string{"A": `$HOME ${HOME} \n`},
+		},
+		{
+			name: "self reference",
+			data: "PATH=${PATH}:/opt/bin\nA=${A}x",
+			env:  map[string]string{"PATH": "/usr/bin"},
+			want: map[string]string{"PATH": "/usr/bin:/opt/bin", "A": "x"},
+		},
+		{
+			name: "earlier definitions take precedence",
+			data: "HOST=file\nA=$HOST",
+			env:  map[string]string{"HOST": "env"},
+			want: map[string]string{"HOST": "file", "A": "file"},
+		},
+		{
+			name:     "disabled",
+			data:     "A=$HOME\nB=\"${HOME:-x}\"",
+			env:      map[string]string{"HOME": "/root"},
+			want:     map[string]string{"A": "$HOME", "B": "${HOME:-x}"},
+			noExpand: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := dotenv.ParseWith([]byte(tt.data), dotenv.Options{
+				Expand: !tt.noExpand,
+				LookupEnv: func(name string) (string, bool) {
+					value, ok := tt.env[name]
+
+					return value, ok
+				},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package env
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// CoerceValues replaces the string values of data, nested maps included, that look like booleans,
+// integers, floats, or JSON objects or arrays with their typed values, see CoerceValue.
+func CoerceValues(data map[string]any) {
+	for key, value := range data {
+		switch v := value.(type) {
+		case string:
+			data[key] = CoerceValue(v)
+		case map[string]any:
+			CoerceValues(v)
+		}
+	}
+}
+
+// CoerceValue parses value into a bool ("true" or "false", case-insensitively), an int, a float64,
+// or, if it is a JSON object or array, a map[string]any or []any. Only numbers with a fraction or
+// an exponent become floats. Other values, including numbers with leading zeros such as "0755" or
+// "007" and integers overflowing an int, are returned as-is.
+func CoerceValue(value string) any {
+	trimmed := strings.TrimSpace(value)
+
+	switch strings.ToLower(trimmed) {
+	case "true":
+		return true
+	case "false":
+		return false
+	case "":
+		return value
+	}
+
+	if isNumber(trimmed) {
+		if i, err := strconv.Atoi(trimmed); err == nil {
+			return i
+		}
+
+		// integers overflowing an int, e.g. account numbers, are kept as strings rather than
+		// losing precision as floats
+		if !strings.ContainsAny(trimmed, ".eE") {
+			return value
+		}
+
+		if f, err := strconv.ParseFloat(trimmed, 64); err == nil {
+			return f
+		}
+
+		return value
+	}
+
+	if (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid([]byte(trimmed)) {
+		var decoded any
+		if err := json.Unmarshal([]byte(trimmed), &decoded); err == nil {
+			return decoded
+		}
+	}
+
+	return value
+}
+
+// isNumber reports whether s is a decimal number without leading zeros, e.g. "-12", "3.5" or
+// "1e6", but not "0755", "0x1F", "Inf" or "1_000".
+func isNumber(s string) bool {
+	digits := strings.TrimLeft(s, "+-")
+	if len(s)-len(digits) > 1 || digits == "" || digits[0] < '0' || digits[0] > '9' {
+		return false
+	}
+
+	if len(digits) > 1 && digits[0] == '0' && digits[1] != '.' && digits[1] != 'e' && digits[1] != 'E' {
+		return false
+	}
+
+	return strings.IndexFunc(digits, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.' && r != 'e' && r != 'E' && r != '+' && r != '-'
+	}) < 0
+}
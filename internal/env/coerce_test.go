@@ -0,0 +1,46 @@
+package env_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg/internal/env"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoerceValue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		value string
+		want  any
+	}{
+		{name: "true", value: "true", want: true},
+		{name: "false mixed case", value: "False", want: false},
+		{name: "not a bool", value: "yes", want: "yes"},
+		{name: "int", value: "8080", want: 8080},
+		{name: "negative int", value: "-12", want: -12},
+		{name: "zero", value: "0", want: 0},
+		{name: "float", value: "0.75", want: 0.75},
+		{name: "exponent", value: "1e3", want: 1000.0},
+		{name: "leading zero", value: "0755", want: "0755"},
+		{name: "hex", value: "0x1F", want: "0x1F"},
+		{name: "infinity", value: "Inf", want: "Inf"},
+		{name: "version", value: "1.2.3", want: "1.2.3"},
+		{name: "int overflow", value: "99999999999999999999", want: "99999999999999999999"},
+		{name: "negative int overflow", value: "-99999999999999999999", want: "-99999999999999999999"},
+		{name: "object", value: `{"a": 1, "b": [true]}`, want: map[string]any{"a": 1.0, "b": []any{true}}},
+		{name: "array", value: `["a", "b"]`, want: []any{"a", "b"}},
+		{name: "invalid json", value: "[not json]", want: "[not json]"},
+		{name: "empty", value: "", want: ""},
+		{name: "text", value: "hello", want: "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, env.CoerceValue(tt.value))
+		})
+	}
+}
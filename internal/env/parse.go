@@ -18,8 +18,9 @@ const (
 // - pre: prefix to filter variables by (variables not matching prefix are excluded)
 // - sep: separator used in environment variable names to create nested structure
 // - normalizeKey: whether to normalize keys by removing underscore separators
+// - skip: reports whether a variable must be filtered out, e.g. IsUnsafeVar; nil keeps all variables
 // Returns a nested map[string]any containing the processed environment variables.
-func ParseVariables(vars map[string]string, pre, sep string, normalizeKey bool) map[string]any {
+func ParseVariables(vars map[string]string, pre, sep string, normalizeKey bool, skip func(key string) bool) map[string]any {
 	data := make(map[string]any)
 
 	pre = strings.ToLower(strings.TrimSpace(pre))
@@ -28,7 +29,7 @@ func ParseVariables(vars map[string]string, pre, sep string, normalizeKey bool)
 		key = strings.ToLower(strings.TrimSpace(key))
 
 		// Filter out unsafe variables
-		if IsUnsafeVar(key) {
+		if skip != nil && skip(key) {
 			continue
 		}
 
@@ -0,0 +1,713 @@
+// Package jsonschema validates decoded configuration values against a JSON Schema document.
+//
+// It implements the subset of the JSON Schema vocabulary (draft 2020-12) relevant to
+// configuration contracts:
+//   - type, enum and const
+//   - properties, required and additionalProperties
+//   - items, minItems and maxItems
+//   - minimum, maximum, exclusiveMinimum and exclusiveMaximum
+//   - minLength, maxLength and pattern
+//   - allOf, anyOf, oneOf and not
+//   - $ref to local definitions (e.g. "#/$defs/port")
+//
+// Other keywords are ignored.
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrInvalidSchema indicates that a schema document is malformed.
+	ErrInvalidSchema = errors.New("invalid schema")
+
+	// ErrUnresolvedRef indicates that a $ref doesn't point to a definition of the schema document.
+	ErrUnresolvedRef = errors.New("unresolved $ref")
+)
+
+// Error describes a value violating a schema keyword.
+type Error struct {
+	// Key is the dotted key of the value, with array elements addressed by their index (e.g.
+	// "servers.0.port"), or empty for the root value.
+	Key string
+	// Keyword is the schema keyword violated by the value, e.g. "required".
+	Keyword string
+	// Message describes the violation.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e.Key == "" {
+		return e.Message
+	}
+
+	return "key " + e.Key + ": " + e.Message
+}
+
+// Schema is a compiled JSON Schema document.
+type Schema struct {
+	root *node
+}
+
+// node is a compiled (sub)schema.
+type node struct {
+	// always is set for boolean schemas, which accept (true) or reject (false) any value.
+	always *bool
+
+	types    []string
+	enum     []any
+	constVal any
+	hasConst bool
+
+	properties           map[string]*node
+	required             []string
+	additionalProperties *node
+
+	items              *node
+	minItems, maxItems int
+
+	minimum, maximum                   *float64
+	exclusiveMinimum, exclusiveMaximum *float64
+
+	minLength, maxLength int
+	pattern              *regexp.Regexp
+
+	allOf, anyOf, oneOf []*node
+	not                 *node
+
+	ref *node
+}
+
+// compiler compiles a schema document, resolving its references.
+type compiler struct {
+	doc  any
+	refs map[string]*node
+}
+
+// Compile parses and compiles the JSON Schema document data.
+func Compile(data []byte) (*Schema, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	c := &compiler{doc: doc, refs: make(map[string]*node)}
+
+	root, err := c.compile(doc, "#")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Schema{root: root}, nil
+}
+
+// compile compiles the (sub)schema s found at the JSON pointer ptr.
+func (c *compiler) compile(s any, ptr string) (*node, error) {
+	if b, ok := s.(bool); ok {
+		return &node{always: &b}, nil
+	}
+
+	m, ok := s.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s must be an object or a boolean", ErrInvalidSchema, ptr)
+	}
+
+	n := &node{minItems: -1, maxItems: -1, minLength: -1, maxLength: -1}
+
+	var err error
+
+	if n.types, err = schemaTypes(m["type"], ptr); err != nil {
+		return nil, err
+	}
+
+	if enum, ok := m["enum"]; ok {
+		if n.enum, ok = enum.([]any); !ok {
+			return nil, fmt.Errorf("%w: %s/enum must be an array", ErrInvalidSchema, ptr)
+		}
+	}
+
+	n.constVal, n.hasConst = m["const"]
+
+	if err = c.compileObject(n, m, ptr); err != nil {
+		return nil, err
+	}
+
+	if err = c.compileArray(n, m, ptr); err != nil {
+		return nil, err
+	}
+
+	if err = compileBounds(n, m, ptr); err != nil {
+		return nil, err
+	}
+
+	if err = c.compileCombinators(n, m, ptr); err != nil {
+		return nil, err
+	}
+
+	if ref, ok := m["$ref"].(string); ok {
+		if n.ref, err = c.resolve(ref); err != nil {
+			return nil, err
+		}
+	}
+
+	return n, nil
+}
+
+// compileObject compiles the object keywords of m into n.
+func (c *compiler) compileObject(n *node, m map[string]any, ptr string) error {
+	if props, ok := m["properties"]; ok {
+		propMap, ok := props.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: %s/properties must be an object", ErrInvalidSchema, ptr)
+		}
+
+		n.properties = make(map[string]*node, len(propMap))
+
+		for name, prop := range propMap {
+			compiled, err := c.compile(prop, ptr+"/properties/"+escapePointer(name))
+			if err != nil {
+				return err
+			}
+
+			n.properties[name] = compiled
+		}
+	}
+
+	if required, ok := m["required"]; ok {
+		names, ok := required.([]any)
+		if !ok {
+			return fmt.Errorf("%w: %s/required must be an array", ErrInvalidSchema, ptr)
+		}
+
+		for _, name := range names {
+			str, ok := name.(string)
+			if !ok {
+				return fmt.Errorf("%w: %s/required must contain strings", ErrInvalidSchema, ptr)
+			}
+
+			n.required = append(n.required, str)
+		}
+	}
+
+	if additional, ok := m["additionalProperties"]; ok {
+		compiled, err := c.compile(additional, ptr+"/additionalProperties")
+		if err != nil {
+			return err
+		}
+
+		n.additionalProperties = compiled
+	}
+
+	return nil
+}
+
+// compileArray compiles the array keywords of m into n.
+func (c *compiler) compileArray(n *node, m map[string]any, ptr string) error {
+	if items, ok := m["items"]; ok {
+		compiled, err := c.compile(items, ptr+"/items")
+		if err != nil {
+			return err
+		}
+
+		n.items = compiled
+	}
+
+	var err error
+
+	if n.minItems, err = count(m, "minItems", ptr); err != nil {
+		return err
+	}
+
+	n.maxItems, err = count(m, "maxItems", ptr)
+
+	return err
+}
+
+// compileBounds compiles the numeric and string keywords of m into n.
+func compileBounds(n *node, m map[string]any, ptr string) error {
+	for keyword, dst := range map[string]**float64{
+		"minimum":          &n.minimum,
+		"maximum":          &n.maximum,
+		"exclusiveMinimum": &n.exclusiveMinimum,
+		"exclusiveMaximum": &n.exclusiveMaximum,
+	} {
+		if v, ok := m[keyword]; ok {
+			f, ok := v.(float64)
+			if !ok {
+				return fmt.Errorf("%w: %s/%s must be a number", ErrInvalidSchema, ptr, keyword)
+			}
+
+			*dst = &f
+		}
+	}
+
+	var err error
+
+	if n.minLength, err = count(m, "minLength", ptr); err != nil {
+		return err
+	}
+
+	if n.maxLength, err = count(m, "maxLength", ptr); err != nil {
+		return err
+	}
+
+	if pattern, ok := m["pattern"]; ok {
+		str, ok := pattern.(string)
+		if !ok {
+			return fmt.Errorf("%w: %s/pattern must be a string", ErrInvalidSchema, ptr)
+		}
+
+		if n.pattern, err = regexp.Compile(str); err != nil {
+			return fmt.Errorf("%w: %s/pattern: %w", ErrInvalidSchema, ptr, err)
+		}
+	}
+
+	return nil
+}
+
+// compileCombinators compiles the allOf, anyOf, oneOf and not keywords of m into n.
+func (c *compiler) compileCombinators(n *node, m map[string]any, ptr string) error {
+	for keyword, dst := range map[string]*[]*node{"allOf": &n.allOf, "anyOf": &n.anyOf, "oneOf": &n.oneOf} {
+		v, ok := m[keyword]
+		if !ok {
+			continue
+		}
+
+		schemas, ok := v.([]any)
+		if !ok || len(schemas) == 0 {
+			return fmt.Errorf("%w: %s/%s must be a non-empty array", ErrInvalidSchema, ptr, keyword)
+		}
+
+		for i, s := range schemas {
+			compiled, err := c.compile(s, ptr+"/"+keyword+"/"+strconv.Itoa(i))
+			if err != nil {
+				return err
+			}
+
+			*dst = append(*dst, compiled)
+		}
+	}
+
+	if not, ok := m["not"]; ok {
+		compiled, err := c.compile(not, ptr+"/not")
+		if err != nil {
+			return err
+		}
+
+		n.not = compiled
+	}
+
+	return nil
+}
+
+// resolve returns the node of the local reference ref, e.g. "#/$defs/port", compiling it on first
+// use. Recursive references share the same node.
+func (c *compiler) resolve(ref string) (*node, error) {
+	if n, ok := c.refs[ref]; ok {
+		return n, nil
+	}
+
+	if ref != "#" && !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("%w %q: only local references are supported", ErrUnresolvedRef, ref)
+	}
+
+	target := c.doc
+
+	if ref != "#" {
+		for _, token := range strings.Split(ref[2:], "/") {
+			m, ok := target.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%w %q", ErrUnresolvedRef, ref)
+			}
+
+			if target, ok = m[unescapePointer(token)]; !ok {
+				return nil, fmt.Errorf("%w %q", ErrUnresolvedRef, ref)
+			}
+		}
+	}
+
+	// register the node before compiling it, so that recursive references resolve to it
+	n := &node{}
+	c.refs[ref] = n
+
+	compiled, err := c.compile(target, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	*n = *compiled
+
+	return n, nil
+}
+
+// Validate validates v, a configuration value decoded into maps, slices and scalars, against the
+// schema, and returns the joined *Error of every violation.
+func (s *Schema) Validate(v any) error {
+	var errs []error
+
+	s.root.validate(v, "", &errs)
+
+	return errors.Join(errs...)
+}
+
+// validate appends the violations of v, found at key, to errs.
+func (n *node) validate(v any, key string, errs *[]error) {
+	fail := func(keyword, format string, args ...any) {
+		*errs = append(*errs, &Error{Key: key, Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if n.always != nil {
+		if !*n.always {
+			fail("false", "no value is allowed")
+		}
+
+		return
+	}
+
+	if n.ref != nil {
+		n.ref.validate(v, key, errs)
+	}
+
+	if len(n.types) > 0 && !slices.ContainsFunc(n.types, func(t string) bool { return hasType(v, t) }) {
+		fail("type", "must be of type %s, got %s", strings.Join(n.types, " or "), typeOf(v))
+
+		return
+	}
+
+	if n.enum != nil && !slices.ContainsFunc(n.enum, func(e any) bool { return equal(v, e) }) {
+		fail("enum", "must be one of %v, got %v", n.enum, v)
+	}
+
+	if n.hasConst && !equal(v, n.constVal) {
+		fail("const", "must be %v, got %v", n.constVal, v)
+	}
+
+	switch value := normalize(v).(type) {
+	case map[string]any:
+		n.validateObject(value, key, errs)
+	case []any:
+		n.validateArray(value, key, errs)
+	case float64:
+		n.validateNumber(value, fail)
+	case string:
+		n.validateString(value, fail)
+	}
+
+	n.validateCombinators(v, key, errs, fail)
+}
+
+// validateObject appends the violations of the object m, found at key, to errs.
+func (n *node) validateObject(m map[string]any, key string, errs *[]error) {
+	for _, name := range n.required {
+		if _, ok := m[name]; !ok {
+			*errs = append(*errs, &Error{Key: key, Keyword: "required", Message: "missing required key " + name})
+		}
+	}
+
+	for _, name := range sortedKeys(m) {
+		if prop, ok := n.properties[name]; ok {
+			prop.validate(m[name], join(key, name), errs)
+		} else if n.additionalProperties != nil {
+			if n.additionalProperties.always != nil && !*n.additionalProperties.always {
+				*errs = append(*errs, &Error{
+					Key:     join(key, name),
+					Keyword: "additionalProperties",
+					Message: "key is not allowed",
+				})
+
+				continue
+			}
+
+			n.additionalProperties.validate(m[name], join(key, name), errs)
+		}
+	}
+}
+
+// validateArray appends the violations of the array a, found at key, to errs.
+func (n *node) validateArray(a []any, key string, errs *[]error) {
+	if n.minItems >= 0 && len(a) < n.minItems {
+		*errs = append(*errs, &Error{
+			Key:     key,
+			Keyword: "minItems",
+			Message: fmt.Sprintf("must have at least %d items, got %d", n.minItems, len(a)),
+		})
+	}
+
+	if n.maxItems >= 0 && len(a) > n.maxItems {
+		*errs = append(*errs, &Error{
+			Key:     key,
+			Keyword: "maxItems",
+			Message: fmt.Sprintf("must have at most %d items, got %d", n.maxItems, len(a)),
+		})
+	}
+
+	if n.items != nil {
+		for i, item := range a {
+			n.items.validate(item, join(key, strconv.Itoa(i)), errs)
+		}
+	}
+}
+
+// validateNumber reports the bounds violated by f with fail.
+func (n *node) validateNumber(f float64, fail func(keyword, format string, args ...any)) {
+	if n.minimum != nil && f < *n.minimum {
+		fail("minimum", "must be >= %v, got %v", *n.minimum, f)
+	}
+
+	if n.maximum != nil && f > *n.maximum {
+		fail("maximum", "must be <= %v, got %v", *n.maximum, f)
+	}
+
+	if n.exclusiveMinimum != nil && f <= *n.exclusiveMinimum {
+		fail("exclusiveMinimum", "must be > %v, got %v", *n.exclusiveMinimum, f)
+	}
+
+	if n.exclusiveMaximum != nil && f >= *n.exclusiveMaximum {
+		fail("exclusiveMaximum", "must be < %v, got %v", *n.exclusiveMaximum, f)
+	}
+}
+
+// validateString reports the length and pattern constraints violated by str with fail.
+func (n *node) validateString(str string, fail func(keyword, format string, args ...any)) {
+	length := utf8.RuneCountInString(str)
+
+	if n.minLength >= 0 && length < n.minLength {
+		fail("minLength", "must be at least %d characters long, got %d", n.minLength, length)
+	}
+
+	if n.maxLength >= 0 && length > n.maxLength {
+		fail("maxLength", "must be at most %d characters long, got %d", n.maxLength, length)
+	}
+
+	if n.pattern != nil && !n.pattern.MatchString(str) {
+		fail("pattern", "must match pattern %q", n.pattern.String())
+	}
+}
+
+// validateCombinators appends the violations of the allOf, anyOf, oneOf and not keywords by v,
+// found at key, to errs.
+func (n *node) validateCombinators(v any, key string, errs *[]error, fail func(keyword, format string, args ...any)) {
+	for _, s := range n.allOf {
+		s.validate(v, key, errs)
+	}
+
+	if n.anyOf != nil && n.matches(n.anyOf, v) == 0 {
+		fail("anyOf", "must match at least one schema of anyOf")
+	}
+
+	if n.oneOf != nil {
+		if matched := n.matches(n.oneOf, v); matched != 1 {
+			fail("oneOf", "must match exactly one schema of oneOf, matched %d", matched)
+		}
+	}
+
+	if n.not != nil && n.matches([]*node{n.not}, v) == 1 {
+		fail("not", "must not match the schema of not")
+	}
+}
+
+// matches returns the number of schemas v is valid against.
+func (n *node) matches(schemas []*node, v any) int {
+	matched := 0
+
+	for _, s := range schemas {
+		var errs []error
+
+		s.validate(v, "", &errs)
+
+		if len(errs) == 0 {
+			matched++
+		}
+	}
+
+	return matched
+}
+
+// schemaTypes returns the types of the type keyword t, a string or an array of strings.
+func schemaTypes(t any, ptr string) ([]string, error) {
+	switch t := t.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{t}, nil
+	case []any:
+		types := make([]string, 0, len(t))
+
+		for _, typ := range t {
+			str, ok := typ.(string)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s/type must contain strings", ErrInvalidSchema, ptr)
+			}
+
+			types = append(types, str)
+		}
+
+		return types, nil
+	default:
+		return nil, fmt.Errorf("%w: %s/type must be a string or an array", ErrInvalidSchema, ptr)
+	}
+}
+
+// count returns the non-negative integer value of the keyword of m, or -1 if it is absent.
+func count(m map[string]any, keyword, ptr string) (int, error) {
+	v, ok := m[keyword]
+	if !ok {
+		return -1, nil
+	}
+
+	f, ok := v.(float64)
+	if !ok || f < 0 || f != math.Trunc(f) {
+		return 0, fmt.Errorf("%w: %s/%s must be a non-negative integer", ErrInvalidSchema, ptr, keyword)
+	}
+
+	return int(f), nil
+}
+
+// hasType reports whether v is an instance of the JSON type t.
+func hasType(v any, t string) bool {
+	if t == "integer" {
+		f, ok := normalize(v).(float64)
+
+		return ok && f == math.Trunc(f)
+	}
+
+	return typeOf(v) == t
+}
+
+// typeOf returns the JSON type of v.
+func typeOf(v any) string {
+	switch normalize(v).(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// normalize converts v to the representation of its JSON type: numbers to float64, and slices
+// and string-keyed maps of any type to []any and map[string]any.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case nil, bool, float64, string, []any, map[string]any:
+		return v
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+
+		return v
+	}
+
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.String:
+		return rv.String()
+	case reflect.Slice, reflect.Array:
+		a := make([]any, rv.Len())
+		for i := range a {
+			a[i] = rv.Index(i).Interface()
+		}
+
+		return a
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return v
+		}
+
+		m := make(map[string]any, rv.Len())
+		for iter := rv.MapRange(); iter.Next(); {
+			m[iter.Key().String()] = iter.Value().Interface()
+		}
+
+		return m
+	default:
+		return v
+	}
+}
+
+// equal reports whether a and b are equal JSON values.
+func equal(a, b any) bool {
+	a, b = normalize(a), normalize(b)
+
+	switch a := a.(type) {
+	case []any:
+		bs, ok := b.([]any)
+
+		return ok && slices.EqualFunc(a, bs, equal)
+	case map[string]any:
+		bm, ok := b.(map[string]any)
+		if !ok || len(a) != len(bm) {
+			return false
+		}
+
+		for k, v := range a {
+			if bv, ok := bm[k]; !ok || !equal(v, bv) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+// join returns the dotted key of the child name of key.
+func join(key, name string) string {
+	if key == "" {
+		return name
+	}
+
+	return key + "." + name
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// escapePointer escapes name as a JSON pointer reference token.
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// unescapePointer unescapes the JSON pointer reference token.
+func unescapePointer(token string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+}
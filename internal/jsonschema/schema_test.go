@@ -0,0 +1,194 @@
+package jsonschema_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg/internal/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompile_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr error
+	}{
+		{name: "malformed JSON", schema: `{`, wantErr: jsonschema.ErrInvalidSchema},
+		{name: "not an object", schema: `42`, wantErr: jsonschema.ErrInvalidSchema},
+		{name: "invalid type", schema: `{"type": 1}`, wantErr: jsonschema.ErrInvalidSchema},
+		{name: "invalid pattern", schema: `{"pattern": "("}`, wantErr: jsonschema.ErrInvalidSchema},
+		{name: "negative minItems", schema: `{"minItems": -1}`, wantErr: jsonschema.ErrInvalidSchema},
+		{name: "empty anyOf", schema: `{"anyOf": []}`, wantErr: jsonschema.ErrInvalidSchema},
+		{name: "missing definition", schema: `{"$ref": "#/$defs/port"}`, wantErr: jsonschema.ErrUnresolvedRef},
+		{name: "remote reference", schema: `{"$ref": "https://example.com/schema.json"}`, wantErr: jsonschema.ErrUnresolvedRef},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := jsonschema.Compile([]byte(tt.schema))
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSchema_Validate(t *testing.T) {
+	t.Parallel()
+
+	const schema = `{
+		"type": "object",
+		"required": ["server"],
+		"properties": {
+			"server": {
+				"type": "object",
+				"required": ["port"],
+				"additionalProperties": false,
+				"properties": {
+					"host": {"type": "string", "minLength": 1, "pattern": "^[a-z.]+$"},
+					"port": {"$ref": "#/$defs/port"},
+					"mode": {"enum": ["http", "https"]}
+				}
+			},
+			"replicas": {"type": "integer", "exclusiveMinimum": 0},
+			"tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}},
+			"timeout": {"anyOf": [{"type": "string"}, {"type": "number"}]},
+			"tls": {"oneOf": [{"required": ["cert"]}, {"required": ["acme"]}]},
+			"debug": {"not": {"const": true}}
+		},
+		"$defs": {
+			"port": {"type": "integer", "minimum": 1, "maximum": 65535}
+		}
+	}`
+
+	compiled, err := jsonschema.Compile([]byte(schema))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		value    map[string]any
+		wantKeys []string
+		wantMsgs []string
+	}{
+		{
+			name: "valid",
+			value: map[string]any{
+				"server":   map[string]any{"host": "example.com", "port": 8080, "mode": "https"},
+				"replicas": 3.0,
+				"tags":     []string{"a", "b"},
+				"timeout":  "5s",
+				"tls":      map[string]any{"cert": "cert.pem"},
+				"debug":    false,
+			},
+		},
+		{
+			name:     "missing required key",
+			value:    map[string]any{},
+			wantKeys: []string{""},
+			wantMsgs: []string{"missing required key server"},
+		},
+		{
+			name: "nested violations",
+			value: map[string]any{
+				"server": map[string]any{"host": "Example", "port": 70000, "mode": "ftp", "extra": 1},
+			},
+			wantKeys: []string{"server.extra", "server.host", "server.mode", "server.port"},
+			wantMsgs: []string{
+				"key server.extra: key is not allowed",
+				`key server.host: must match pattern "^[a-z.]+$"`,
+				"key server.mode: must be one of [http https], got ftp",
+				"key server.port: must be <= 65535, got 70000",
+			},
+		},
+		{
+			name: "types and bounds",
+			value: map[string]any{
+				"server":   map[string]any{"port": "8080"},
+				"replicas": 1.5,
+				"tags":     []any{"a", 1, "c"},
+			},
+			wantKeys: []string{"replicas", "server.port", "tags", "tags.1"},
+			wantMsgs: []string{
+				"key replicas: must be of type integer, got number",
+				"key server.port: must be of type integer, got string",
+				"key tags: must have at most 2 items, got 3",
+				"key tags.1: must be of type string, got number",
+			},
+		},
+		{
+			name: "combinators",
+			value: map[string]any{
+				"server":  map[string]any{"port": 80},
+				"timeout": true,
+				"tls":     map[string]any{"cert": "cert.pem", "acme": true},
+				"debug":   true,
+			},
+			wantKeys: []string{"debug", "timeout", "tls"},
+			wantMsgs: []string{
+				"key debug: must not match the schema of not",
+				"key timeout: must match at least one schema of anyOf",
+				"key tls: must match exactly one schema of oneOf, matched 2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := compiled.Validate(tt.value)
+			if tt.wantKeys == nil {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.Error(t, err)
+
+			var joined interface{ Unwrap() []error }
+			require.ErrorAs(t, err, &joined)
+
+			var keys, msgs []string
+
+			for _, e := range joined.Unwrap() {
+				var schemaErr *jsonschema.Error
+				require.True(t, errors.As(e, &schemaErr))
+
+				keys = append(keys, schemaErr.Key)
+				msgs = append(msgs, schemaErr.Error())
+			}
+
+			assert.Equal(t, tt.wantKeys, keys)
+			assert.Equal(t, tt.wantMsgs, msgs)
+		})
+	}
+}
+
+func TestSchema_Validate_RecursiveRef(t *testing.T) {
+	t.Parallel()
+
+	compiled, err := jsonschema.Compile([]byte(`{
+		"$defs": {
+			"node": {
+				"type": "object",
+				"properties": {"name": {"type": "string"}, "children": {"type": "array", "items": {"$ref": "#/$defs/node"}}}
+			}
+		},
+		"$ref": "#/$defs/node"
+	}`))
+	require.NoError(t, err)
+
+	require.NoError(t, compiled.Validate(map[string]any{
+		"name":     "root",
+		"children": []any{map[string]any{"name": "child"}},
+	}))
+
+	err = compiled.Validate(map[string]any{
+		"children": []any{map[string]any{"children": []any{map[string]any{"name": 1}}}},
+	})
+	require.EqualError(t, err, "key children.0.children.0.name: must be of type string, got number")
+}
@@ -1,7 +1,7 @@
 // Package maps provides utilities for deep binding and merging of maps into Go data structures.
 // It supports recursive binding of map[string]any into structs with handling for nested types:
-// structs, slices, arrays, maps and pointers. Field matching uses json tags (if present) then
-// case-insensitive field names.
+// structs, slices, arrays, maps and pointers. Field matching uses gcfg then json tags (if present)
+// then case-insensitive field names.
 //
 // The package includes:
 //   - Bind: converts map[string]any to struct handling nested types
@@ -10,7 +10,15 @@
 //
 // Key features:
 //   - Type conversion between common Go types
-//   - Support for json struct tags
+//   - Support for gcfg and json struct tags
+//   - Required fields (`required:"true"` struct tag) and default values (`default` struct tag)
+//   - Deprecated fields (`deprecated` struct tag) reported via a callback
+//   - Alternative keys of fields (`alias` struct tag)
+//   - time.Time values parsed with custom layouts and time zones (`layout` and `tz` struct tags)
+//   - Byte sizes with unit suffixes, e.g. "10MB" or "1GiB" (`bytesize:"true"` struct tag)
+//   - net.IPNet (CIDR notation) and url.URL values parsed from strings
+//   - Types implementing Unmarshaler or json.Unmarshaler, and encoding.TextUnmarshaler bound from
+//     strings
 //   - Case-insensitive field matching
 //   - Handling of nested types (structs, slices, arrays, maps)
 //   - Pointer auto-initialization
@@ -18,10 +26,15 @@
 package maps
 
 import (
+	"encoding"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
+	"net"
+	"net/url"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -34,7 +47,8 @@ var (
 	ErrDestIsNil = errors.New("dest is nil")
 	// ErrDestMustBePointer indicates that the destination must be a non-nil pointer to a struct.
 	ErrDestMustBePointer = errors.New("dest must be a non-nil pointer to a struct")
-	// ErrDestMustPointToStruct indicates that the destination pointer must point to a struct.
+	// ErrDestMustPointToStruct indicates that the destination pointer must point to a struct (or a
+	// map).
 	ErrDestMustPointToStruct = errors.New("dest must point to a struct")
 	// ErrDestinationNotSettable indicates that the destination value cannot be set.
 	ErrDestinationNotSettable = errors.New("destination not settable")
@@ -42,6 +56,8 @@ var (
 	ErrSrcIsNil = errors.New("src is nil")
 	// ErrSrcMustBeStruct indicates that the source must be a struct or pointer to struct.
 	ErrSrcMustBeStruct = errors.New("src must be a struct or pointer to struct")
+	// ErrMissingKey indicates that the key of a field tagged `required:"true"` is absent.
+	ErrMissingKey = errors.New("missing key")
 
 	// Type conversion errors...
 
@@ -57,6 +73,8 @@ var (
 	ErrUnsupportedKind = errors.New("unsupported kind")
 	// ErrUnsupportedKeyType indicates unsupported map key type during conversion.
 	ErrUnsupportedKeyType = errors.New("unsupported key type")
+	// ErrDecodeHook indicates that a decode hook failed to convert a value.
+	ErrDecodeHook = errors.New("decode hook failed")
 
 	// Conversion errors...
 
@@ -68,6 +86,16 @@ var (
 	ErrCannotConvertToUint64 = errors.New("cannot convert to uint64")
 	// ErrCannotConvertToFloat64 indicates type cannot be converted to float64.
 	ErrCannotConvertToFloat64 = errors.New("cannot convert to float64")
+	// ErrCannotConvertToDuration indicates type cannot be converted to time.Duration.
+	ErrCannotConvertToDuration = errors.New("cannot convert to duration")
+	// ErrCannotConvertToTime indicates a string cannot be parsed as a time.Time.
+	ErrCannotConvertToTime = errors.New("cannot convert to time")
+	// ErrCannotUnmarshal indicates that a type implementing Unmarshaler or json.Unmarshaler failed
+	// to unmarshal a value.
+	ErrCannotUnmarshal = errors.New("cannot unmarshal into")
+	// ErrCannotUnmarshalText indicates that a type implementing encoding.TextUnmarshaler failed to
+	// unmarshal a string.
+	ErrCannotUnmarshalText = errors.New("cannot unmarshal text into")
 
 	// Range/overflow errors...
 
@@ -101,10 +129,111 @@ var (
 	ErrMapKeyConversion = errors.New("map key conversion error")
 )
 
-// Bind binds src (map[string]any) into dest which must be a pointer to struct.
+// BindError is an error binding the value at a configuration key into a field.
+type BindError struct {
+	// Key is the dotted key of the value, e.g. "servers.0.port".
+	Key string
+	// Field is the path of the field, e.g. "Servers[0].Port".
+	Field string
+	// Type is the type of the field.
+	Type reflect.Type
+	// SourceType is the type of the value, nil if the key is missing (see ErrMissingKey).
+	SourceType reflect.Type
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *BindError) Error() string {
+	if errors.Is(e.Err, ErrMissingKey) {
+		return fmt.Sprintf("%v %s", e.Err, e.Key)
+	}
+
+	msg := fmt.Sprintf("cannot bind %v to %v", e.SourceType, e.Type)
+	if e.Field != "" {
+		msg = fmt.Sprintf("cannot bind %v to field %s of type %v", e.SourceType, e.Field, e.Type)
+	}
+
+	if e.Key != "" {
+		msg = "key " + e.Key + ": " + msg
+	}
+
+	return msg + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+// defaultTagNames are the struct tags consulted, in order, for the keys of struct fields.
+var defaultTagNames = []string{"gcfg", "json"}
+
+// Unmarshaler is implemented by types decoding themselves from configuration values: the binder
+// calls UnmarshalConfig with the source value (e.g., a map[string]any for a nested object) instead
+// of binding it.
+type Unmarshaler interface {
+	UnmarshalConfig(value any) error
+}
+
+// DecodeHook converts value, of type from, before it is bound to a value of type to (with pointers
+// dereferenced). It returns the value to bind, either converted or unchanged.
+type DecodeHook func(from, to reflect.Type, value any) (any, error)
+
+// BindOptions configures how values are bound by BindWithOptions.
+type BindOptions struct {
+	// DecodeHooks are called, in order, with every non-nil value before it is bound, each with the
+	// value returned by the previous one.
+	DecodeHooks []DecodeHook
+	// KeySeparator separates the segments of the dotted keys reported in errors and to
+	// OnDeprecated. Default: ".".
+	KeySeparator string
+	// OnDeprecated, if set, is called with the dotted key and the message of the bound fields
+	// tagged `deprecated:"<message>"`.
+	OnDeprecated func(key, message string)
+	// KeyPrefix is the dotted key of the bound value, prefixing the keys reported in errors and to
+	// OnDeprecated.
+	KeyPrefix string
+	// OnUnusedKey, if set, is called with the dotted key of the values bound to structs which don't
+	// match any of their fields.
+	OnUnusedKey func(key string)
+	// TagNames are the struct tags consulted, in order, for the keys of struct fields, before
+	// falling back to the case-insensitive field name. Default: defaultTagNames.
+	TagNames []string
+
+	// DurationUnit is the unit of the bare numbers (e.g., 30 or "30") bound to time.Duration values,
+	// e.g. time.Second. Default: time.Nanosecond.
+	DurationUnit time.Duration
+	// SliceSeparator, if not empty, splits string values bound to slices into their items
+	// (e.g., "a,b,c" with "," binds to []string{"a", "b", "c"}). Items are trimmed and empty items
+	// are dropped.
+	SliceSeparator string
+}
+
+// binder binds values according to its options.
+type binder struct {
+	opts BindOptions
+	// path is the dotted path of the bound value, used in error messages.
+	path string
+	// fieldPath is the Go path of the bound value, e.g. Servers[0].Port, used in error messages.
+	fieldPath string
+	// layout and tz are the `layout` and `tz` tags of the bound field, used to parse time.Time
+	// values.
+	layout string
+	tz     string
+	// byteSize reports whether the bound field is tagged `bytesize:"true"`.
+	byteSize bool
+}
+
+// Bind binds src (map[string]any) into dest which must be a pointer to a struct or a map.
 // It recursively assigns values handling nested structs, slices, arrays, maps and pointers.
-// Field matching: `json` tag (if present) then case-insensitive field name.
+// Field matching: `gcfg` then `json` tag (if present) then case-insensitive field name.
 func Bind(src map[string]any, dest any) error {
+	return BindWithOptions(src, dest, BindOptions{})
+}
+
+// BindWithOptions binds src into dest like Bind, according to opts.
+func BindWithOptions(src map[string]any, dest any, opts BindOptions) error {
 	if dest == nil {
 		return ErrDestIsNil
 	}
@@ -114,29 +243,260 @@ func Bind(src map[string]any, dest any) error {
 		return ErrDestMustBePointer
 	}
 
-	rv = rv.Elem()
-	if rv.Kind() != reflect.Struct {
+	if kind := rv.Elem().Kind(); kind != reflect.Struct && kind != reflect.Map {
 		return ErrDestMustPointToStruct
 	}
 
-	typeInfo := buildStructFieldMap(rv.Type())
+	return BindValue(src, dest, opts)
+}
+
+// BindValue binds src, of any type, into the value pointed to by dest according to opts, e.g. an
+// []any array into a []T slice. Maps are bound into structs like by Bind.
+func BindValue(src any, dest any, opts BindOptions) error {
+	b := binder{opts: opts, path: opts.KeyPrefix}
+
+	if dest == nil {
+		return ErrDestIsNil
+	}
+
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return ErrDestMustBePointer
+	}
+
+	rv = rv.Elem()
+
+	if m, ok := src.(map[string]any); ok && rv.Kind() == reflect.Struct {
+		return b.bindStruct(rv, m)
+	}
+
+	return b.bindError(rv, src, b.setValue(rv, src))
+}
+
+// tagNames returns the struct tags consulted for the keys of struct fields.
+func (b binder) tagNames() []string {
+	if b.opts.TagNames == nil {
+		return defaultTagNames
+	}
+
+	return b.opts.TagNames
+}
+
+// at returns a binder for the value at key, nested under the path of b.
+func (b binder) at(key string) binder {
+	sep := b.opts.KeySeparator
+	if sep == "" {
+		sep = "."
+	}
+
+	b.path = JoinPath(b.path, key, sep)
+
+	return b
+}
+
+// field returns a binder for the value of the field fi at key, nested under the path of b.
+func (b binder) field(key string, fi fieldInfo) binder {
+	b = b.at(key)
+
+	if b.fieldPath != "" {
+		b.fieldPath += "."
+	}
+
+	b.fieldPath += fi.Name
+	b.layout = fi.Layout
+	b.tz = fi.TZ
+	b.byteSize = fi.ByteSize
+
+	return b
+}
+
+// elem returns a binder for the element at key of the bound slice, array or map.
+func (b binder) elem(key string) binder {
+	b = b.at(key)
+	b.fieldPath += "[" + key + "]"
+
+	return b
+}
+
+// bindError returns err, the error binding v into dst, as a *BindError, unless it already holds
+// BindErrors (e.g., the errors of nested fields).
+func (b binder) bindError(dst reflect.Value, v any, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var bindErr *BindError
+	if errors.As(err, &bindErr) {
+		return err
+	}
+
+	return &BindError{
+		Key:        b.path,
+		Field:      b.fieldPath,
+		Type:       dst.Type(),
+		SourceType: reflect.TypeOf(v),
+		Err:        err,
+	}
+}
+
+// bindStruct binds m into the struct dst, then binds the default values of the missing fields and
+// checks that the required ones were bound. Errors are not fatal: the errors of all the fields are
+// joined, in declaration order.
+func (b binder) bindStruct(dst reflect.Value, m map[string]any) error {
+	fieldMap := buildStructFieldMap(dst.Type(), b.tagNames())
+	bound := make(map[string]bool, len(m))
+	fieldErrs := make(map[string]error)
+
+	// Keys are bound by increasing match precedence, so that e.g. keys matching a field by its tag
+	// take precedence over keys matching the same field by alias or by name.
+	for _, match := range []int{matchByName, matchByAlias, matchByTag} {
+		for key, val := range m {
+			fi, ok := lookupField(fieldMap, key)
+			if !ok || fi.Match != match {
+				continue
+			}
 
-	for k, v := range src {
-		if fi, ok := typeInfo[k]; ok {
-			fv := getFieldByPath(rv, fi.Path)
+			fv := getFieldByPath(dst, fi.Path)
 			if !fv.CanSet() {
 				// unexported field
 				continue
 			}
 
-			err := setValue(fv, v)
-			if err != nil {
-				return fmt.Errorf("field %s: %w", fi.Name, err)
+			id := fmt.Sprint(fi.Path)
+			bound[id] = true
+
+			fb := b.field(key, fi)
+			if err := fb.setValue(fv, val); err != nil {
+				fieldErrs[id] = fb.bindError(fv, val, err)
+
+				continue
+			}
+
+			delete(fieldErrs, id)
+
+			if fi.Deprecated != "" && b.opts.OnDeprecated != nil {
+				b.opts.OnDeprecated(b.at(key).path, fi.Deprecated)
 			}
 		}
 	}
 
-	return nil
+	var errs []error
+
+	for _, fi := range structFields(fieldMap) {
+		if err, ok := fieldErrs[fmt.Sprint(fi.Path)]; ok {
+			errs = append(errs, err)
+		}
+	}
+
+	errs = append(errs, b.bindMissing(dst, fieldMap, bound))
+
+	if b.opts.OnUnusedKey != nil {
+		for _, key := range sortedKeys(m) {
+			if _, ok := lookupField(fieldMap, key); !ok {
+				b.opts.OnUnusedKey(b.at(key).path)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// lookupField returns the field of fieldMap matching key: by tag key then lowercased name.
+func lookupField(fieldMap map[string]fieldInfo, key string) (fieldInfo, bool) {
+	fi, ok := fieldMap[key]
+	if !ok {
+		fi, ok = fieldMap[strings.ToLower(key)]
+	}
+
+	return fi, ok
+}
+
+// bindMissing binds the default values of the fields of the struct dst which are not in bound,
+// including the fields of unbound nested structs, and returns ErrMissingKey for the ones tagged
+// `required:"true"` without a default value.
+func (b binder) bindMissing(dst reflect.Value, fieldMap map[string]fieldInfo, bound map[string]bool) error {
+	var errs []error
+
+	for _, fi := range structFields(fieldMap) {
+		if bound[fmt.Sprint(fi.Path)] {
+			continue
+		}
+
+		fb := b.field(fi.Key, fi)
+
+		if fi.HasDefault {
+			fv := getFieldByPath(dst, fi.Path)
+			if err := fb.setDefault(fv, fi.Default); err != nil {
+				errs = append(errs, fb.bindError(fv, fi.Default, fmt.Errorf("invalid default: %w", err)))
+			}
+
+			continue
+		}
+
+		if fi.Required {
+			errs = append(errs, &BindError{
+				Key:   fb.path,
+				Field: fb.fieldPath,
+				Type:  dst.Type().FieldByIndex(fi.Path).Type,
+				Err:   ErrMissingKey,
+			})
+
+			continue
+		}
+
+		if dst.Type().FieldByIndex(fi.Path).Type.Kind() == reflect.Struct {
+			fv := getFieldByPath(dst, fi.Path)
+			errs = append(errs, fb.bindMissing(fv, buildStructFieldMap(fv.Type(), b.tagNames()), nil))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// setDefault binds the default value of a field given by its `default` struct tag. Defaults of
+// slice fields are split on the slice separator, or on "," if none is set.
+func (b binder) setDefault(dst reflect.Value, value string) error {
+	if !dst.CanSet() {
+		return nil
+	}
+
+	if b.opts.SliceSeparator == "" {
+		b.opts.SliceSeparator = ","
+	}
+
+	return b.setValue(dst, value)
+}
+
+// sortedKeys returns the keys of m in increasing order.
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// structFields returns the distinct fields of fieldMap in declaration order.
+func structFields(fieldMap map[string]fieldInfo) []fieldInfo {
+	seen := make(map[string]bool, len(fieldMap))
+	fields := make([]fieldInfo, 0, len(fieldMap))
+
+	for _, fi := range fieldMap {
+		if id := fmt.Sprint(fi.Path); !seen[id] {
+			seen[id] = true
+
+			fields = append(fields, fi)
+		}
+	}
+
+	slices.SortFunc(fields, func(a, b fieldInfo) int {
+		return slices.Compare(a.Path, b.Path)
+	})
+
+	return fields
 }
 
 // getFieldByPath retrieves a field value following a path through embedded structs.
@@ -159,7 +519,7 @@ func getFieldByPath(rv reflect.Value, path []int) reflect.Value {
 
 // Unbind converts src (struct or pointer to struct) into dest (map[string]any).
 // It recursively assigns values from the struct to the map, handling nested structs,
-// slices, arrays, maps and pointers. Field keys use json tag (if present) then field name.
+// slices, arrays, maps and pointers. Field keys use gcfg then json tag (if present) then field name.
 func Unbind(src any, dest map[string]any) error {
 	if src == nil {
 		return ErrSrcIsNil
@@ -224,13 +584,8 @@ func setMapFromStructRecursive(rv reflect.Value, m map[string]any) error {
 		}
 
 		key := sf.Name
-
-		jsonTag := sf.Tag.Get("json")
-		if jsonTag != "" {
-			parts := strings.Split(jsonTag, ",")
-			if parts[0] != "" && parts[0] != "-" {
-				key = parts[0]
-			}
+		if tagKey, _, ok := lookupTagKey(sf, defaultTagNames); ok {
+			key = tagKey
 		}
 
 		val, err := getAnyFromValue(fv)
@@ -319,22 +674,39 @@ func getAnyFromValue(rv reflect.Value) (any, error) {
 }
 
 type fieldInfo struct {
-	Name  string
-	Index int
-	Tag   string
-	Path  []int // Path to the field through embedded structs
+	Name       string
+	Index      int
+	Tag        string
+	Key        string // Key of the field: the tag name, or the lowercased field name
+	Required   bool   // Whether the field is tagged `required:"true"`
+	Default    string // Default value of the field, given by its `default` tag
+	HasDefault bool   // Whether the field has a `default` tag
+	Deprecated string // Deprecation message of the field, given by its `deprecated` tag
+	Layout     string // Layout of time.Time values, given by the `layout` tag
+	TZ         string // Time zone of time.Time values, given by the `tz` tag
+	ByteSize   bool   // Whether strings are parsed as byte sizes, given by the `bytesize` tag
+	Match      int    // How the key matches the field, see matchByName
+	Path       []int  // Path to the field through embedded structs
 }
 
-// buildStructFieldMap creates a lookup for "keys" to fields using json tag then case-insensitive name.
-func buildStructFieldMap(t reflect.Type) map[string]fieldInfo {
+// How keys match fields, by increasing precedence.
+const (
+	matchByName  = iota // lowercased field name
+	matchByAlias        // `alias` tag
+	matchByTag          // key given by a struct tag, e.g. `gcfg:"db_host"`
+)
+
+// buildStructFieldMap creates a lookup for "keys" to fields using the struct tags tagNames then
+// case-insensitive name.
+func buildStructFieldMap(t reflect.Type, tagNames []string) map[string]fieldInfo {
 	out := map[string]fieldInfo{}
-	buildStructFieldMapRecursive(t, []int{}, out)
+	buildStructFieldMapRecursive(t, tagNames, []int{}, out)
 
 	return out
 }
 
 // buildStructFieldMapRecursive recursively builds a field map handling embedded structs.
-func buildStructFieldMapRecursive(t reflect.Type, indexPath []int, out map[string]fieldInfo) {
+func buildStructFieldMapRecursive(t reflect.Type, tagNames []string, indexPath []int, out map[string]fieldInfo) {
 	for i := range t.NumField() {
 		sf := t.Field(i)
 		// skip unexported fields
@@ -355,40 +727,227 @@ func buildStructFieldMapRecursive(t reflect.Type, indexPath []int, out map[strin
 
 			if fieldType.Kind() == reflect.Struct {
 				// Recursively process embedded struct fields
-				buildStructFieldMapRecursive(fieldType, currentPath, out)
+				buildStructFieldMapRecursive(fieldType, tagNames, currentPath, out)
 
 				continue
 			}
 		}
 
-		jsonTag := sf.Tag.Get("json")
 		name := sf.Name
+		required, _ := strconv.ParseBool(sf.Tag.Get("required"))
+		byteSize, _ := strconv.ParseBool(sf.Tag.Get("bytesize"))
+		def, hasDefault := sf.Tag.Lookup("default")
 
 		key := strings.ToLower(name)
-		if jsonTag != "" {
-			parts := strings.Split(jsonTag, ",")
-			if parts[0] != "" && parts[0] != "-" {
-				out[parts[0]] = fieldInfo{
-					Name:  sf.Name,
-					Index: currentPath[len(currentPath)-1],
-					Tag:   jsonTag,
-					Path:  currentPath,
-				}
+		info := fieldInfo{
+			Name:       sf.Name,
+			Index:      currentPath[len(currentPath)-1],
+			Key:        key,
+			Required:   required,
+			Default:    def,
+			HasDefault: hasDefault,
+			Deprecated: sf.Tag.Get("deprecated"),
+			Layout:     sf.Tag.Get("layout"),
+			TZ:         sf.Tag.Get("tz"),
+			ByteSize:   byteSize,
+			Path:       currentPath,
+		}
+
+		if tagKey, tag, ok := lookupTagKey(sf, tagNames); ok {
+			info.Key = tagKey
+			tagInfo := info
+			tagInfo.Tag = tag
+			tagInfo.Match = matchByTag
+			out[tagKey] = tagInfo
+		}
+		// alternative keys accepted for the field, e.g. renamed keys
+		for _, alias := range strings.Split(sf.Tag.Get("alias"), ",") {
+			alias = strings.TrimSpace(alias)
+			if existing, exists := out[alias]; alias != "" && (!exists || existing.Match < matchByAlias) {
+				aliasInfo := info
+				aliasInfo.Match = matchByAlias
+				out[alias] = aliasInfo
 			}
 		}
 		// fallback by lowercased field name if not already present
 		if _, exists := out[key]; !exists {
-			out[key] = fieldInfo{
-				Name:  sf.Name,
-				Index: currentPath[len(currentPath)-1],
-				Tag:   "",
-				Path:  currentPath,
-			}
+			out[key] = info
+		}
+	}
+}
+
+// FieldKey returns the key the struct field sf is bound from: the name given by the first of the
+// struct tags tagNames (defaultTagNames if nil), or the lowercased field name.
+func FieldKey(sf reflect.StructField, tagNames []string) string {
+	if tagNames == nil {
+		tagNames = defaultTagNames
+	}
+
+	if key, _, ok := lookupTagKey(sf, tagNames); ok {
+		return key
+	}
+
+	return strings.ToLower(sf.Name)
+}
+
+// lookupTagKey returns the key given to the field sf by the first of the struct tags tagNames
+// naming it (e.g., "db_host" for `gcfg:"db_host"`), along with the tag value. Tags without a name
+// or with the name "-" are skipped.
+func lookupTagKey(sf reflect.StructField, tagNames []string) (key, tag string, ok bool) {
+	for _, tagName := range tagNames {
+		tag = sf.Tag.Get(tagName)
+		if tag == "" {
+			continue
+		}
+
+		if key, _, _ = strings.Cut(tag, ","); key != "" && key != "-" {
+			return key, tag, true
+		}
+	}
+
+	return "", "", false
+}
+
+// stringParsers parse the strings bound to standard library types which don't implement
+// encoding.TextUnmarshaler. Types like net.IP, netip.Addr, netip.Prefix and regexp.Regexp do, and
+// are bound by unmarshal.
+var stringParsers = map[reflect.Type]func(str string) (any, error){
+	reflect.TypeFor[net.IPNet](): func(str string) (any, error) {
+		_, ipNet, err := net.ParseCIDR(str)
+		if err != nil {
+			return nil, err
+		}
+
+		return *ipNet, nil
+	},
+	reflect.TypeFor[url.URL](): func(str string) (any, error) {
+		u, err := url.Parse(str)
+		if err != nil {
+			return nil, err
+		}
+
+		return *u, nil
+	},
+}
+
+// unmarshal sets dst from v if dst implements Unmarshaler, encoding.TextUnmarshaler (for string
+// values) or json.Unmarshaler (with v marshaled to JSON), reporting whether it does.
+func unmarshal(dst reflect.Value, v any) (bool, error) {
+	if !dst.CanAddr() {
+		return false, nil
+	}
+
+	if u, ok := dst.Addr().Interface().(Unmarshaler); ok {
+		if err := u.UnmarshalConfig(v); err != nil {
+			return true, fmt.Errorf("%w %s: %w", ErrCannotUnmarshal, dst.Type(), err)
+		}
+
+		return true, nil
+	}
+
+	if str, ok := v.(string); ok {
+		if ok, err := unmarshalText(dst, str); ok {
+			return true, err
+		}
+	}
+
+	// numbers and other scalars bound to scalar kinds are converted natively
+	if isScalarKind(dst.Kind()) && isScalarKind(reflect.TypeOf(v).Kind()) {
+		return false, nil
+	}
+
+	// numbers bound to e.g. big.Int, big.Float, big.Rat or decimal types are given as exact
+	// decimal strings
+	if str, ok := formatNumber(v); ok {
+		if ok, err := unmarshalText(dst, str); ok {
+			return true, err
+		}
+	}
+
+	u, ok := dst.Addr().Interface().(json.Unmarshaler)
+	if !ok || reflect.TypeOf(v) == dst.Type() {
+		return false, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err == nil {
+		err = u.UnmarshalJSON(data)
+	}
+
+	if err != nil {
+		return true, fmt.Errorf("%w %s: %w", ErrCannotUnmarshal, dst.Type(), err)
+	}
+
+	return true, nil
+}
+
+// isScalarKind reports whether values of kind are booleans, numbers or strings.
+func isScalarKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// formatNumber formats v as a decimal string without loss of precision if it is a number.
+func formatNumber(v any) (string, bool) {
+	switch typ := v.(type) {
+	case json.Number:
+		return typ.String(), true
+	case float64:
+		return strconv.FormatFloat(typ, 'f', -1, 64), true
+	case float32:
+		return strconv.FormatFloat(float64(typ), 'f', -1, 32), true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(typ), true
+	default:
+		return "", false
+	}
+}
+
+// unmarshalText sets dst from str if dst implements encoding.TextUnmarshaler (e.g., time.Time,
+// netip.Addr or custom enums), reporting whether it does.
+func unmarshalText(dst reflect.Value, str string) (bool, error) {
+	if !dst.CanAddr() {
+		return false, nil
+	}
+
+	u, ok := dst.Addr().Interface().(encoding.TextUnmarshaler)
+	if !ok {
+		return false, nil
+	}
+
+	if err := u.UnmarshalText([]byte(str)); err != nil {
+		return true, fmt.Errorf("%w %s: %w", ErrCannotUnmarshalText, dst.Type(), err)
+	}
+
+	return true, nil
+}
+
+// decode passes v through the decode hooks, for a destination of type to.
+func (b binder) decode(to reflect.Type, v any) (any, error) {
+	for _, hook := range b.opts.DecodeHooks {
+		if v == nil {
+			break
+		}
+
+		var err error
+
+		v, err = hook(reflect.TypeOf(v), to, v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrDecodeHook, err)
 		}
 	}
+
+	return v, nil
 }
 
-func setValue(dst reflect.Value, v any) error {
+func (b binder) setValue(dst reflect.Value, v any) error {
 	// handle pointer destination by allocating if nil
 	for dst.Kind() == reflect.Ptr {
 		if dst.IsNil() {
@@ -409,44 +968,89 @@ func setValue(dst reflect.Value, v any) error {
 		return nil
 	}
 
-	srcVal := reflect.ValueOf(v)
+	if len(b.opts.DecodeHooks) > 0 {
+		decoded, err := b.decode(dst.Type(), v)
+		if err != nil {
+			return err
+		}
 
-	switch dst.Kind() {
-	case reflect.Struct:
-		// if src is map[string]any -> recurse
-		if m, ok := v.(map[string]any); ok {
-			// create an addressable struct value to pass into Bind-like logic
-			// we'll iterate fields manually instead of calling Bind to avoid type checks
-			t := dst.Type()
-
-			fieldMap := buildStructFieldMap(t)
-			for key, val := range m {
-				// try tag key then lowercased name
-				if fi, ok := fieldMap[key]; ok {
-					fv := getFieldByPath(dst, fi.Path)
-					if !fv.CanSet() {
-						continue
-					}
+		if decoded == nil {
+			dst.Set(reflect.Zero(dst.Type()))
 
-					err := setValue(fv, val)
-					if err != nil {
-						return fmt.Errorf("struct field %s: %w", fi.Name, err)
-					}
-				} else if fi, ok := fieldMap[strings.ToLower(key)]; ok {
-					fv := getFieldByPath(dst, fi.Path)
-					if !fv.CanSet() {
-						continue
-					}
+			return nil
+		}
 
-					err := setValue(fv, val)
-					if err != nil {
-						return fmt.Errorf("struct field %s: %w", fi.Name, err)
-					}
-				}
+		// values converted by the hooks to the destination type are bound as is
+		if reflect.TypeOf(decoded) != reflect.TypeOf(v) && reflect.TypeOf(decoded).AssignableTo(dst.Type()) {
+			dst.Set(reflect.ValueOf(decoded))
+
+			return nil
+		}
+
+		v = decoded
+	}
+
+	if str, ok := v.(string); ok && dst.Type() == reflect.TypeFor[time.Time]() && (b.layout != "" || b.tz != "") {
+		t, err := b.toTime(str)
+		if err != nil {
+			return err
+		}
+
+		dst.Set(reflect.ValueOf(t))
+
+		return nil
+	}
+
+	if str, ok := v.(string); ok && b.byteSize {
+		switch dst.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			size, err := ParseByteSize(str)
+			if err != nil {
+				return err
 			}
 
+			// bound as a number below, with range checks
+			v = size
+		}
+	}
+
+	if str, ok := v.(string); ok {
+		if parse, ok := stringParsers[dst.Type()]; ok {
+			parsed, err := parse(str)
+			if err != nil {
+				return fmt.Errorf("%w %s: %w", ErrCannotUnmarshalText, dst.Type(), err)
+			}
+
+			dst.Set(reflect.ValueOf(parsed))
+
 			return nil
 		}
+	}
+
+	if ok, err := unmarshal(dst, v); ok {
+		return err
+	}
+
+	if dst.Type() == reflect.TypeFor[time.Duration]() {
+		d, err := b.toDuration(v)
+		if err != nil {
+			return err
+		}
+
+		dst.SetInt(int64(d))
+
+		return nil
+	}
+
+	srcVal := reflect.ValueOf(v)
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		// if src is map[string]any -> recurse
+		if m, ok := v.(map[string]any); ok {
+			return b.bindStruct(dst, m)
+		}
 		// if src is a struct assignable
 		if srcVal.Type().AssignableTo(dst.Type()) {
 			dst.Set(srcVal)
@@ -469,7 +1073,11 @@ func setValue(dst reflect.Value, v any) error {
 			keyType := dst.Type().Key()
 
 			elemType := dst.Type().Elem()
-			for mk, mv := range m {
+
+			var errs []error
+
+			for _, mk := range sortedKeys(m) {
+				mv := m[mk]
 				kv := reflect.New(keyType).Elem()
 				// set key (try convert string to key)
 				if err := setSimpleValueFromString(kv, mk); err != nil {
@@ -477,13 +1085,17 @@ func setValue(dst reflect.Value, v any) error {
 					if keyType.Kind() == reflect.String {
 						kv.SetString(mk)
 					} else {
-						return fmt.Errorf("%w: %w", ErrMapKeyConversion, err)
+						errs = append(errs, b.elem(mk).bindError(kv, mk, fmt.Errorf("%w: %w", ErrMapKeyConversion, err)))
+
+						continue
 					}
 				}
 
 				ev := reflect.New(elemType).Elem()
-				if err := setValue(ev, mv); err != nil {
-					return fmt.Errorf("map value for key %s: %w", mk, err)
+				if err := b.elem(mk).setValue(ev, mv); err != nil {
+					errs = append(errs, b.elem(mk).bindError(ev, mv, err))
+
+					continue
 				}
 
 				newMap.SetMapIndex(kv, ev)
@@ -491,7 +1103,7 @@ func setValue(dst reflect.Value, v any) error {
 
 			dst.Set(newMap)
 
-			return nil
+			return errors.Join(errs...)
 		}
 		// if src is a map with reflected type that can be converted
 		if srcVal.Type().AssignableTo(dst.Type()) {
@@ -503,19 +1115,26 @@ func setValue(dst reflect.Value, v any) error {
 		return fmt.Errorf("%w %T", ErrCannotSetMapFrom, v)
 
 	case reflect.Slice:
+		if str, ok := v.(string); ok && b.opts.SliceSeparator != "" {
+			v = b.splitSlice(str)
+		}
+
 		// expect src to be []any or something convertible
 		if arr, ok := v.([]any); ok {
 			slice := reflect.MakeSlice(dst.Type(), len(arr), len(arr))
+
+			var errs []error
+
 			for i := range arr {
-				err := setValue(slice.Index(i), arr[i])
-				if err != nil {
-					return fmt.Errorf("slice index %d: %w", i, err)
+				eb := b.elem(strconv.Itoa(i))
+				if err := eb.setValue(slice.Index(i), arr[i]); err != nil {
+					errs = append(errs, eb.bindError(slice.Index(i), arr[i], err))
 				}
 			}
 
 			dst.Set(slice)
 
-			return nil
+			return errors.Join(errs...)
 		}
 		// if src is slice/array assignable/convertible
 		if srcVal.Kind() == reflect.Slice || srcVal.Kind() == reflect.Array {
@@ -529,18 +1148,21 @@ func setValue(dst reflect.Value, v any) error {
 			l := srcVal.Len()
 
 			slice := reflect.MakeSlice(dst.Type(), l, l)
+
+			var errs []error
+
 			for i := range l {
 				elem := srcVal.Index(i).Interface()
 
-				err := setValue(slice.Index(i), elem)
-				if err != nil {
-					return fmt.Errorf("slice element %d: %w", i, err)
+				eb := b.elem(strconv.Itoa(i))
+				if err := eb.setValue(slice.Index(i), elem); err != nil {
+					errs = append(errs, eb.bindError(slice.Index(i), elem, err))
 				}
 			}
 
 			dst.Set(slice)
 
-			return nil
+			return errors.Join(errs...)
 		}
 
 		return fmt.Errorf("%w %T", ErrCannotSetSliceFrom, v)
@@ -552,14 +1174,16 @@ func setValue(dst reflect.Value, v any) error {
 				return fmt.Errorf("%w: dest %d src %d", ErrArrayLengthMismatch, dst.Len(), len(arr))
 			}
 
+			var errs []error
+
 			for i := range dst.Len() {
-				err := setValue(dst.Index(i), arr[i])
-				if err != nil {
-					return fmt.Errorf("array index %d: %w", i, err)
+				eb := b.elem(strconv.Itoa(i))
+				if err := eb.setValue(dst.Index(i), arr[i]); err != nil {
+					errs = append(errs, eb.bindError(dst.Index(i), arr[i], err))
 				}
 			}
 
-			return nil
+			return errors.Join(errs...)
 		}
 		// try assignable
 		if srcVal.Type().AssignableTo(dst.Type()) {
@@ -588,6 +1212,19 @@ func setValue(dst reflect.Value, v any) error {
 	}
 }
 
+// splitSlice splits str into the items of a slice on the slice separator.
+func (b binder) splitSlice(str string) []any {
+	items := make([]any, 0)
+
+	for _, item := range strings.Split(str, b.opts.SliceSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
 func setBasicKind(dst reflect.Value, v any) error {
 	switch dst.Kind() {
 	case reflect.Bool:
@@ -660,6 +1297,10 @@ func toBool(val any) (bool, error) {
 		return typ, nil
 	case string:
 		return strconv.ParseBool(typ)
+	case json.Number:
+		f, err := typ.Float64()
+
+		return f != 0, err
 	case float64:
 		return typ != 0, nil
 	case float32:
@@ -685,6 +1326,110 @@ func toString(val any) string {
 	}
 }
 
+// toDuration converts val to a time.Duration: strings are parsed by time.ParseDuration (e.g.,
+// "1m30s"), and bare numbers (or numeric strings) are counted in the duration unit.
+func (b binder) toDuration(val any) (time.Duration, error) {
+	unit := b.opts.DurationUnit
+	if unit <= 0 {
+		unit = time.Nanosecond
+	}
+
+	switch typ := val.(type) {
+	case time.Duration:
+		return typ, nil
+	case string:
+		if d, err := time.ParseDuration(typ); err == nil {
+			return d, nil
+		}
+
+		if i, err := strconv.ParseInt(typ, 10, 64); err == nil {
+			return scaleDuration(i, unit)
+		}
+
+		f, err := strconv.ParseFloat(typ, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%w %q", ErrCannotConvertToDuration, typ)
+		}
+
+		return scaleFloatDuration(f, unit)
+	case float64, float32:
+		f, err := toFloat64(typ)
+		if err != nil {
+			return 0, err
+		}
+
+		return scaleFloatDuration(f, unit)
+	case json.Number:
+		if i, err := typ.Int64(); err == nil {
+			return scaleDuration(i, unit)
+		}
+
+		f, err := typ.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("%w %q", ErrCannotConvertToDuration, typ)
+		}
+
+		return scaleFloatDuration(f, unit)
+	}
+
+	i, err := toInt64(val)
+	if err != nil {
+		return 0, fmt.Errorf("%w %T", ErrCannotConvertToDuration, val)
+	}
+
+	return scaleDuration(i, unit)
+}
+
+// toTime parses str with the layout of the bound field (time.RFC3339 by default), in its time zone
+// (UTC by default) when str doesn't specify one, then converts the time to that time zone if set.
+func (b binder) toTime(str string) (time.Time, error) {
+	layout := b.layout
+	if layout == "" {
+		layout = time.RFC3339
+	}
+
+	loc := time.UTC
+
+	if b.tz != "" {
+		var err error
+
+		loc, err = time.LoadLocation(b.tz)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w %q: %w", ErrCannotConvertToTime, str, err)
+		}
+	}
+
+	t, err := time.ParseInLocation(layout, str, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w %q: %w", ErrCannotConvertToTime, str, err)
+	}
+
+	if b.tz != "" {
+		t = t.In(loc)
+	}
+
+	return t, nil
+}
+
+// scaleDuration returns n units, checking for overflows.
+func scaleDuration(n int64, unit time.Duration) (time.Duration, error) {
+	if n > math.MaxInt64/int64(unit) || n < math.MinInt64/int64(unit) {
+		return 0, fmt.Errorf("%w duration: %d%s", ErrIntegerOverflow, n, unit)
+	}
+
+	return time.Duration(n) * unit, nil
+}
+
+// scaleFloatDuration returns f units, checking for overflows.
+func scaleFloatDuration(f float64, unit time.Duration) (time.Duration, error) {
+	d := f * float64(unit)
+	if math.IsNaN(d) || d >= math.MaxInt64 || d < math.MinInt64 {
+		return 0, fmt.Errorf("%w duration: %g%s", ErrIntegerOverflow, f, unit)
+	}
+
+	return time.Duration(d), nil
+}
+
 func toInt64(val any) (int64, error) {
 	switch typ := val.(type) {
 	case int:
@@ -721,6 +1466,14 @@ func toInt64(val any) (int64, error) {
 		return int64(typ), nil
 	case string:
 		return strconv.ParseInt(typ, 10, 64)
+	case json.Number:
+		if i, err := typ.Int64(); err == nil {
+			return i, nil
+		}
+
+		f, err := typ.Float64()
+
+		return int64(f), err
 	case time.Duration:
 		return int64(typ), nil
 	default:
@@ -778,6 +1531,17 @@ func toUint64(val any) (uint64, error) {
 		return uint64(typ), nil
 	case string:
 		return strconv.ParseUint(typ, 10, 64)
+	case json.Number:
+		if u, err := strconv.ParseUint(typ.String(), 10, 64); err == nil {
+			return u, nil
+		}
+
+		f, err := typ.Float64()
+		if err == nil && f < 0 {
+			return 0, fmt.Errorf("%w: %f", ErrNegativeFloatCannotConvert, f)
+		}
+
+		return uint64(f), err
 	default:
 		return 0, fmt.Errorf("%w %T", ErrCannotConvertToUint64, val)
 	}
@@ -811,6 +1575,8 @@ func toFloat64(val any) (float64, error) {
 		return float64(typ), nil
 	case string:
 		return strconv.ParseFloat(typ, 64)
+	case json.Number:
+		return typ.Float64()
 	default:
 		return 0, fmt.Errorf("%w %T", ErrCannotConvertToFloat64, val)
 	}
@@ -848,6 +1614,10 @@ func withinUintRange(unt uint64, bits int) bool {
 
 // setSimpleValueFromString tries to set a reflect.Value from a string (used for map keys).
 func setSimpleValueFromString(dst reflect.Value, str string) error {
+	if ok, err := unmarshalText(dst, str); ok {
+		return err
+	}
+
 	switch dst.Kind() {
 	case reflect.String:
 		dst.SetString(str)
@@ -0,0 +1,1127 @@
+package maps_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/big"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindWithOptions_SliceSeparator(t *testing.T) {
+	t.Parallel()
+
+	type Target struct {
+		Hosts []string `json:"hosts"`
+		Ports []int    `json:"ports"`
+	}
+
+	tests := []struct {
+		name      string
+		separator string
+		src       map[string]any
+		expected  Target
+		wantErr   bool
+	}{
+		{
+			name:      "splits strings",
+			separator: ",",
+			src:       map[string]any{"hosts": "a, b,,c", "ports": "80,443"},
+			expected:  Target{Hosts: []string{"a", "b", "c"}, Ports: []int{80, 443}},
+		},
+		{
+			name:      "custom separator",
+			separator: ";",
+			src:       map[string]any{"hosts": "a,b;c"},
+			expected:  Target{Hosts: []string{"a,b", "c"}},
+		},
+		{
+			name:      "empty string binds an empty slice",
+			separator: ",",
+			src:       map[string]any{"hosts": ""},
+			expected:  Target{Hosts: []string{}},
+		},
+		{
+			name:      "slices are bound as is",
+			separator: ",",
+			src:       map[string]any{"hosts": []any{"a,b"}},
+			expected:  Target{Hosts: []string{"a,b"}},
+		},
+		{
+			name:      "invalid items",
+			separator: ",",
+			src:       map[string]any{"ports": "80,http"},
+			wantErr:   true,
+		},
+		{
+			name:    "strings are not split by default",
+			src:     map[string]any{"hosts": "a,b"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var dest Target
+
+			err := maps.BindWithOptions(tt.src, &dest, maps.BindOptions{SliceSeparator: tt.separator})
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, dest)
+		})
+	}
+}
+
+func TestBind_Required(t *testing.T) {
+	t.Parallel()
+
+	type Database struct {
+		Host     string `json:"host"`
+		Password string `json:"password" required:"true"`
+	}
+
+	type Target struct {
+		Name     string   `required:"true"`
+		Database Database `json:"database"`
+		Replicas []Database
+	}
+
+	tests := []struct {
+		name    string
+		src     map[string]any
+		missing string
+	}{
+		{
+			name: "all required keys present",
+			src: map[string]any{
+				"name":     "app",
+				"database": map[string]any{"password": "secret"},
+			},
+		},
+		{
+			name: "zero values satisfy required",
+			src: map[string]any{
+				"name":     "",
+				"database": map[string]any{"password": nil},
+			},
+		},
+		{
+			name:    "missing top-level key",
+			src:     map[string]any{"database": map[string]any{"password": "secret"}},
+			missing: "name",
+		},
+		{
+			name:    "missing nested key",
+			src:     map[string]any{"name": "app", "database": map[string]any{"host": "localhost"}},
+			missing: "database.password",
+		},
+		{
+			name:    "missing section",
+			src:     map[string]any{"name": "app"},
+			missing: "database.password",
+		},
+		{
+			name: "missing key in slice element",
+			src: map[string]any{
+				"name":     "app",
+				"database": map[string]any{"password": "secret"},
+				"replicas": []any{map[string]any{"host": "replica"}},
+			},
+			missing: "replicas.0.password",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var dest Target
+
+			err := maps.Bind(tt.src, &dest)
+			if tt.missing == "" {
+				require.NoError(t, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, maps.ErrMissingKey)
+			assert.Contains(t, err.Error(), "missing key "+tt.missing)
+		})
+	}
+}
+
+func TestBind_Default(t *testing.T) {
+	t.Parallel()
+
+	type Server struct {
+		Host string `json:"host" default:"0.0.0.0"`
+		Port int    `json:"port" default:"8080"`
+	}
+
+	type Target struct {
+		Server  Server   `json:"server"`
+		Tags    []string `json:"tags" default:"a, b"`
+		Debug   *bool    `json:"debug" default:"true"`
+		Token   string   `json:"token" required:"true" default:"none"`
+		Retries int      `json:"retries"`
+	}
+
+	debug := true
+
+	tests := []struct {
+		name     string
+		src      map[string]any
+		expected Target
+		wantErr  bool
+	}{
+		{
+			name: "defaults fill missing keys",
+			src:  map[string]any{},
+			expected: Target{
+				Server: Server{Host: "0.0.0.0", Port: 8080},
+				Tags:   []string{"a", "b"},
+				Debug:  &debug,
+				Token:  "none",
+			},
+		},
+		{
+			name: "present keys win over defaults",
+			src: map[string]any{
+				"server":  map[string]any{"port": 9090},
+				"tags":    []any{},
+				"token":   "",
+				"retries": 3,
+			},
+			expected: Target{
+				Server:  Server{Host: "0.0.0.0", Port: 9090},
+				Tags:    []string{},
+				Debug:   &debug,
+				Token:   "",
+				Retries: 3,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var dest Target
+
+			require.NoError(t, maps.Bind(tt.src, &dest))
+			assert.Equal(t, tt.expected, dest)
+		})
+	}
+
+	t.Run("invalid default", func(t *testing.T) {
+		t.Parallel()
+
+		var dest struct {
+			Port int `default:"http"`
+		}
+
+		err := maps.Bind(map[string]any{}, &dest)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field Port of type int: invalid default")
+	})
+}
+
+func TestBindWithOptions_TagNames(t *testing.T) {
+	t.Parallel()
+
+	type Target struct {
+		Host string `gcfg:"db_host" json:"host"`
+		Port int    `json:"port" config:"db_port"`
+		User string `gcfg:"-" json:"user"`
+	}
+
+	src := map[string]any{
+		"db_host": "gcfg-host",
+		"host":    "json-host",
+		"port":    5432,
+		"db_port": 6432,
+		"user":    "admin",
+	}
+
+	tests := []struct {
+		name     string
+		tagNames []string
+		expected Target
+	}{
+		{
+			name:     "gcfg then json by default",
+			expected: Target{Host: "gcfg-host", Port: 5432, User: "admin"},
+		},
+		{
+			name:     "custom tag",
+			tagNames: []string{"config", "json"},
+			expected: Target{Host: "json-host", Port: 6432, User: "admin"},
+		},
+		{
+			name:     "field names only",
+			tagNames: []string{},
+			expected: Target{Host: "json-host", Port: 5432, User: "admin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var dest Target
+
+			require.NoError(t, maps.BindWithOptions(src, &dest, maps.BindOptions{TagNames: tt.tagNames}))
+			assert.Equal(t, tt.expected, dest)
+		})
+	}
+}
+
+func TestBindWithOptions_OnDeprecated(t *testing.T) {
+	t.Parallel()
+
+	type Server struct {
+		Addr string `json:"addr"`
+		Port int    `json:"port" deprecated:"use addr"`
+	}
+
+	type Target struct {
+		Servers []Server `json:"servers"`
+		Timeout int      `json:"timeout" deprecated:"use request_timeout"`
+	}
+
+	src := map[string]any{
+		"servers": []any{
+			map[string]any{"addr": ":80"},
+			map[string]any{"port": 443},
+		},
+	}
+
+	var deprecated []string
+
+	var dest Target
+
+	require.NoError(t, maps.BindWithOptions(src, &dest, maps.BindOptions{
+		KeySeparator: "/",
+		OnDeprecated: func(key, message string) {
+			deprecated = append(deprecated, key+" "+message)
+		},
+	}))
+
+	assert.Equal(t, 443, dest.Servers[1].Port)
+	assert.Equal(t, []string{"servers/1/port use addr"}, deprecated)
+}
+
+func TestBind_Alias(t *testing.T) {
+	t.Parallel()
+
+	type Target struct {
+		Timeout int `gcfg:"timeout" alias:"timeout_seconds, request_timeout"`
+	}
+
+	tests := []struct {
+		name string
+		src  map[string]any
+		want int
+	}{
+		{name: "tag key", src: map[string]any{"timeout": 5}, want: 5},
+		{name: "first alias", src: map[string]any{"timeout_seconds": 10}, want: 10},
+		{name: "second alias", src: map[string]any{"request_timeout": 15}, want: 15},
+		{
+			name: "tag key takes precedence",
+			src:  map[string]any{"timeout": 5, "timeout_seconds": 10, "request_timeout": 15},
+			want: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var dest Target
+
+			require.NoError(t, maps.Bind(tt.src, &dest))
+			assert.Equal(t, tt.want, dest.Timeout)
+		})
+	}
+}
+
+func TestBindWithOptions_DecodeHooks(t *testing.T) {
+	t.Parallel()
+
+	type Level int
+
+	type Target struct {
+		Level  Level            `json:"level"`
+		Levels map[string]Level `json:"levels"`
+		Other  *Level           `json:"other"`
+		Name   string           `json:"name"`
+	}
+
+	levels := map[string]Level{"debug": -4, "info": 0, "warn": 4}
+	errUnknownLevel := errors.New("unknown level")
+
+	hook := func(from, to reflect.Type, value any) (any, error) {
+		if from.Kind() != reflect.String || to != reflect.TypeFor[Level]() {
+			return value, nil
+		}
+
+		level, ok := levels[value.(string)]
+		if !ok {
+			return nil, fmt.Errorf("%w %q", errUnknownLevel, value)
+		}
+
+		return level, nil
+	}
+
+	tests := []struct {
+		name    string
+		src     map[string]any
+		want    Target
+		wantErr error
+	}{
+		{
+			name: "converted values",
+			src: map[string]any{
+				"level":  "debug",
+				"levels": map[string]any{"db": "warn"},
+				"other":  "info",
+				"name":   "app",
+			},
+			want: Target{Level: -4, Levels: map[string]Level{"db": 4}, Other: new(Level), Name: "app"},
+		},
+		{
+			name: "unchanged values",
+			src:  map[string]any{"level": 4},
+			want: Target{Level: 4},
+		},
+		{
+			name:    "hook error",
+			src:     map[string]any{"level": "trace"},
+			wantErr: errUnknownLevel,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var dest Target
+
+			err := maps.BindWithOptions(tt.src, &dest, maps.BindOptions{
+				DecodeHooks: []maps.DecodeHook{hook},
+			})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.ErrorIs(t, err, maps.ErrDecodeHook)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, dest)
+		})
+	}
+}
+
+func TestBind_TextUnmarshaler(t *testing.T) {
+	t.Parallel()
+
+	type Target struct {
+		Addr    netip.Addr            `json:"addr"`
+		Gateway *netip.Addr           `json:"gateway"`
+		Peers   []netip.Addr          `json:"peers"`
+		Names   map[netip.Addr]string `json:"names"`
+		Since   time.Time             `json:"since"`
+	}
+
+	gateway := netip.MustParseAddr("10.0.0.1")
+
+	tests := []struct {
+		name    string
+		src     map[string]any
+		want    Target
+		wantErr error
+	}{
+		{
+			name: "strings",
+			src: map[string]any{
+				"addr":    "10.0.0.2",
+				"gateway": "10.0.0.1",
+				"peers":   []any{"10.0.0.3", "::1"},
+				"names":   map[string]any{"10.0.0.4": "db"},
+				"since":   "2024-01-02T03:04:05Z",
+			},
+			want: Target{
+				Addr:    netip.MustParseAddr("10.0.0.2"),
+				Gateway: &gateway,
+				Peers:   []netip.Addr{netip.MustParseAddr("10.0.0.3"), netip.MustParseAddr("::1")},
+				Names:   map[netip.Addr]string{netip.MustParseAddr("10.0.0.4"): "db"},
+				Since:   time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+		},
+		{
+			name:    "invalid",
+			src:     map[string]any{"addr": "localhost"},
+			wantErr: maps.ErrCannotUnmarshalText,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var dest Target
+
+			err := maps.Bind(tt.src, &dest)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, dest)
+		})
+	}
+}
+
+// point unmarshals itself from a [x, y] JSON array.
+type point struct {
+	X, Y int
+}
+
+func (p *point) UnmarshalJSON(data []byte) error {
+	var xy [2]int
+	if err := json.Unmarshal(data, &xy); err != nil {
+		return err
+	}
+
+	p.X, p.Y = xy[0], xy[1]
+
+	return nil
+}
+
+// endpoints unmarshals itself from a list of addresses or a single address.
+type endpoints []string
+
+var errInvalidEndpoints = errors.New("invalid endpoints")
+
+func (e *endpoints) UnmarshalConfig(value any) error {
+	switch v := value.(type) {
+	case string:
+		*e = endpoints{v}
+	case []any:
+		for _, item := range v {
+			*e = append(*e, fmt.Sprint(item))
+		}
+	default:
+		return fmt.Errorf("%w: %T", errInvalidEndpoints, value)
+	}
+
+	return nil
+}
+
+func TestBind_Unmarshaler(t *testing.T) {
+	t.Parallel()
+
+	type Target struct {
+		Origin    point       `json:"origin"`
+		Cursor    *point      `json:"cursor"`
+		Endpoints endpoints   `json:"endpoints"`
+		Backups   []endpoints `json:"backups"`
+	}
+
+	tests := []struct {
+		name    string
+		src     map[string]any
+		want    Target
+		wantErr error
+	}{
+		{
+			name: "values",
+			src: map[string]any{
+				"origin":    []any{1, 2},
+				"cursor":    []any{3, 4},
+				"endpoints": "a:80",
+				"backups":   []any{[]any{"b:80", "c:80"}},
+			},
+			want: Target{
+				Origin:    point{X: 1, Y: 2},
+				Cursor:    &point{X: 3, Y: 4},
+				Endpoints: endpoints{"a:80"},
+				Backups:   []endpoints{{"b:80", "c:80"}},
+			},
+		},
+		{
+			name:    "json error",
+			src:     map[string]any{"origin": map[string]any{"x": 1}},
+			wantErr: maps.ErrCannotUnmarshal,
+		},
+		{
+			name:    "config error",
+			src:     map[string]any{"endpoints": 80},
+			wantErr: errInvalidEndpoints,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var dest Target
+
+			err := maps.Bind(tt.src, &dest)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				require.ErrorIs(t, err, maps.ErrCannotUnmarshal)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, dest)
+		})
+	}
+}
+
+func TestBindWithOptions_Duration(t *testing.T) {
+	t.Parallel()
+
+	type Target struct {
+		Timeout time.Duration `json:"timeout"`
+	}
+
+	tests := []struct {
+		name    string
+		value   any
+		unit    time.Duration
+		want    time.Duration
+		wantErr error
+	}{
+		{name: "duration string", value: "1m30s", want: 90 * time.Second},
+		{name: "duration string ignores unit", value: "1m30s", unit: time.Second, want: 90 * time.Second},
+		{name: "nanoseconds by default", value: 1500, want: 1500 * time.Nanosecond},
+		{name: "integer in unit", value: 30, unit: time.Second, want: 30 * time.Second},
+		{name: "numeric string in unit", value: "30", unit: time.Second, want: 30 * time.Second},
+		{name: "float in unit", value: 1.5, unit: time.Second, want: 1500 * time.Millisecond},
+		{name: "json number in unit", value: json.Number("2"), unit: time.Minute, want: 2 * time.Minute},
+		{name: "duration", value: time.Hour, unit: time.Second, want: time.Hour},
+		{name: "invalid string", value: "soon", wantErr: maps.ErrCannotConvertToDuration},
+		{name: "overflow", value: math.MaxInt64, unit: time.Second, wantErr: maps.ErrIntegerOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var dest Target
+
+			err := maps.BindWithOptions(map[string]any{"timeout": tt.value}, &dest, maps.BindOptions{
+				DurationUnit: tt.unit,
+			})
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, dest.Timeout)
+		})
+	}
+}
+
+func TestBind_TimeLayout(t *testing.T) {
+	t.Parallel()
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	launch := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	type Target struct {
+		Since    time.Time   `json:"since"`
+		Expiry   time.Time   `json:"expiry" layout:"2006-01-02"`
+		Start    time.Time   `json:"start" layout:"2006-01-02 15:04" tz:"Asia/Tokyo"`
+		Deadline time.Time   `json:"deadline" tz:"Asia/Tokyo"`
+		Holidays []time.Time `json:"holidays" layout:"2006-01-02"`
+		Launch   *time.Time  `json:"launch" layout:"2006-01-02" default:"2025-06-01"`
+	}
+
+	tests := []struct {
+		name    string
+		src     map[string]any
+		want    Target
+		wantErr error
+	}{
+		{
+			name: "layouts",
+			src: map[string]any{
+				"since":    "2024-01-02T03:04:05Z",
+				"expiry":   "2025-12-31",
+				"start":    "2025-01-01 09:00",
+				"deadline": "2025-01-01T00:00:00Z",
+				"holidays": []any{"2025-01-01", "2025-12-25"},
+			},
+			want: Target{
+				Since:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+				Expiry:   time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+				Start:    time.Date(2025, 1, 1, 9, 0, 0, 0, tokyo),
+				Deadline: time.Date(2025, 1, 1, 9, 0, 0, 0, tokyo),
+				Holidays: []time.Time{
+					time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+					time.Date(2025, 12, 25, 0, 0, 0, 0, time.UTC),
+				},
+				Launch: &launch,
+			},
+		},
+		{
+			name:    "layout mismatch",
+			src:     map[string]any{"expiry": "2025-12-31T00:00:00Z"},
+			wantErr: maps.ErrCannotConvertToTime,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var dest Target
+
+			err := maps.Bind(tt.src, &dest)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, dest)
+		})
+	}
+}
+
+func TestBind_StandardTypes(t *testing.T) {
+	t.Parallel()
+
+	type Target struct {
+		IP       net.IP         `json:"ip"`
+		Network  net.IPNet      `json:"network"`
+		Networks []*net.IPNet   `json:"networks"`
+		Addr     netip.Addr     `json:"addr"`
+		Prefix   netip.Prefix   `json:"prefix"`
+		Endpoint *url.URL       `json:"endpoint"`
+		Pattern  *regexp.Regexp `json:"pattern"`
+	}
+
+	tests := []struct {
+		name    string
+		src     map[string]any
+		check   func(t *testing.T, dest Target)
+		wantErr error
+	}{
+		{
+			name: "strings",
+			src: map[string]any{
+				"ip":       "192.168.1.10",
+				"network":  "10.0.0.0/8",
+				"networks": []any{"172.16.0.0/12", "fd00::/8"},
+				"addr":     "::1",
+				"prefix":   "192.168.0.0/16",
+				"endpoint": "https://example.com:8443/api?v=1",
+				"pattern":  "^user-[0-9]+$",
+			},
+			check: func(t *testing.T, dest Target) {
+				t.Helper()
+
+				assert.True(t, dest.IP.Equal(net.IPv4(192, 168, 1, 10)))
+				assert.Equal(t, "10.0.0.0/8", dest.Network.String())
+				require.Len(t, dest.Networks, 2)
+				assert.Equal(t, "172.16.0.0/12", dest.Networks[0].String())
+				assert.Equal(t, "fd00::/8", dest.Networks[1].String())
+				assert.Equal(t, netip.IPv6Loopback(), dest.Addr)
+				assert.Equal(t, netip.MustParsePrefix("192.168.0.0/16"), dest.Prefix)
+				assert.Equal(t, "example.com:8443", dest.Endpoint.Host)
+				assert.Equal(t, "1", dest.Endpoint.Query().Get("v"))
+				assert.True(t, dest.Pattern.MatchString("user-42"))
+			},
+		},
+		{
+			name:    "invalid cidr",
+			src:     map[string]any{"network": "10.0.0.0"},
+			wantErr: maps.ErrCannotUnmarshalText,
+		},
+		{
+			name:    "invalid url",
+			src:     map[string]any{"endpoint": "http://[::1"},
+			wantErr: maps.ErrCannotUnmarshalText,
+		},
+		{
+			name:    "invalid pattern",
+			src:     map[string]any{"pattern": "(unclosed"},
+			wantErr: maps.ErrCannotUnmarshalText,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var dest Target
+
+			err := maps.Bind(tt.src, &dest)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			tt.check(t, dest)
+		})
+	}
+}
+
+// decimal is a fixed-point number with 2 decimal places, like third-party decimal types
+// implementing encoding.TextUnmarshaler.
+type decimal struct {
+	cents int64
+}
+
+var errInvalidDecimal = errors.New("invalid decimal")
+
+func (d *decimal) UnmarshalText(text []byte) error {
+	r, ok := new(big.Rat).SetString(string(text))
+	if !ok {
+		return fmt.Errorf("%w %q", errInvalidDecimal, text)
+	}
+
+	cents := new(big.Rat).Mul(r, big.NewRat(100, 1))
+	if !cents.IsInt() || !cents.Num().IsInt64() {
+		return fmt.Errorf("%w %q", errInvalidDecimal, text)
+	}
+
+	d.cents = cents.Num().Int64()
+
+	return nil
+}
+
+func TestBind_BigNumbers(t *testing.T) {
+	t.Parallel()
+
+	type Target struct {
+		Supply *big.Int   `json:"supply"`
+		Rate   *big.Rat   `json:"rate"`
+		Ratio  *big.Float `json:"ratio"`
+		Price  decimal    `json:"price"`
+		Level  slog.Level `json:"level"`
+	}
+
+	supply, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+
+	tests := []struct {
+		name    string
+		src     map[string]any
+		want    Target
+		wantErr error
+	}{
+		{
+			name: "strings",
+			src: map[string]any{
+				"supply": "123456789012345678901234567890",
+				"rate":   "0.1",
+				"ratio":  "0.5",
+				"price":  "19.99",
+			},
+			want: Target{
+				Supply: supply,
+				Rate:   big.NewRat(1, 10),
+				Ratio:  big.NewFloat(0.5),
+				Price:  decimal{cents: 1999},
+			},
+		},
+		{
+			name: "numbers",
+			src: map[string]any{
+				"supply": json.Number("123456789012345678901234567890"),
+				"rate":   0.1,
+				"ratio":  float32(0.5),
+				"price":  json.Number("19.99"),
+				"level":  4,
+			},
+			want: Target{
+				Supply: supply,
+				Rate:   big.NewRat(1, 10),
+				Ratio:  big.NewFloat(0.5),
+				Price:  decimal{cents: 1999},
+				Level:  slog.LevelWarn,
+			},
+		},
+		{
+			name: "integers",
+			src:  map[string]any{"supply": uint64(math.MaxUint64), "price": 20},
+			want: Target{
+				Supply: new(big.Int).SetUint64(math.MaxUint64),
+				Price:  decimal{cents: 2000},
+			},
+		},
+		{
+			name:    "fraction to integer",
+			src:     map[string]any{"supply": 1.5},
+			wantErr: maps.ErrCannotUnmarshalText,
+		},
+		{
+			name:    "invalid decimal",
+			src:     map[string]any{"price": 0.001},
+			wantErr: errInvalidDecimal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var dest Target
+
+			err := maps.Bind(tt.src, &dest)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.Supply.String(), dest.Supply.String())
+			assert.Equal(t, tt.want.Price, dest.Price)
+			assert.Equal(t, tt.want.Level, dest.Level)
+
+			if tt.want.Rate != nil {
+				assert.Equal(t, 0, tt.want.Rate.Cmp(dest.Rate))
+			}
+
+			if tt.want.Ratio != nil {
+				assert.Equal(t, 0, tt.want.Ratio.Cmp(dest.Ratio))
+			}
+		})
+	}
+}
+
+func TestBindValue(t *testing.T) {
+	t.Parallel()
+
+	type Server struct {
+		Host string `json:"host"`
+		Port int    `json:"port" required:"true"`
+	}
+
+	t.Run("slice", func(t *testing.T) {
+		t.Parallel()
+
+		var servers []Server
+
+		require.NoError(t, maps.BindValue([]any{
+			map[string]any{"host": "a", "port": 80},
+			map[string]any{"host": "b", "port": "443"},
+		}, &servers, maps.BindOptions{}))
+		assert.Equal(t, []Server{{Host: "a", Port: 80}, {Host: "b", Port: 443}}, servers)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		t.Parallel()
+
+		var servers map[string]Server
+
+		require.NoError(t, maps.BindValue(map[string]any{
+			"a": map[string]any{"port": 80},
+		}, &servers, maps.BindOptions{}))
+		assert.Equal(t, map[string]Server{"a": {Port: 80}}, servers)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		t.Parallel()
+
+		var server Server
+
+		err := maps.BindValue(map[string]any{"host": "a"}, &server, maps.BindOptions{})
+		require.ErrorIs(t, err, maps.ErrMissingKey)
+		assert.Contains(t, err.Error(), "missing key port")
+	})
+
+	t.Run("scalar", func(t *testing.T) {
+		t.Parallel()
+
+		var port int
+
+		require.NoError(t, maps.BindValue("8080", &port, maps.BindOptions{}))
+		assert.Equal(t, 8080, port)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		var servers []Server
+
+		require.ErrorIs(t, maps.BindValue([]any{}, nil, maps.BindOptions{}), maps.ErrDestIsNil)
+		require.ErrorIs(t, maps.BindValue([]any{}, servers, maps.BindOptions{}), maps.ErrDestMustBePointer)
+		require.ErrorIs(t, maps.BindValue(map[string]any{}, &servers, maps.BindOptions{}), maps.ErrCannotSetSliceFrom)
+	})
+}
+
+func TestBind_MapRoot(t *testing.T) {
+	t.Parallel()
+
+	var limits map[string]int
+
+	require.NoError(t, maps.Bind(map[string]any{"api": "100", "admin": 10}, &limits))
+	assert.Equal(t, map[string]int{"api": 100, "admin": 10}, limits)
+
+	var servers []string
+
+	require.ErrorIs(t, maps.Bind(map[string]any{}, &servers), maps.ErrDestMustPointToStruct)
+}
+
+func TestBind_JoinsErrors(t *testing.T) {
+	t.Parallel()
+
+	type Server struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+
+	type Target struct {
+		Name    string         `json:"name" required:"true"`
+		Port    int            `json:"port"`
+		Debug   bool           `json:"debug"`
+		Servers []Server       `json:"servers"`
+		Limits  map[string]int `json:"limits"`
+		Token   string         `json:"token" required:"true"`
+	}
+
+	var dest Target
+
+	err := maps.Bind(map[string]any{
+		"port":  "http",
+		"debug": "maybe",
+		"servers": []any{
+			map[string]any{"host": "a", "port": "x"},
+			map[string]any{"host": "b", "port": 443},
+			map[string]any{"host": "c", "port": "y"},
+		},
+		"limits": map[string]any{"b": "two", "a": 1, "c": "three"},
+	}, &dest)
+	require.Error(t, err)
+	require.ErrorIs(t, err, strconv.ErrSyntax)
+	require.ErrorIs(t, err, maps.ErrMissingKey)
+
+	// all the invalid keys are reported, in a stable order, and the valid ones are bound
+	assert.Equal(t, []string{
+		`key port: cannot bind string to field Port of type int: strconv.ParseInt: parsing "http": invalid syntax`,
+		`key debug: cannot bind string to field Debug of type bool: strconv.ParseBool: parsing "maybe": invalid syntax`,
+		`key servers.0.port: cannot bind string to field Servers[0].Port of type int: strconv.ParseInt: parsing "x": invalid syntax`,
+		`key servers.2.port: cannot bind string to field Servers[2].Port of type int: strconv.ParseInt: parsing "y": invalid syntax`,
+		`key limits.b: cannot bind string to field Limits[b] of type int: strconv.ParseInt: parsing "two": invalid syntax`,
+		`key limits.c: cannot bind string to field Limits[c] of type int: strconv.ParseInt: parsing "three": invalid syntax`,
+		"missing key name",
+		"missing key token",
+	}, strings.Split(err.Error(), "\n"))
+	assert.Equal(t, 443, dest.Servers[1].Port)
+	assert.Equal(t, map[string]int{"a": 1}, dest.Limits)
+}
+
+func TestBind_BindError(t *testing.T) {
+	t.Parallel()
+
+	type Server struct {
+		Port int `json:"port"`
+	}
+
+	type Target struct {
+		Servers []Server `json:"servers"`
+		Token   string   `json:"token" required:"true"`
+	}
+
+	var dest Target
+
+	err := maps.BindWithOptions(map[string]any{
+		"servers": []any{map[string]any{"port": []any{80}}},
+	}, &dest, maps.BindOptions{KeyPrefix: "app", KeySeparator: "/"})
+
+	var bindErr *maps.BindError
+
+	require.ErrorAs(t, err, &bindErr)
+	assert.Equal(t, "app/servers/0/port", bindErr.Key)
+	assert.Equal(t, "Servers[0].Port", bindErr.Field)
+	assert.Equal(t, reflect.TypeFor[int](), bindErr.Type)
+	assert.Equal(t, reflect.TypeFor[[]any](), bindErr.SourceType)
+	require.ErrorIs(t, bindErr, maps.ErrCannotConvertToInt64)
+
+	var joined interface{ Unwrap() []error }
+
+	require.ErrorAs(t, err, &joined)
+	require.Len(t, joined.Unwrap(), 2)
+	require.ErrorAs(t, joined.Unwrap()[1], &bindErr)
+	assert.Equal(t, "app/token", bindErr.Key)
+	assert.Equal(t, "Token", bindErr.Field)
+	assert.Nil(t, bindErr.SourceType)
+	require.ErrorIs(t, bindErr, maps.ErrMissingKey)
+	assert.Equal(t, "missing key app/token", bindErr.Error())
+}
+
+func TestBindWithOptions_OnUnusedKey(t *testing.T) {
+	t.Parallel()
+
+	type Server struct {
+		Host string `json:"host"`
+	}
+
+	type Target struct {
+		Name    string            `json:"name"`
+		Servers []Server          `json:"servers"`
+		Labels  map[string]string `json:"labels"`
+		Extra   any               `json:"extra"`
+	}
+
+	var unused []string
+
+	var dest Target
+
+	require.NoError(t, maps.BindWithOptions(map[string]any{
+		"name":    "app",
+		"Timeout": 30,
+		"servers": []any{
+			map[string]any{"host": "a", "port": 80},
+		},
+		"labels": map[string]any{"team": "core"},
+		"extra":  map[string]any{"anything": true},
+		"legacy": map[string]any{"url": "http://old"},
+	}, &dest, maps.BindOptions{
+		OnUnusedKey: func(key string) {
+			unused = append(unused, key)
+		},
+	}))
+
+	slices.Sort(unused)
+	assert.Equal(t, []string{"Timeout", "legacy", "servers.0.port"}, unused)
+}
@@ -0,0 +1,72 @@
+package maps
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidByteSize indicates that a string is not a valid byte size.
+var ErrInvalidByteSize = errors.New("invalid byte size")
+
+// byteSizeUnits maps the lowercased byte size suffixes to their multiplier: SI suffixes are powers
+// of 1000 and IEC suffixes powers of 1024.
+var byteSizeUnits = map[string]uint64{
+	"":    1,
+	"b":   1,
+	"kb":  1e3,
+	"mb":  1e6,
+	"gb":  1e9,
+	"tb":  1e12,
+	"pb":  1e15,
+	"eb":  1e18,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+	"eib": 1 << 60,
+}
+
+// ParseByteSize parses a byte size with an optional, case-insensitive unit suffix, e.g. "512",
+// "10MB" (10 * 1000^2 bytes), "1.5 GiB" (1.5 * 1024^3 bytes).
+func ParseByteSize(str string) (uint64, error) {
+	str = strings.TrimSpace(str)
+
+	// split the number from the unit
+	i := strings.IndexFunc(str, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(str)
+	}
+
+	number, unit := str[:i], strings.ToLower(strings.TrimSpace(str[i:]))
+
+	multiplier, ok := byteSizeUnits[unit]
+	if !ok || number == "" {
+		return 0, fmt.Errorf("%w %q", ErrInvalidByteSize, str)
+	}
+
+	if n, err := strconv.ParseUint(number, 10, 64); err == nil {
+		if n > math.MaxUint64/multiplier {
+			return 0, fmt.Errorf("%w: %q", ErrUnsignedIntegerOverflow, str)
+		}
+
+		return n * multiplier, nil
+	}
+
+	f, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q", ErrInvalidByteSize, str)
+	}
+
+	size := f * float64(multiplier)
+	if size >= math.MaxUint64 {
+		return 0, fmt.Errorf("%w: %q", ErrUnsignedIntegerOverflow, str)
+	}
+
+	return uint64(size), nil
+}
@@ -0,0 +1,101 @@
+package maps_test
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseByteSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    uint64
+		wantErr error
+	}{
+		{name: "bytes", input: "512", want: 512},
+		{name: "bytes suffix", input: "512B", want: 512},
+		{name: "SI", input: "10MB", want: 10_000_000},
+		{name: "IEC", input: "1GiB", want: 1 << 30},
+		{name: "case insensitive", input: "64kib", want: 64 << 10},
+		{name: "space", input: " 2 TB ", want: 2_000_000_000_000},
+		{name: "fraction", input: "1.5KiB", want: 1536},
+		{name: "unknown unit", input: "10XB", wantErr: maps.ErrInvalidByteSize},
+		{name: "no number", input: "MB", wantErr: maps.ErrInvalidByteSize},
+		{name: "invalid number", input: "1.2.3MB", wantErr: maps.ErrInvalidByteSize},
+		{name: "overflow", input: "20EiB", wantErr: maps.ErrUnsignedIntegerOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := maps.ParseByteSize(tt.input)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBind_ByteSize(t *testing.T) {
+	t.Parallel()
+
+	type Target struct {
+		MaxBody  int64  `json:"max_body" bytesize:"true"`
+		Cache    uint64 `json:"cache" bytesize:"true" default:"64MiB"`
+		Buffer   int32  `json:"buffer" bytesize:"true"`
+		Untagged int64  `json:"untagged"`
+	}
+
+	tests := []struct {
+		name    string
+		src     map[string]any
+		want    Target
+		wantErr error
+	}{
+		{
+			name: "sizes",
+			src:  map[string]any{"max_body": "10MB", "buffer": 4096},
+			want: Target{MaxBody: 10_000_000, Cache: 64 << 20, Buffer: 4096},
+		},
+		{
+			name:    "overflow",
+			src:     map[string]any{"buffer": "4GiB"},
+			wantErr: maps.ErrIntegerOverflow,
+		},
+		{
+			name:    "untagged",
+			src:     map[string]any{"untagged": "10MB"},
+			wantErr: strconv.ErrSyntax,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var dest Target
+
+			err := maps.Bind(tt.src, &dest)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, dest)
+		})
+	}
+}
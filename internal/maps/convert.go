@@ -0,0 +1,25 @@
+package maps
+
+import (
+	"errors"
+	"reflect"
+)
+
+// ErrConvertDestMustBePointer indicates that the conversion destination must be a non-nil pointer.
+var ErrConvertDestMustBePointer = errors.New("dest must be a non-nil pointer")
+
+// Convert converts v into the value pointed to by dest, using the same conversion rules as Bind
+// (e.g., the string "5432" can be converted to an int).
+func Convert(v any, dest any) error {
+	return ConvertWith(v, dest, BindOptions{})
+}
+
+// ConvertWith is like Convert, converting v according to opts, e.g. with decode hooks.
+func ConvertWith(v any, dest any, opts BindOptions) error {
+	rv := reflect.ValueOf(dest)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return ErrConvertDestMustBePointer
+	}
+
+	return binder{opts: opts, path: opts.KeyPrefix}.setValue(rv.Elem(), v)
+}
@@ -0,0 +1,98 @@
+package maps_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	t.Run("string to int", func(t *testing.T) {
+		t.Parallel()
+
+		var out int
+		require.NoError(t, maps.Convert("5432", &out))
+		assert.Equal(t, 5432, out)
+	})
+
+	t.Run("float to int", func(t *testing.T) {
+		t.Parallel()
+
+		var out int
+		require.NoError(t, maps.Convert(float64(8080), &out))
+		assert.Equal(t, 8080, out)
+	})
+
+	t.Run("string to bool", func(t *testing.T) {
+		t.Parallel()
+
+		var out bool
+		require.NoError(t, maps.Convert("true", &out))
+		assert.True(t, out)
+	})
+
+	t.Run("int to string", func(t *testing.T) {
+		t.Parallel()
+
+		var out string
+		require.NoError(t, maps.Convert(42, &out))
+		assert.Equal(t, "42", out)
+	})
+
+	t.Run("slice", func(t *testing.T) {
+		t.Parallel()
+
+		var out []int
+		require.NoError(t, maps.Convert([]any{"1", 2.0}, &out))
+		assert.Equal(t, []int{1, 2}, out)
+	})
+
+	t.Run("json.Number", func(t *testing.T) {
+		t.Parallel()
+
+		var i int64
+		require.NoError(t, maps.Convert(json.Number("9007199254740993"), &i))
+		assert.Equal(t, int64(9007199254740993), i)
+
+		var u uint64
+		require.NoError(t, maps.Convert(json.Number("18446744073709551615"), &u))
+		assert.Equal(t, uint64(18446744073709551615), u)
+
+		var f float64
+		require.NoError(t, maps.Convert(json.Number("1.5"), &f))
+		assert.InDelta(t, 1.5, f, 0)
+
+		var truncated int
+		require.NoError(t, maps.Convert(json.Number("2.0"), &truncated))
+		assert.Equal(t, 2, truncated)
+
+		var b bool
+		require.NoError(t, maps.Convert(json.Number("1"), &b))
+		assert.True(t, b)
+
+		var s string
+		require.NoError(t, maps.Convert(json.Number("42"), &s))
+		assert.Equal(t, "42", s)
+
+		require.Error(t, maps.Convert(json.Number("-1"), &u))
+		require.Error(t, maps.Convert(json.Number("300"), new(int8)))
+	})
+
+	t.Run("invalid conversion", func(t *testing.T) {
+		t.Parallel()
+
+		var out int
+		require.Error(t, maps.Convert("not a number", &out))
+	})
+
+	t.Run("non-pointer dest", func(t *testing.T) {
+		t.Parallel()
+
+		require.ErrorIs(t, maps.Convert(1, 0), maps.ErrConvertDestMustBePointer)
+	})
+}
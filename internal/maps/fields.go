@@ -0,0 +1,91 @@
+package maps
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// FieldNode is a configuration key of a struct, see FieldTree.
+type FieldNode struct {
+	// Key is the key of the field, relative to its parent.
+	Key string
+	// Field is the struct field bound from the key.
+	Field reflect.StructField
+	// Value is the value of the field; nil pointers are replaced by the zero value of their
+	// element type.
+	Value reflect.Value
+	// Children holds the keys of nested structs, nil for the keys bound to a single value.
+	Children []FieldNode
+}
+
+// FieldTree returns the keys the fields of the struct v are bound from, in declaration order, with
+// the fields of nested structs as children. Fields are keyed the same way as when binding with
+// opts, and the fields of embedded structs are promoted. Structs bound from a single value (e.g.
+// time.Time, url.URL or types implementing Unmarshaler) aren't descended into.
+func FieldTree(v reflect.Value, opts BindOptions) []FieldNode {
+	b := binder{opts: opts}
+
+	return b.fieldTree(v, map[reflect.Type]bool{})
+}
+
+func (b binder) fieldTree(v reflect.Value, visiting map[reflect.Type]bool) []FieldNode {
+	t := v.Type()
+	if visiting[t] {
+		return nil
+	}
+
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	fields := structFields(buildStructFieldMap(t, b.tagNames()))
+	nodes := make([]FieldNode, 0, len(fields))
+
+	for _, fi := range fields {
+		fv, err := v.FieldByIndexErr(fi.Path)
+		if err != nil {
+			// field of a nil embedded struct pointer
+			fv = reflect.New(t.FieldByIndex(fi.Path).Type).Elem()
+		}
+
+		for fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				fv = reflect.New(fv.Type().Elem())
+			}
+
+			fv = fv.Elem()
+		}
+
+		node := FieldNode{Key: fi.Key, Field: t.FieldByIndex(fi.Path), Value: fv}
+
+		if fv.Kind() == reflect.Struct && !IsValueStruct(fv.Type()) {
+			node.Children = b.fieldTree(fv, visiting)
+			if node.Children == nil {
+				node.Children = []FieldNode{}
+			}
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
+// IsValueStruct reports whether values of the struct type t are bound from a single value rather
+// than from their fields.
+func IsValueStruct(t reflect.Type) bool {
+	if t == reflect.TypeFor[time.Time]() {
+		return true
+	}
+
+	if _, ok := stringParsers[t]; ok {
+		return true
+	}
+
+	ptr := reflect.PointerTo(t)
+
+	return ptr.Implements(reflect.TypeFor[Unmarshaler]()) ||
+		ptr.Implements(reflect.TypeFor[encoding.TextUnmarshaler]()) ||
+		ptr.Implements(reflect.TypeFor[json.Unmarshaler]())
+}
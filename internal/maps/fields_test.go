@@ -0,0 +1,54 @@
+package maps_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldTree(t *testing.T) {
+	t.Parallel()
+
+	type Base struct {
+		Name string `json:"name"`
+	}
+
+	type Node struct {
+		Base
+
+		Server *struct {
+			Port int `gcfg:"port"`
+		} `gcfg:"server"`
+		Since time.Time
+		Next  *Node `gcfg:"next"`
+		tag   string
+	}
+
+	value := Node{Base: Base{Name: "app"}, tag: "ignored"}
+	nodes := maps.FieldTree(reflect.ValueOf(value), maps.BindOptions{})
+
+	keys := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		keys = append(keys, node.Key)
+	}
+
+	require.Equal(t, []string{"name", "server", "since", "next"}, keys)
+	assert.Equal(t, "app", nodes[0].Value.Interface())
+	assert.Nil(t, nodes[0].Children)
+
+	// nil pointers are replaced by zero values
+	require.Len(t, nodes[1].Children, 1)
+	assert.Equal(t, "port", nodes[1].Children[0].Key)
+	assert.Equal(t, 0, nodes[1].Children[0].Value.Interface())
+
+	// time.Time is bound from a single value
+	assert.Nil(t, nodes[2].Children)
+
+	// recursive types aren't descended into
+	assert.Empty(t, nodes[3].Children)
+	assert.NotNil(t, nodes[3].Children)
+}
@@ -0,0 +1,127 @@
+package maps
+
+import (
+	"sort"
+	"strings"
+)
+
+// pathEscape escapes the separator within the segments of paths, see EscapeSegment.
+const pathEscape = `\`
+
+// EscapeSegment escapes the occurrences of sep and of the escape character "\" in segment, so a
+// path joined with JoinPath is split back into the same segments by SplitPath, e.g. the segment
+// "app.kubernetes.io/name" is escaped to `app\.kubernetes\.io/name` with sep ".".
+func EscapeSegment(segment, sep string) string {
+	if sep == "" || (!strings.Contains(segment, pathEscape) && !strings.Contains(segment, sep)) {
+		return segment
+	}
+
+	segment = strings.ReplaceAll(segment, pathEscape, pathEscape+pathEscape)
+
+	return strings.ReplaceAll(segment, sep, pathEscape+sep)
+}
+
+// JoinPath appends the escaped segment to the sep-joined path prefix.
+func JoinPath(prefix, segment, sep string) string {
+	if prefix == "" {
+		return EscapeSegment(segment, sep)
+	}
+
+	return prefix + sep + EscapeSegment(segment, sep)
+}
+
+// JoinSegments joins the escaped segments with sep, the inverse of SplitPath.
+func JoinSegments(segments []string, sep string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = EscapeSegment(segment, sep)
+	}
+
+	return strings.Join(escaped, sep)
+}
+
+// SplitPath splits the sep-joined path into its segments, the inverse of JoinPath. A separator
+// preceded by "\" is part of the segment rather than a separator, and "\\" stands for "\".
+func SplitPath(path, sep string) []string {
+	if sep == "" {
+		return []string{path}
+	}
+
+	if !strings.Contains(path, pathEscape) {
+		return strings.Split(path, sep)
+	}
+
+	var (
+		parts   []string
+		segment strings.Builder
+	)
+
+	for i := 0; i < len(path); {
+		switch {
+		case strings.HasPrefix(path[i:], pathEscape+sep):
+			segment.WriteString(sep)
+
+			i += len(pathEscape) + len(sep)
+		case strings.HasPrefix(path[i:], pathEscape+pathEscape):
+			segment.WriteString(pathEscape)
+
+			i += 2 * len(pathEscape)
+		case strings.HasPrefix(path[i:], sep):
+			parts = append(parts, segment.String())
+			segment.Reset()
+
+			i += len(sep)
+		default:
+			segment.WriteByte(path[i])
+
+			i++
+		}
+	}
+
+	return append(parts, segment.String())
+}
+
+// Flatten converts a nested map into a flat map keyed by the sep-joined paths of its leaf values,
+// with the segments escaped (see EscapeSegment). Leaves are all non-map values as well as empty
+// nested maps.
+func Flatten(m map[string]any, sep string) map[string]any {
+	out := make(map[string]any)
+	flattenRecursive(m, "", sep, out)
+
+	return out
+}
+
+func flattenRecursive(m map[string]any, prefix, sep string, out map[string]any) {
+	for k, v := range m {
+		path := JoinPath(prefix, k, sep)
+
+		if nested, ok := v.(map[string]any); ok && len(nested) > 0 {
+			flattenRecursive(nested, path, sep, out)
+
+			continue
+		}
+
+		out[path] = v
+	}
+}
+
+// Expand converts a flat map keyed by sep-joined paths back into a nested map, the inverse of
+// Flatten. When a path collides with an existing non-map value, the later path in sorted order
+// wins.
+func Expand(flat map[string]any, sep string) map[string]any {
+	out := make(map[string]any)
+
+	keys := make([]string, 0, len(flat))
+	for k := range flat {
+		keys = append(keys, k)
+	}
+
+	// Sort keys for a deterministic result on colliding paths.
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		SetNested(out, SplitPath(k, sep), flat[k])
+	}
+
+	return out
+}
@@ -0,0 +1,180 @@
+package maps_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatten(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		m        map[string]any
+		expected map[string]any
+	}{
+		{
+			name:     "empty map",
+			m:        map[string]any{},
+			expected: map[string]any{},
+		},
+		{
+			name: "nested maps",
+			m: map[string]any{
+				"app": map[string]any{
+					"name": "myapp",
+					"server": map[string]any{
+						"port": 8080,
+					},
+				},
+				"debug": true,
+			},
+			expected: map[string]any{
+				"app.name":        "myapp",
+				"app.server.port": 8080,
+				"debug":           true,
+			},
+		},
+		{
+			name: "empty nested map is a leaf",
+			m: map[string]any{
+				"app": map[string]any{},
+			},
+			expected: map[string]any{
+				"app": map[string]any{},
+			},
+		},
+		{
+			name: "separators within keys are escaped",
+			m: map[string]any{
+				"labels": map[string]any{
+					"app.kubernetes.io/name": "web",
+					`C:\data`:                "dir",
+				},
+			},
+			expected: map[string]any{
+				`labels.app\.kubernetes\.io/name`: "web",
+				`labels.C:\\data`:                 "dir",
+			},
+		},
+		{
+			name: "slices are leaves",
+			m: map[string]any{
+				"hosts": []any{"a", "b"},
+			},
+			expected: map[string]any{
+				"hosts": []any{"a", "b"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, maps.Flatten(tt.m, "."))
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		flat     map[string]any
+		expected map[string]any
+	}{
+		{
+			name:     "empty map",
+			flat:     map[string]any{},
+			expected: map[string]any{},
+		},
+		{
+			name: "escaped separators",
+			flat: map[string]any{
+				`labels.app\.kubernetes\.io/name`: "web",
+			},
+			expected: map[string]any{
+				"labels": map[string]any{"app.kubernetes.io/name": "web"},
+			},
+		},
+		{
+			name: "nested paths",
+			flat: map[string]any{
+				"app.name":        "myapp",
+				"app.server.port": 8080,
+				"debug":           true,
+			},
+			expected: map[string]any{
+				"app": map[string]any{
+					"name": "myapp",
+					"server": map[string]any{
+						"port": 8080,
+					},
+				},
+				"debug": true,
+			},
+		},
+		{
+			name: "deeper path replaces scalar",
+			flat: map[string]any{
+				"app":      "scalar",
+				"app.name": "myapp",
+			},
+			expected: map[string]any{
+				"app": map[string]any{
+					"name": "myapp",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, maps.Expand(tt.flat, "."))
+		})
+	}
+}
+
+func TestSplitPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		path     string
+		sep      string
+		expected []string
+	}{
+		{name: "plain", path: "a.b.c", sep: ".", expected: []string{"a", "b", "c"}},
+		{name: "escaped separator", path: `a.b\.c`, sep: ".", expected: []string{"a", "b.c"}},
+		{name: "escaped escape", path: `a\\.b`, sep: ".", expected: []string{`a\`, "b"}},
+		{name: "lone escape", path: `a\b.c`, sep: ".", expected: []string{`a\b`, "c"}},
+		{name: "multi-character separator", path: `a::b\::c`, sep: "::", expected: []string{"a", "b::c"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, maps.SplitPath(tt.path, tt.sep))
+		})
+	}
+}
+
+func TestJoinPath_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	segments := []string{"labels", "app.kubernetes.io/name", `C:\data`, `trailing\`, ""}
+
+	path := ""
+	for _, segment := range segments {
+		path = maps.JoinPath(path, segment, ".")
+	}
+
+	assert.Equal(t, segments, maps.SplitPath(path, "."))
+}
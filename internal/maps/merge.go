@@ -4,23 +4,37 @@ import (
 	"strings"
 )
 
+// NormalizeKey is the key normalization applied by Merge and MergeWithoutOverride: keys are
+// trimmed and lower-cased.
+func NormalizeKey(key string) string {
+	return strings.ToLower(strings.TrimSpace(key))
+}
+
 // Merge deep merges src into dst while ignoring empty keys and normalizing keys to lower-case.
 func Merge(dst, src map[string]any) {
+	MergeFunc(dst, src, NormalizeKey)
+}
+
+// MergeFunc deep merges src into dst while normalizing keys with normalize, and ignoring keys
+// normalized to "". Maps of src added to dst are copied, so their nested keys are normalized too.
+func MergeFunc(dst, src map[string]any, normalize func(key string) string) {
 	for k, val := range src {
-		normalK := strings.ToLower(strings.TrimSpace(k))
+		normalK := normalize(k)
 		if normalK == "" {
 			continue
 		}
 
-		// If both dst[normalK] and val are maps, merge them recursively
-		if dv, ok := dst[normalK]; ok {
-			if dm, ok1 := dv.(map[string]any); ok1 {
-				if sm, ok2 := val.(map[string]any); ok2 {
-					Merge(dm, sm)
-
-					continue
-				}
+		if sm, ok := val.(map[string]any); ok {
+			// If both dst[normalK] and val are maps, merge them recursively
+			dm, ok := dst[normalK].(map[string]any)
+			if !ok {
+				dm = make(map[string]any, len(sm))
+				dst[normalK] = dm
 			}
+
+			MergeFunc(dm, sm, normalize)
+
+			continue
 		}
 
 		// Otherwise, just overwrite
@@ -31,8 +45,15 @@ func Merge(dst, src map[string]any) {
 // MergeWithoutOverride deep merges src into dst without overriding existing values,
 // while ignoring empty keys and normalizing keys to lower-case.
 func MergeWithoutOverride(dst, src map[string]any) {
+	MergeWithoutOverrideFunc(dst, src, NormalizeKey)
+}
+
+// MergeWithoutOverrideFunc deep merges src into dst without overriding existing values, while
+// normalizing keys with normalize, and ignoring keys normalized to "". Maps of src added to dst are
+// copied, so their nested keys are normalized too.
+func MergeWithoutOverrideFunc(dst, src map[string]any, normalize func(key string) string) {
 	for k, val := range src {
-		normalK := strings.ToLower(strings.TrimSpace(k))
+		normalK := normalize(k)
 		if normalK == "" {
 			continue
 		}
@@ -42,7 +63,7 @@ func MergeWithoutOverride(dst, src map[string]any) {
 			// If both are maps, merge them recursively
 			if dm, ok1 := dv.(map[string]any); ok1 {
 				if sm, ok2 := val.(map[string]any); ok2 {
-					MergeWithoutOverride(dm, sm)
+					MergeWithoutOverrideFunc(dm, sm, normalize)
 
 					continue
 				}
@@ -53,6 +74,12 @@ func MergeWithoutOverride(dst, src map[string]any) {
 		}
 
 		// Key doesn't exist in dst, so add it
+		if sm, ok := val.(map[string]any); ok {
+			dm := make(map[string]any, len(sm))
+			MergeWithoutOverrideFunc(dm, sm, normalize)
+			val = dm
+		}
+
 		dst[normalK] = val
 	}
 }
@@ -108,6 +108,16 @@ func TestMerge(t *testing.T) {
 				"config": "simple",
 			},
 		},
+		{
+			name: "normalize keys of added nested maps",
+			dst:  map[string]any{},
+			src: map[string]any{
+				"Database": map[string]any{"Host": "localhost"},
+			},
+			expected: map[string]any{
+				"database": map[string]any{"host": "localhost"},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -339,3 +349,22 @@ func TestMerge_vs_MergeWithoutOverride(t *testing.T) {
 	}
 	assert.Equal(t, expectedMergeWithoutOverride, dstMergeWithoutOverride)
 }
+
+func TestMergeFunc(t *testing.T) {
+	t.Parallel()
+
+	identity := func(key string) string { return key }
+
+	dst := map[string]any{"Labels": map[string]any{"Team": "platform"}}
+	src := map[string]any{"Labels": map[string]any{"team": "infra", "": "ignored"}, "Name": "api"}
+
+	maps.MergeFunc(dst, src, identity)
+	assert.Equal(t, map[string]any{
+		"Labels": map[string]any{"Team": "platform", "team": "infra"},
+		"Name":   "api",
+	}, dst)
+
+	maps.MergeWithoutOverrideFunc(dst, map[string]any{"Name": "web", "Tier": "backend"}, identity)
+	assert.Equal(t, "api", dst["Name"])
+	assert.Equal(t, "backend", dst["Tier"])
+}
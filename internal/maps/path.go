@@ -0,0 +1,169 @@
+package maps
+
+import "strconv"
+
+// SetNested sets value at the given path, creating intermediate maps as needed.
+// Intermediate values that are not maps are replaced.
+func SetNested(m map[string]any, path []string, value any) {
+	if len(path) == 0 {
+		return
+	}
+
+	parent := m
+
+	for _, part := range path[:len(path)-1] {
+		nested, ok := parent[part].(map[string]any)
+		if !ok {
+			nested = make(map[string]any)
+			parent[part] = nested
+		}
+
+		parent = nested
+	}
+
+	parent[path[len(path)-1]] = value
+}
+
+// DeleteNested removes the value at the given path and prunes parent maps left empty by the removal.
+// Returns true if a value was removed.
+func DeleteNested(m map[string]any, path []string) bool {
+	if len(path) == 0 {
+		return false
+	}
+
+	if len(path) == 1 {
+		if _, exists := m[path[0]]; !exists {
+			return false
+		}
+
+		delete(m, path[0])
+
+		return true
+	}
+
+	nested, ok := m[path[0]].(map[string]any)
+	if !ok {
+		return false
+	}
+
+	if !DeleteNested(nested, path[1:]) {
+		return false
+	}
+
+	if len(nested) == 0 {
+		delete(m, path[0])
+	}
+
+	return true
+}
+
+// GetNested returns the value at the given path and reports whether it exists. Besides nested
+// maps, the path traverses []any slices, whose elements are addressed by their index
+// (e.g., "servers", "0", "host").
+func GetNested(m map[string]any, path []string) (any, bool) {
+	var current any = m
+
+	for _, part := range path {
+		switch container := current.(type) {
+		case map[string]any:
+			value, ok := container[part]
+			if !ok {
+				return nil, false
+			}
+
+			current = value
+		case []any:
+			i, ok := sliceIndex(container, part)
+			if !ok {
+				return nil, false
+			}
+
+			current = container[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// SetNestedIndexed sets value at the given path, traversing []any slices like GetNested and
+// creating missing intermediate maps. Unlike SetNested, intermediate values that are neither maps
+// nor slices are not replaced, and slice indices must be within range. Returns true if the value
+// was set.
+func SetNestedIndexed(m map[string]any, path []string, value any) bool {
+	if len(path) == 0 {
+		return false
+	}
+
+	var current any = m
+
+	for i, part := range path {
+		last := i == len(path)-1
+
+		switch container := current.(type) {
+		case map[string]any:
+			if last {
+				container[part] = value
+
+				return true
+			}
+
+			next, ok := container[part]
+			if !ok {
+				next = make(map[string]any)
+				container[part] = next
+			}
+
+			current = next
+		case []any:
+			index, ok := sliceIndex(container, part)
+			if !ok {
+				return false
+			}
+
+			if last {
+				container[index] = value
+
+				return true
+			}
+
+			current = container[index]
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// SlicePrefix returns the number of leading segments of path addressing the first []any slice
+// traversed by the path (excluding its final segment), or 0 if the path traverses no slice.
+func SlicePrefix(m map[string]any, path []string) int {
+	var current any = m
+
+	for i := range len(path) - 1 {
+		switch container := current.(type) {
+		case map[string]any:
+			current = container[path[i]]
+		default:
+			return 0
+		}
+
+		if _, ok := current.([]any); ok {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// sliceIndex parses part as an index of s, reporting whether it is a valid one.
+func sliceIndex(s []any, part string) (int, bool) {
+	i, err := strconv.Atoi(part)
+	if err != nil || i < 0 || i >= len(s) {
+		return 0, false
+	}
+
+	return i, true
+}
@@ -0,0 +1,227 @@
+package maps_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetNested(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		m        map[string]any
+		path     []string
+		value    any
+		expected map[string]any
+	}{
+		{
+			name:     "empty path is a no-op",
+			m:        map[string]any{"key": "value"},
+			path:     []string{},
+			value:    "ignored",
+			expected: map[string]any{"key": "value"},
+		},
+		{
+			name:     "creates intermediate maps",
+			m:        map[string]any{},
+			path:     []string{"a", "b", "c"},
+			value:    1,
+			expected: map[string]any{"a": map[string]any{"b": map[string]any{"c": 1}}},
+		},
+		{
+			name:     "replaces non-map intermediate values",
+			m:        map[string]any{"a": "scalar"},
+			path:     []string{"a", "b"},
+			value:    1,
+			expected: map[string]any{"a": map[string]any{"b": 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			maps.SetNested(tt.m, tt.path, tt.value)
+			assert.Equal(t, tt.expected, tt.m)
+		})
+	}
+}
+
+func TestDeleteNested(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		m        map[string]any
+		path     []string
+		deleted  bool
+		expected map[string]any
+	}{
+		{
+			name:     "empty path",
+			m:        map[string]any{"key": "value"},
+			path:     []string{},
+			deleted:  false,
+			expected: map[string]any{"key": "value"},
+		},
+		{
+			name:     "missing key",
+			m:        map[string]any{"key": "value"},
+			path:     []string{"a", "b"},
+			deleted:  false,
+			expected: map[string]any{"key": "value"},
+		},
+		{
+			name: "prunes empty parents",
+			m: map[string]any{
+				"a":   map[string]any{"b": map[string]any{"c": 1}},
+				"key": "value",
+			},
+			path:     []string{"a", "b", "c"},
+			deleted:  true,
+			expected: map[string]any{"key": "value"},
+		},
+		{
+			name: "keeps non-empty parents",
+			m: map[string]any{
+				"a": map[string]any{"b": 1, "c": 2},
+			},
+			path:     []string{"a", "b"},
+			deleted:  true,
+			expected: map[string]any{"a": map[string]any{"c": 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.deleted, maps.DeleteNested(tt.m, tt.path))
+			assert.Equal(t, tt.expected, tt.m)
+		})
+	}
+}
+
+func TestGetNested(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]any{
+		"database": map[string]any{"host": "localhost"},
+		"servers": []any{
+			map[string]any{"host": "a"},
+			map[string]any{"host": "b"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		path     []string
+		expected any
+		exists   bool
+	}{
+		{name: "nested map", path: []string{"database", "host"}, expected: "localhost", exists: true},
+		{name: "slice element", path: []string{"servers", "1"}, expected: map[string]any{"host": "b"}, exists: true},
+		{name: "through slice", path: []string{"servers", "0", "host"}, expected: "a", exists: true},
+		{name: "index out of range", path: []string{"servers", "2", "host"}},
+		{name: "negative index", path: []string{"servers", "-1"}},
+		{name: "non-numeric index", path: []string{"servers", "first"}},
+		{name: "missing key", path: []string{"database", "port"}},
+		{name: "through scalar", path: []string{"database", "host", "name"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			value, exists := maps.GetNested(m, tt.path)
+			assert.Equal(t, tt.exists, exists)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestSetNestedIndexed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		m        map[string]any
+		path     []string
+		value    any
+		set      bool
+		expected map[string]any
+	}{
+		{
+			name:     "empty path",
+			m:        map[string]any{"key": "value"},
+			path:     []string{},
+			value:    1,
+			expected: map[string]any{"key": "value"},
+		},
+		{
+			name:     "creates intermediate maps",
+			m:        map[string]any{},
+			path:     []string{"a", "b"},
+			value:    1,
+			set:      true,
+			expected: map[string]any{"a": map[string]any{"b": 1}},
+		},
+		{
+			name:     "sets slice element field",
+			m:        map[string]any{"servers": []any{map[string]any{"port": 80}}},
+			path:     []string{"servers", "0", "port"},
+			value:    8081,
+			set:      true,
+			expected: map[string]any{"servers": []any{map[string]any{"port": 8081}}},
+		},
+		{
+			name:     "replaces slice element",
+			m:        map[string]any{"ports": []any{80, 443}},
+			path:     []string{"ports", "1"},
+			value:    8443,
+			set:      true,
+			expected: map[string]any{"ports": []any{80, 8443}},
+		},
+		{
+			name:     "index out of range",
+			m:        map[string]any{"ports": []any{80}},
+			path:     []string{"ports", "1"},
+			value:    443,
+			expected: map[string]any{"ports": []any{80}},
+		},
+		{
+			name:     "keeps scalar intermediate values",
+			m:        map[string]any{"a": "scalar"},
+			path:     []string{"a", "b"},
+			value:    1,
+			expected: map[string]any{"a": "scalar"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.set, maps.SetNestedIndexed(tt.m, tt.path, tt.value))
+			assert.Equal(t, tt.expected, tt.m)
+		})
+	}
+}
+
+func TestSlicePrefix(t *testing.T) {
+	t.Parallel()
+
+	m := map[string]any{
+		"servers": []any{map[string]any{"port": 80}},
+		"a":       map[string]any{"ports": []any{80}},
+	}
+
+	assert.Equal(t, 1, maps.SlicePrefix(m, []string{"servers", "0", "port"}))
+	assert.Equal(t, 2, maps.SlicePrefix(m, []string{"a", "ports", "0"}))
+	assert.Equal(t, 0, maps.SlicePrefix(m, []string{"servers"}))
+	assert.Equal(t, 0, maps.SlicePrefix(m, []string{"a", "ports"}))
+	assert.Equal(t, 0, maps.SlicePrefix(m, []string{"missing", "0"}))
+}
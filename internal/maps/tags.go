@@ -0,0 +1,48 @@
+package maps
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// TaggedKeys returns the keys, with their segments joined with sep, of the fields of the struct
+// type t, including the fields of nested structs, whose struct tag tag is true (e.g.,
+// `secret:"true"`). Fields are keyed the same way as when binding with opts.
+func TaggedKeys(t reflect.Type, tag, sep string, opts BindOptions) []string {
+	b := binder{opts: opts}
+
+	var keys []string
+
+	b.taggedKeys(t, tag, sep, "", map[reflect.Type]bool{}, &keys)
+
+	return keys
+}
+
+func (b binder) taggedKeys(t reflect.Type, tag, sep, prefix string, visiting map[reflect.Type]bool, keys *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || visiting[t] {
+		return
+	}
+
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	for _, fi := range structFields(buildStructFieldMap(t, b.tagNames())) {
+		key := fi.Key
+		if prefix != "" {
+			key = prefix + sep + key
+		}
+
+		sf := t.FieldByIndex(fi.Path)
+		if tagged, _ := strconv.ParseBool(sf.Tag.Get(tag)); tagged {
+			*keys = append(*keys, key)
+
+			continue
+		}
+
+		b.taggedKeys(sf.Type, tag, sep, key, visiting, keys)
+	}
+}
@@ -0,0 +1,64 @@
+package maps_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaggedKeys(t *testing.T) {
+	t.Parallel()
+
+	type Credentials struct {
+		User     string
+		Password string `secret:"true"`
+	}
+
+	type Node struct {
+		Name string
+		Next *Node  // recursive types are not traversed again
+		Key  string `secret:"true"`
+	}
+
+	type Target struct {
+		Database Credentials `json:"db"`
+		Backup   *Credentials
+		Token    string `gcfg:"api_token" secret:"true"`
+		Public   string `secret:"false"`
+		Nodes    Node
+	}
+
+	tests := []struct {
+		name     string
+		sep      string
+		opts     maps.BindOptions
+		expected []string
+	}{
+		{
+			name:     "nested and pointer structs",
+			sep:      ".",
+			expected: []string{"db.password", "backup.password", "api_token", "nodes.key"},
+		},
+		{
+			name:     "custom separator",
+			sep:      "/",
+			expected: []string{"db/password", "backup/password", "api_token", "nodes/key"},
+		},
+		{
+			name:     "custom tags",
+			sep:      ".",
+			opts:     maps.BindOptions{TagNames: []string{}},
+			expected: []string{"database.password", "backup.password", "token", "nodes.key"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.ElementsMatch(t, tt.expected, maps.TaggedKeys(reflect.TypeOf(&Target{}), "secret", tt.sep, tt.opts))
+		})
+	}
+}
@@ -2,7 +2,10 @@
 package providers
 
 import (
+	"context"
+	"crypto/sha256"
 	"io/fs"
+	"time"
 
 	"github.com/ahmedkamalio/gcfg/internal/sysfs"
 )
@@ -11,6 +14,11 @@ import (
 // It is used as a base provider for other file-based configuration providers.
 type FSProvider struct {
 	fs fs.FS
+
+	// allowSymlinksSet and maxFileSizeSet record whether SetAllowSymlinks and SetMaxFileSize were
+	// called, so the inherited settings don't override them.
+	allowSymlinksSet bool
+	maxFileSizeSet   bool
 }
 
 // NewFSProvider creates a new FSProvider with the given fs.FS implementation.
@@ -31,6 +39,64 @@ func (p *FSProvider) SetFS(fs fs.FS) {
 	p.fs = fs
 }
 
+// SetBaseDir sets the directory the files must be within, relative paths being resolved against
+// it, when reading them with the default sysfs.SysFS. An empty dir is the working directory.
+func (p *FSProvider) SetBaseDir(dir string) {
+	if sfs, ok := p.fs.(*sysfs.SysFS); ok {
+		sfs.BaseDir = dir
+	}
+}
+
+// BaseDir returns the directory set with SetBaseDir, empty if unset or not reading files with the
+// default sysfs.SysFS.
+func (p *FSProvider) BaseDir() string {
+	if sfs, ok := p.fs.(*sysfs.SysFS); ok {
+		return sfs.BaseDir
+	}
+
+	return ""
+}
+
+// SetAllowSymlinks sets whether symlinks within the base directory may be opened, when reading
+// files with the default sysfs.SysFS.
+func (p *FSProvider) SetAllowSymlinks(allow bool) {
+	p.allowSymlinksSet = true
+	p.setAllowSymlinks(allow)
+}
+
+// InheritAllowSymlinks is like SetAllowSymlinks, unless SetAllowSymlinks was called.
+func (p *FSProvider) InheritAllowSymlinks(allow bool) {
+	if !p.allowSymlinksSet {
+		p.setAllowSymlinks(allow)
+	}
+}
+
+func (p *FSProvider) setAllowSymlinks(allow bool) {
+	if sfs, ok := p.fs.(*sysfs.SysFS); ok {
+		sfs.AllowSymlinks = allow
+	}
+}
+
+// SetMaxFileSize sets the size limit of the files in bytes, when reading them with the default
+// sysfs.SysFS. Zero is sysfs.DefaultMaxFileSize and a negative size disables the limit.
+func (p *FSProvider) SetMaxFileSize(size int64) {
+	p.maxFileSizeSet = true
+	p.setMaxFileSize(size)
+}
+
+// InheritMaxFileSize is like SetMaxFileSize, unless SetMaxFileSize was called.
+func (p *FSProvider) InheritMaxFileSize(size int64) {
+	if !p.maxFileSizeSet {
+		p.setMaxFileSize(size)
+	}
+}
+
+func (p *FSProvider) setMaxFileSize(size int64) {
+	if sfs, ok := p.fs.(*sysfs.SysFS); ok {
+		sfs.MaxFileSize = size
+	}
+}
+
 // OpenFile opens the named file using the underlying fs.FS implementation.
 func (p *FSProvider) OpenFile(name string) (fs.File, error) {
 	return p.fs.Open(name)
@@ -40,3 +106,122 @@ func (p *FSProvider) OpenFile(name string) (fs.File, error) {
 func (p *FSProvider) ReadFile(name string) ([]byte, error) {
 	return fs.ReadFile(p.fs, name)
 }
+
+// modTimeResolution is the coarsest resolution of modification times among common file systems
+// (FAT). Files rewritten within it may keep their modification time.
+const modTimeResolution = 2 * time.Second
+
+// fileSignature identifies a version of a file.
+type fileSignature struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+	// hash is the SHA-256 hash of the contents of the file if hashed is true, which is only
+	// computed to tell apart versions with the same size and modification time, at hashedAt.
+	hash     [sha256.Size]byte
+	hashed   bool
+	hashedAt time.Time
+}
+
+// sameStat reports whether s and other have the same size and modification time.
+func (s fileSignature) sameStat(other fileSignature) bool {
+	return s.exists == other.exists && s.size == other.size && s.modTime.Equal(other.modTime)
+}
+
+// settled reports whether the hash of s was computed once the resolution of modification times
+// passed, after which the contents can't change without the modification time changing.
+func (s fileSignature) settled() bool {
+	return s.hashed && s.hashedAt.Sub(s.modTime) >= modTimeResolution
+}
+
+// stat returns the signature of the named file.
+func (p *FSProvider) stat(name string) fileSignature {
+	info, err := fs.Stat(p.fs, name)
+	if err != nil {
+		return fileSignature{}
+	}
+
+	return fileSignature{
+		exists:  true,
+		size:    info.Size(),
+		modTime: info.ModTime(),
+	}
+}
+
+// hash sets the hash of the contents of the named file in sig, unless the file can't be read.
+func (p *FSProvider) hash(name string, sig *fileSignature) {
+	data, err := fs.ReadFile(p.fs, name)
+	if err != nil {
+		return
+	}
+
+	sig.hash, sig.hashed, sig.hashedAt = sha256.Sum256(data), true, time.Now()
+}
+
+// WatchFile polls the named file every interval and sends on the returned channel when it changes.
+//
+// The file is looked up by name on every poll, so replacing it with an atomic rename is detected.
+// Changes are detected by the size and modification time of the file. As a file rewritten within
+// the resolution of modification times may keep both, its contents are hashed as well until a hash
+// is computed once that resolution passed; the contents of files left untouched are not read again.
+// Changes are debounced: a notification is sent once the file has been stable for a full interval,
+// and never while the file is missing (e.g., in the middle of a rename).
+// The channel is closed once ctx is done.
+func (p *FSProvider) WatchFile(ctx context.Context, name string, interval time.Duration) <-chan struct{} {
+	out := make(chan struct{})
+	current := p.stat(name)
+
+	go func() {
+		defer close(out)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		pending := false
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			sig := p.stat(name)
+			changed := !sig.sameStat(current)
+
+			if !changed && sig.exists {
+				if current.settled() {
+					sig = current
+				} else {
+					p.hash(name, &sig)
+
+					// the contents of a new version are hashed once it is stable
+					changed = current.hashed && sig.hashed && sig.hash != current.hash
+				}
+			}
+
+			current = sig
+
+			if changed {
+				// Wait for the file to settle before notifying.
+				pending = true
+
+				continue
+			}
+
+			if !pending || !sig.exists {
+				continue
+			}
+
+			pending = false
+
+			select {
+			case out <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
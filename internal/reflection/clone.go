@@ -25,7 +25,7 @@ func Clone[T any](v T) T {
 	case reflect.Slice:
 		newSlice := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Cap())
 		for i := range rv.Len() {
-			newSlice.Index(i).Set(reflect.ValueOf(Clone(rv.Index(i).Interface())))
+			newSlice.Index(i).Set(cloneValue(rv.Index(i)))
 		}
 
 		return newSlice.Interface().(T)
@@ -33,10 +33,7 @@ func Clone[T any](v T) T {
 	case reflect.Map:
 		newMap := reflect.MakeMapWithSize(rv.Type(), rv.Len())
 		for _, key := range rv.MapKeys() {
-			newMap.SetMapIndex(
-				reflect.ValueOf(Clone(key.Interface())),
-				reflect.ValueOf(Clone(rv.MapIndex(key).Interface())),
-			)
+			newMap.SetMapIndex(cloneValue(key), cloneValue(rv.MapIndex(key)))
 		}
 
 		return newMap.Interface().(T)
@@ -45,3 +42,14 @@ func Clone[T any](v T) T {
 		return v
 	}
 }
+
+// cloneValue returns a deep copy of v, keeping nil values (e.g., nil map entries) as zero values
+// of the type of v rather than dropping them.
+func cloneValue(v reflect.Value) reflect.Value {
+	cloned := Clone(v.Interface())
+	if cloned == nil {
+		return reflect.Zero(v.Type())
+	}
+
+	return reflect.ValueOf(cloned)
+}
@@ -17,27 +17,43 @@ var (
 	ErrConfigFileTooLarge = errors.New("config file too large")
 )
 
-const maxConfigFileSize = 1 << 20 // 1 MB
+// DefaultMaxFileSize is the size limit of the opened files unless set in Options.
+const DefaultMaxFileSize = 1 << 20 // 1 MB
 
-// SafeOpen ensures the file path is safe and opens the file.
+// Options configures the checks of SafeOpenWith.
+type Options struct {
+	// BaseDir is the directory files must be within; relative paths are resolved against it.
+	// Defaults to the current working directory.
+	BaseDir string
+
+	// AllowSymlinks allows opening symlinks, as long as their target is within BaseDir too.
+	AllowSymlinks bool
+
+	// MaxFileSize is the size limit of the opened files in bytes; zero is DefaultMaxFileSize and a
+	// negative size disables the limit.
+	MaxFileSize int64
+}
+
+// SafeOpen ensures the file path is safe and opens the file, using the default Options.
 func SafeOpen(filePath string) (*os.File, error) {
-	// Get current working directory as baseDir
-	baseDir, err := os.Getwd()
+	return SafeOpenWith(filePath, Options{})
+}
+
+// SafeOpenWith ensures the file path is safe according to opts and opens the file.
+func SafeOpenWith(filePath string, opts Options) (*os.File, error) {
+	baseDir, err := resolveBaseDir(opts.BaseDir)
 	if err != nil {
 		return nil, err
 	}
 
-	// Clean the input path
-	cleanPath := filepath.Clean(filePath)
-
-	// Make it absolute
-	absPath, err := filepath.Abs(cleanPath)
-	if err != nil {
-		return nil, err
+	// Clean the input path and make it absolute
+	absPath := filepath.Clean(filePath)
+	if !filepath.IsAbs(absPath) {
+		absPath = filepath.Join(baseDir, absPath)
 	}
 
 	// Ensure the absolute path is within the baseDir
-	if !strings.HasPrefix(absPath, baseDir+string(os.PathSeparator)) && absPath != baseDir {
+	if !isWithin(baseDir, absPath) {
 		return nil, ErrUnsafeFilePathOutsideDirectory
 	}
 
@@ -48,14 +64,65 @@ func SafeOpen(filePath string) (*os.File, error) {
 	}
 
 	if info.Mode()&fs.ModeSymlink != 0 {
-		return nil, ErrUnsafeFilePathSymlink
+		if !opts.AllowSymlinks {
+			return nil, ErrUnsafeFilePathSymlink
+		}
+
+		// Ensure the target is within the baseDir too
+		if absPath, info, err = resolveSymlink(baseDir, absPath); err != nil {
+			return nil, err
+		}
 	}
 
 	// Enforce size limit
-	if info.Size() > maxConfigFileSize {
+	maxSize := opts.MaxFileSize
+	if maxSize == 0 {
+		maxSize = DefaultMaxFileSize
+	}
+
+	if maxSize > 0 && info.Size() > maxSize {
 		return nil, ErrConfigFileTooLarge
 	}
 
 	//nolint:gosec
 	return os.Open(absPath)
 }
+
+// resolveBaseDir returns the absolute path of baseDir, or the current working directory if empty.
+func resolveBaseDir(baseDir string) (string, error) {
+	if baseDir == "" {
+		return os.Getwd()
+	}
+
+	return filepath.Abs(baseDir)
+}
+
+// isWithin reports whether the absolute path is dir or within it.
+func isWithin(dir, path string) bool {
+	return path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, string(os.PathSeparator))+string(os.PathSeparator))
+}
+
+// resolveSymlink returns the target of the symlink at path and its info, ensuring it is within
+// baseDir, both with their symlinks evaluated.
+func resolveSymlink(baseDir, path string) (string, fs.FileInfo, error) {
+	realBaseDir, err := filepath.EvalSymlinks(baseDir)
+	if err != nil {
+		return "", nil, err
+	}
+
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if !isWithin(realBaseDir, target) {
+		return "", nil, ErrUnsafeFilePathOutsideDirectory
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return target, info, nil
+}
@@ -4,7 +4,10 @@ package sysfs
 import "io/fs"
 
 // SysFS implements the fs.FS interface and provides safe file system operations.
-type SysFS struct{}
+type SysFS struct {
+	// Options configures the checks of the opened files.
+	Options
+}
 
 var _ fs.FS = (*SysFS)(nil)
 
@@ -16,5 +19,5 @@ func NewSysFS() *SysFS {
 // Open safely opens the file at the given name using path validation.
 // It implements the fs.FS interface Open method.
 func (s SysFS) Open(name string) (fs.File, error) {
-	return SafeOpen(name)
+	return SafeOpenWith(name, s.Options)
 }
@@ -0,0 +1,366 @@
+package gcfg
+
+import (
+	"errors"
+	"fmt"
+	stdmaps "maps"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/ahmedkamalio/gcfg/internal/reflection"
+)
+
+var (
+	// ErrUnresolvedReference indicates that a ${...} reference doesn't resolve to a value.
+	ErrUnresolvedReference = errors.New("unresolved reference")
+
+	// ErrReferenceCycle indicates that ${...} references refer to each other in a cycle.
+	ErrReferenceCycle = errors.New("reference cycle")
+
+	// ErrUnterminatedReference indicates that a ${ isn't closed by a }.
+	ErrUnterminatedReference = errors.New("unterminated reference")
+
+	// ErrFileReference indicates that the file of a ${file:path} reference can't be read.
+	ErrFileReference = errors.New("failed to read referenced file")
+)
+
+// WithInterpolation enables references to other configuration values inside string values, e.g.
+//
+//	{"url": "postgres://${database.user}@${database.host}:${database.port}/app"}
+//
+// References are resolved once the defaults, the values of all providers and the overrides are
+// merged, so they see the effective configuration, and may themselves contain references. A value consisting of a single reference takes the value of the
+// referenced key as-is, e.g. an int or a map, while references embedded in a longer string are
+// formatted with fmt. "$${" stands for a literal "${".
+//
+// A Load fails with ErrUnresolvedReference if a referenced key is absent, and with
+// ErrReferenceCycle if references refer to each other, directly or through sections, naming the
+// chain of keys (e.g. "reference cycle a -> b -> a"). Values set with Set are taken literally,
+// also across loads, though they can be referenced. So are the values of the environment
+// variables provider registered by default (see New), which loads unrelated variables too.
+func WithInterpolation() Option {
+	return func(c *Config) {
+		c.interpolation = true
+	}
+}
+
+// referenceLookup returns the value of the reference ref, the text between "${" and "}". If ok is
+// false, the reference is kept as-is.
+type referenceLookup func(ref string) (value any, ok bool, err error)
+
+// interpolateValues resolves the references to other keys in the string values of values, in
+// place, except in the values of the literal keys. The caller must hold c.mu.
+func (c *Config) interpolateValues(values map[string]any, literal map[string]bool) error {
+	in := &interpolator{keys: c.keys, values: values, literal: literal}
+
+	var errs []error
+
+	walkStrings(values, "", c.keys.sep(), in.resolve, &errs)
+
+	return errors.Join(errs...)
+}
+
+// interpolator resolves references to the keys of values.
+type interpolator struct {
+	keys   keyFormat
+	values map[string]any
+	// literal holds the normalized keys whose values are taken literally.
+	literal map[string]bool
+	// resolving holds the keys whose references are being resolved, to detect cycles.
+	resolving []string
+}
+
+// walkStrings replaces the strings nested in v, found at key, with their values given by fn, in
+// place. The errors of fn are appended to errs, prefixed with the key of their string. Map keys are
+// walked in sorted order, so the errors are reported deterministically.
+func walkStrings(v any, key, sep string, fn func(key, str string) (any, error), errs *[]error) {
+	replace := func(item any, itemKey string) (any, bool) {
+		str, ok := item.(string)
+		if !ok {
+			walkStrings(item, itemKey, sep, fn, errs)
+
+			return nil, false
+		}
+
+		replaced, err := fn(itemKey, str)
+		if err != nil {
+			*errs = append(*errs, fmt.Errorf("key %s: %w", itemKey, err))
+
+			return nil, false
+		}
+
+		return replaced, true
+	}
+
+	child := func(k string) string {
+		return maps.JoinPath(key, k, sep)
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		for _, k := range slices.Sorted(stdmaps.Keys(v)) {
+			if replaced, ok := replace(v[k], child(k)); ok {
+				v[k] = replaced
+			}
+		}
+	case []any:
+		for i, item := range v {
+			if replaced, ok := replace(item, child(strconv.Itoa(i))); ok {
+				v[i] = replaced
+			}
+		}
+	}
+}
+
+// resolve resolves the references in str, the value of key.
+func (in *interpolator) resolve(key, str string) (any, error) {
+	if !strings.Contains(str, "${") || in.literal[in.keys.normalize(key)] {
+		return str, nil
+	}
+
+	if i := slices.Index(in.resolving, key); i >= 0 {
+		cycle := append(slices.Clone(in.resolving[i:]), key)
+
+		return nil, fmt.Errorf("%w %s", ErrReferenceCycle, strings.Join(cycle, " -> "))
+	}
+
+	in.resolving = append(in.resolving, key)
+	defer func() { in.resolving = in.resolving[:len(in.resolving)-1] }()
+
+	return expandReferences(str, in.lookup, true)
+}
+
+// lookup returns the resolved value of the referenced key.
+func (in *interpolator) lookup(ref string) (any, bool, error) {
+	value, ok := in.keys.find(in.values, ref)
+	if !ok {
+		return nil, false, fmt.Errorf("%w ${%s}", ErrUnresolvedReference, ref)
+	}
+
+	if str, ok := value.(string); ok {
+		resolved, err := in.resolve(in.keys.normalize(ref), str)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return resolved, true, nil
+	}
+
+	// the references nested in a section are resolved in a copy, so that cycles through sections
+	// (e.g. a: "${b}", b: {c: "${a}"}) are detected too
+	section := reflection.Clone(value)
+
+	var errs []error
+
+	// the errors aren't prefixed with the nested keys, which their reference chain names already
+	walkStrings(section, in.keys.normalize(ref), in.keys.sep(), func(key, str string) (any, error) {
+		resolved, err := in.resolve(key, str)
+		if err != nil {
+			errs = append(errs, err)
+
+			return str, nil
+		}
+
+		return resolved, nil
+	}, &errs)
+
+	if len(errs) > 0 {
+		return nil, false, errors.Join(errs...)
+	}
+
+	return section, true, nil
+}
+
+// expandReferences replaces the ${...} references in str with their values given by lookup,
+// keeping the references lookup doesn't resolve as-is. If str consists of a single resolved
+// reference, its value is returned as-is rather than formatted. Escaped references ("$${") are
+// kept, and unescaped to "${" if unescape is true.
+func expandReferences(str string, lookup referenceLookup, unescape bool) (any, error) {
+	var sb strings.Builder
+
+	for {
+		i := strings.Index(str, "${")
+		if i < 0 {
+			break
+		}
+
+		// escaped "$${"
+		if i > 0 && str[i-1] == '$' {
+			sb.WriteString(str[:i+2])
+			str = str[i+2:]
+
+			continue
+		}
+
+		end := strings.IndexByte(str[i:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("%w in %q", ErrUnterminatedReference, str)
+		}
+
+		ref := str[i+2 : i+end]
+		prefix := str[:i]
+		str = str[i+end+1:]
+
+		value, ok, err := lookup(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			sb.WriteString(prefix + "${" + ref + "}")
+
+			continue
+		}
+
+		if prefix == "" && str == "" && sb.Len() == 0 {
+			return value, nil
+		}
+
+		sb.WriteString(prefix + fmt.Sprint(value))
+	}
+
+	sb.WriteString(str)
+
+	if unescape {
+		return strings.ReplaceAll(sb.String(), "$${", "${"), nil
+	}
+
+	return sb.String(), nil
+}
+
+// WithEnvExpansion enables the expansion of environment variables inside the string values loaded
+// by providers, so file-based configurations can splice in environment-specific pieces:
+//
+//	{"log_dir": "${LOG_DIR:-/var/log/app}", "url": "https://${API_HOST}/v1"}
+//
+// ${NAME:-fallback} expands to fallback if NAME is unset or empty, and ${NAME-fallback} only if it
+// is unset. Unset variables without a fallback expand to an empty string, unless WithInterpolation
+// is given too, in which case ${name} is resolved as a reference to another key. "$${" stands for a
+// literal "${". Variables are expanded on every load, before the values of providers are merged.
+// The values of the environment variables provider registered by default (see New) aren't expanded.
+func WithEnvExpansion() Option {
+	return func(c *Config) {
+		c.envExpansion = true
+	}
+}
+
+// envReference matches the ${...} references to environment variables: a variable name, optionally
+// followed by ":-" or "-" and a fallback.
+var envReference = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)(?:(:?-)(.*))?$`)
+
+// WithFileReferences enables references to files inside the string values loaded by providers,
+// which is how many orchestrators deliver secrets:
+//
+//	{"database": {"password": "${file:/run/secrets/db_password}"}}
+//
+// ${file:path} expands to the contents of the file at path, with the leading and trailing white
+// space trimmed. Files are read on every load, before the values of providers are merged, and a
+// Load fails with ErrFileReference if one can't be read. Files are read with the restrictions of
+// the file providers: they must be within the base directory (see WithBaseDir), not symlinks
+// (see WithAllowSymlinks) and within the size limit (see WithMaxFileSize), so a provider value
+// can't pull arbitrary files into the configuration. Secrets mounted outside of the working
+// directory, often as symlinks (e.g. Kubernetes Secret volumes), need their own restrictions
+// (see WithFileReferencesBaseDir and WithFileReferencesAllowSymlinks), which leave the file
+// providers unaffected:
+//
+//	cfg := gcfg.New(
+//		gcfg.WithFileReferences(),
+//		gcfg.WithFileReferencesBaseDir("/run/secrets"),
+//		gcfg.WithFileReferencesAllowSymlinks(true),
+//	)
+//
+// The values of the environment variables provider registered by default (see New) aren't
+// expanded. Consider marking the keys holding secrets with WithSecret so their values are masked.
+func WithFileReferences() Option {
+	return func(c *Config) {
+		c.fileReferences = true
+	}
+}
+
+// WithFileReferencesBaseDir sets the directory the files referenced by values must be within (see
+// WithFileReferences), e.g. "/run/secrets". Relative paths are resolved against it.
+//
+// Default: the base directory set with WithBaseDir, or the working directory.
+func WithFileReferencesBaseDir(dir string) Option {
+	return func(c *Config) {
+		c.fileReferencesBaseDir = dir
+	}
+}
+
+// WithFileReferencesAllowSymlinks sets whether the files referenced by values may be symlinks (see
+// WithFileReferences). The target of a symlink must be within the base directory too (see
+// WithFileReferencesBaseDir).
+//
+// Default: the setting of WithAllowSymlinks, or false.
+func WithFileReferencesAllowSymlinks(allow bool) Option {
+	return func(c *Config) {
+		c.fileReferencesAllowSymlinks = &allow
+	}
+}
+
+// fileReferencePrefix is the prefix of references to files, see WithFileReferences.
+const fileReferencePrefix = "file:"
+
+// expandProviderValues expands the references to environment variables and files in the string
+// values of values, loaded by a provider, in place. See WithEnvExpansion and WithFileReferences.
+func (c *Config) expandProviderValues(values map[string]any) error {
+	var errs []error
+
+	walkStrings(values, "", c.keys.sep(), func(_, str string) (any, error) {
+		if !strings.Contains(str, "${") {
+			return str, nil
+		}
+
+		expanded, err := expandReferences(str, c.lookupProviderReference, !c.interpolation)
+		if err != nil {
+			return nil, err
+		}
+
+		// keep the expanded values strings, even for a single reference
+		return fmt.Sprint(expanded), nil
+	}, &errs)
+
+	return errors.Join(errs...)
+}
+
+// lookupProviderReference returns the value of the reference ref to a file or an environment
+// variable.
+func (c *Config) lookupProviderReference(ref string) (any, bool, error) {
+	if path, ok := strings.CutPrefix(ref, fileReferencePrefix); ok && c.fileReferences {
+		data, err := c.readFile(path)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w ${%s}: %w", ErrFileReference, ref, err)
+		}
+
+		return strings.TrimSpace(string(data)), true, nil
+	}
+
+	if !c.envExpansion {
+		return nil, false, nil
+	}
+
+	m := envReference.FindStringSubmatch(ref)
+	if m == nil {
+		return nil, false, nil
+	}
+
+	name, op, fallback := m[1], m[2], m[3]
+
+	value, set := os.LookupEnv(name)
+
+	switch {
+	case set && (value != "" || op != ":-"):
+		return value, true, nil
+	case op != "":
+		return fallback, true, nil
+	case c.interpolation:
+		// possibly a reference to another key
+		return nil, false, nil
+	default:
+		return "", true, nil
+	}
+}
@@ -0,0 +1,312 @@
+package gcfg_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithInterpolation(t *testing.T) {
+	t.Parallel()
+
+	base := &mockProvider{name: "base", data: map[string]any{
+		"database": map[string]any{
+			"user": "app",
+			"host": "${hosts.primary}",
+			"port": 5432,
+			"url":  "postgres://${database.user}@${database.host}:${database.port}/app",
+		},
+		"hosts":   map[string]any{"primary": "db.internal"},
+		"port":    "${database.port}",
+		"servers": []any{"${hosts.primary}:80"},
+		"literal": "cost: $${price}",
+	}}
+	override := &mockProvider{name: "override", data: map[string]any{
+		"hosts": map[string]any{"primary": "db.prod"},
+	}}
+
+	cfg := gcfg.New(base, override, gcfg.WithoutDefaultEnv(), gcfg.WithInterpolation())
+	require.NoError(t, cfg.Load())
+
+	// references are resolved after merge
+	assert.Equal(t, "postgres://app@db.prod:5432/app", cfg.Get("database.url"))
+	assert.Equal(t, "db.prod", cfg.Get("database.host"))
+	// a single reference keeps the type of the referenced value
+	assert.Equal(t, 5432, cfg.Get("port"))
+	assert.Equal(t, "db.prod:80", cfg.Get("servers.0"))
+	assert.Equal(t, "cost: ${price}", cfg.Get("literal"))
+}
+
+func TestWithInterpolation_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		data    map[string]any
+		wantErr error
+		wantMsg string
+	}{
+		{
+			name:    "unresolved",
+			data:    map[string]any{"url": "http://${host}"},
+			wantErr: gcfg.ErrUnresolvedReference,
+			wantMsg: "key url: unresolved reference ${host}",
+		},
+		{
+			name:    "cycle",
+			data:    map[string]any{"a": "${b}", "b": "x${a}"},
+			wantErr: gcfg.ErrReferenceCycle,
+			wantMsg: "key a: reference cycle a -> b -> a\nkey b: reference cycle b -> a -> b",
+		},
+		{
+			name:    "self reference",
+			data:    map[string]any{"a": "${a}"},
+			wantErr: gcfg.ErrReferenceCycle,
+			wantMsg: "key a: reference cycle a -> a",
+		},
+		{
+			name: "cycle through section",
+			data: map[string]any{
+				"a": "${b}",
+				"b": map[string]any{"c": []any{"${a}"}},
+			},
+			wantErr: gcfg.ErrReferenceCycle,
+			wantMsg: "key a: reference cycle a -> b.c.0 -> a\nkey b.c.0: reference cycle b.c.0 -> a -> b.c.0",
+		},
+		{
+			name:    "unterminated",
+			data:    map[string]any{"a": "${b"},
+			wantErr: gcfg.ErrUnterminatedReference,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := gcfg.New(&mockProvider{name: "mock", data: tt.data},
+				gcfg.WithoutDefaultEnv(), gcfg.WithInterpolation())
+
+			err := cfg.Load()
+			require.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantMsg != "" {
+				assert.EqualError(t, err, tt.wantMsg)
+			}
+
+			assert.Nil(t, cfg.Get("a"))
+		})
+	}
+}
+
+func TestWithInterpolation_SetIsLiteral(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{"a": "A", "c": "${a}"}},
+		gcfg.WithoutDefaultEnv(), gcfg.WithInterpolation())
+	require.NoError(t, cfg.Load())
+
+	cfg.Set("b", "${a}")
+	assert.Equal(t, "${a}", cfg.Get("b"))
+
+	// values set with Set stay literal across loads
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, "${a}", cfg.Get("b"))
+	assert.Equal(t, "A", cfg.Get("c"))
+}
+
+func TestWithInterpolation_ReferencesSetValues(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{"url": "x://${db.host}"}},
+		gcfg.WithoutDefaultEnv(), gcfg.WithInterpolation())
+
+	cfg.Set("db.host", "a")
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, "x://a", cfg.Get("url"))
+
+	cfg.Set("other", "y://${db.host}")
+	cfg.Set("db.host", "b")
+	require.NoError(t, cfg.Load())
+
+	// references see the values set with Set, which are taken literally themselves
+	assert.Equal(t, "x://b", cfg.Get("url"))
+	assert.Equal(t, "y://${db.host}", cfg.Get("other"))
+}
+
+func TestInterpolation_Disabled(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{"url": "http://${host}"}},
+		gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "http://${host}", cfg.Get("url"))
+}
+
+func TestWithEnvExpansion(t *testing.T) {
+	t.Setenv("GCFG_TEST_HOST", "api.example.com")
+	t.Setenv("GCFG_TEST_EMPTY", "")
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"url":      "https://${GCFG_TEST_HOST}/v1",
+		"log_dir":  "${GCFG_TEST_LOG_DIR:-/var/log/app}",
+		"empty":    "${GCFG_TEST_EMPTY:-fallback}",
+		"set":      "${GCFG_TEST_EMPTY-fallback}",
+		"unset":    "[${GCFG_TEST_UNSET}]",
+		"literal":  "$${GCFG_TEST_HOST}",
+		"hosts":    []any{"${GCFG_TEST_HOST}"},
+		"database": map[string]any{"port": 5432},
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithoutDefaultEnv(), gcfg.WithEnvExpansion())
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "https://api.example.com/v1", cfg.Get("url"))
+	assert.Equal(t, "/var/log/app", cfg.Get("log_dir"))
+	assert.Equal(t, "fallback", cfg.Get("empty"))
+	assert.Empty(t, cfg.Get("set"))
+	assert.Equal(t, "[]", cfg.Get("unset"))
+	assert.Equal(t, "${GCFG_TEST_HOST}", cfg.Get("literal"))
+	assert.Equal(t, "api.example.com", cfg.Get("hosts.0"))
+
+	// the variables are expanded again on every load
+	t.Setenv("GCFG_TEST_HOST", "api2.example.com")
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, "https://api2.example.com/v1", cfg.Get("url"))
+}
+
+func TestWithEnvExpansion_Interpolation(t *testing.T) {
+	t.Setenv("GCFG_TEST_USER", "app")
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{
+			"host": "db.internal",
+			"url":  "postgres://${GCFG_TEST_USER}@${database.host}/${GCFG_TEST_DB:-app}",
+		},
+		"literal": "$${database.host}",
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithoutDefaultEnv(), gcfg.WithEnvExpansion(), gcfg.WithInterpolation())
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "postgres://app@db.internal/app", cfg.Get("database.url"))
+	assert.Equal(t, "${database.host}", cfg.Get("literal"))
+}
+
+func TestWithEnvExpansion_DefaultEnvProvider(t *testing.T) {
+	// unrelated variables which aren't valid references
+	t.Setenv("GCFG_TEST_PROMPT", "${debian_chroot:+($debian_chroot)}$ ")
+	t.Setenv("GCFG_TEST_BROKEN", "${unterminated")
+	t.Setenv("GCFG_TEST_UNKNOWN", "${gcfg_test_missing}")
+	t.Setenv("GCFG_TEST_HOST", "api.example.com")
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"url":    "https://${GCFG_TEST_HOST}/v1",
+		"prompt": "${gcfg_test_prompt}",
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithEnvExpansion(), gcfg.WithFileReferences(), gcfg.WithInterpolation())
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "https://api.example.com/v1", cfg.Get("url"))
+	// the values of the default environment variables provider are taken literally
+	assert.Equal(t, "${unterminated", cfg.Get("gcfg_test_broken"))
+	assert.Equal(t, "${gcfg_test_missing}", cfg.Get("gcfg_test_unknown"))
+	assert.Equal(t, "${debian_chroot:+($debian_chroot)}$ ", cfg.Get("prompt"))
+}
+
+func TestWithFileReferences(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	secret := filepath.Join(dir, "db_password")
+	require.NoError(t, os.WriteFile(secret, []byte("s3cr3t\n"), 0o600))
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{
+			"password": "${file:" + secret + "}",
+			"dsn":      "postgres://app:${file:" + secret + "}@db/app",
+		},
+		"env": "${HOME}",
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithoutDefaultEnv(), gcfg.WithFileReferences(), gcfg.WithBaseDir(dir))
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "s3cr3t", cfg.Get("database.password"))
+	assert.Equal(t, "postgres://app:s3cr3t@db/app", cfg.Get("database.dsn"))
+	// other references are kept as-is
+	assert.Equal(t, "${HOME}", cfg.Get("env"))
+
+	// files are read on every load
+	require.NoError(t, os.WriteFile(secret, []byte("rotated"), 0o600))
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, "rotated", cfg.Get("database.password"))
+
+	require.NoError(t, os.Remove(secret))
+	require.ErrorIs(t, cfg.Load(), gcfg.ErrFileReference)
+	assert.Equal(t, "rotated", cfg.Get("database.password"))
+}
+
+func TestWithFileReferences_Sandbox(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "large"), []byte(strings.Repeat("x", 64)), 0o600))
+	require.NoError(t, os.Symlink("/etc/passwd", filepath.Join(dir, "link")))
+
+	for _, ref := range []string{"/etc/passwd", "../../etc/passwd", "link", "large"} {
+		provider := &mockProvider{name: "mock", data: map[string]any{"value": "${file:" + ref + "}"}}
+
+		cfg := gcfg.New(provider, gcfg.WithoutDefaultEnv(), gcfg.WithFileReferences(),
+			gcfg.WithBaseDir(dir), gcfg.WithMaxFileSize(32))
+
+		require.ErrorIs(t, cfg.Load(), gcfg.ErrFileReference, ref)
+		assert.Nil(t, cfg.Get("value"), ref)
+	}
+}
+
+func TestWithFileReferences_OwnRestrictions(t *testing.T) {
+	t.Parallel()
+
+	// secrets mounted like Kubernetes Secret volumes: symlinks to the files of a hidden directory
+	secrets := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(secrets, "..2024_05_01"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(secrets, "..2024_05_01", "db_password"), []byte("s3cr3t"), 0o600))
+	require.NoError(t, os.Symlink("..2024_05_01", filepath.Join(secrets, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "db_password"), filepath.Join(secrets, "db_password")))
+
+	configDir := t.TempDir()
+	configPath := filepath.Join(configDir, "config.json")
+	reference := `{"password": "${file:` + filepath.Join(secrets, "db_password") + `}"}`
+	require.NoError(t, os.WriteFile(configPath, []byte(reference), 0o600))
+	require.NoError(t, os.Symlink(configPath, filepath.Join(configDir, "link.json")))
+
+	newConfig := func(path string, opts ...gcfg.Provider) *gcfg.Config {
+		provider := gcfg.NewJSONProvider(gcfg.WithJSONFilePath(path))
+
+		return gcfg.New(append([]gcfg.Provider{
+			provider, gcfg.WithoutDefaultEnv(), gcfg.WithFileReferences(), gcfg.WithBaseDir(configDir),
+		}, opts...)...)
+	}
+
+	// the symlinks are rejected by default
+	require.ErrorIs(t, newConfig("config.json").Load(), gcfg.ErrFileReference)
+
+	cfg := newConfig("config.json",
+		gcfg.WithFileReferencesBaseDir(secrets), gcfg.WithFileReferencesAllowSymlinks(true))
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, "s3cr3t", cfg.Get("password"))
+
+	// the file providers keep the restrictions of the Config
+	err := newConfig("link.json",
+		gcfg.WithFileReferencesBaseDir(secrets), gcfg.WithFileReferencesAllowSymlinks(true)).Load()
+	require.Error(t, err)
+	require.NotErrorIs(t, err, gcfg.ErrFileReference)
+}
@@ -1,10 +1,14 @@
 package gcfg
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
+	"time"
 
 	"github.com/ahmedkamalio/gcfg/internal/providers"
 )
@@ -26,10 +30,21 @@ const (
 type JSONProvider struct {
 	*providers.FSProvider
 
-	filePath string
+	filePath  string
+	useNumber bool
+	template  bool
+	decrypt   DecryptFunc
+	signature *fileSignature
+
+	watch         bool
+	watchInterval time.Duration
 }
 
-var _ Provider = (*JSONProvider)(nil)
+var (
+	_ WatchableProvider   = (*JSONProvider)(nil)
+	_ SourceProvider      = (*JSONProvider)(nil)
+	_ ValidatableProvider = (*JSONProvider)(nil)
+)
 
 // JSONOption is a function that configures a JSONProvider.
 type JSONOption func(*JSONProvider)
@@ -41,6 +56,39 @@ func WithJSONFilePath(filePath string) JSONOption {
 	}
 }
 
+// WithJSONBaseDir sets the directory the file must be within, relative paths being resolved
+// against it, e.g. "/etc/myapp". It applies to the default sysfs.SysFS only, not to an fs set with
+// WithJSONFileFS.
+//
+// Default: the base directory set with WithBaseDir, or the working directory.
+func WithJSONBaseDir(dir string) JSONOption {
+	return func(p *JSONProvider) {
+		p.SetBaseDir(dir)
+	}
+}
+
+// WithJSONAllowSymlinks sets whether the file may be a symlink, whose target must be within the
+// base directory too. It applies to the default sysfs.SysFS only, not to an fs set with
+// WithJSONFileFS.
+//
+// Default: the setting of WithAllowSymlinks, or false.
+func WithJSONAllowSymlinks(allow bool) JSONOption {
+	return func(p *JSONProvider) {
+		p.SetAllowSymlinks(allow)
+	}
+}
+
+// WithJSONMaxFileSize sets the size limit of the file in bytes, failing to load larger files. A
+// negative size disables the limit. It applies to the default sysfs.SysFS only, not to an fs set
+// with WithJSONFileFS.
+//
+// Default: the limit set with WithMaxFileSize, or 1 MB.
+func WithJSONMaxFileSize(size int64) JSONOption {
+	return func(p *JSONProvider) {
+		p.SetMaxFileSize(size)
+	}
+}
+
 // WithJSONFileFS sets the fs of which to read the JSON file from.
 //
 // Default: sysfs.SysFS.
@@ -50,6 +98,75 @@ func WithJSONFileFS(fs fs.FS) JSONOption {
 	}
 }
 
+// WithJSONWatch sets the flag to watch the JSON file for changes, see Config.Watch.
+// The file is checked periodically (see WithJSONWatchInterval), replacing it with an atomic
+// rename is supported, and bursts of writes are debounced into a single reload.
+//
+// Default: false.
+func WithJSONWatch(watch bool) JSONOption {
+	return func(p *JSONProvider) {
+		p.watch = watch
+	}
+}
+
+// WithJSONWatchInterval sets the interval at which the watched JSON file is checked for changes.
+//
+// Default: 1s.
+func WithJSONWatchInterval(interval time.Duration) JSONOption {
+	return func(p *JSONProvider) {
+		p.watchInterval = interval
+	}
+}
+
+// WithJSONUseNumber sets the flag to decode JSON numbers as json.Number rather than float64, so
+// large integers (e.g., IDs beyond 2^53) keep their precision when bound to integer fields.
+//
+// Default: false.
+func WithJSONUseNumber(useNumber bool) JSONOption {
+	return func(p *JSONProvider) {
+		p.useNumber = useNumber
+	}
+}
+
+// WithJSONTemplate sets the flag to render the JSON file as a text/template before decoding it,
+// for Helm-style templated configurations:
+//
+//	{"port": {{ env "PORT" | default "8080" }}, "token": "{{ env "TOKEN" | required "TOKEN is not set" }}"}
+//
+// The functions env, default, required and b64dec are available. The rendered values are inserted
+// verbatim, so string values must be quoted in the template.
+//
+// Default: false.
+func WithJSONTemplate(enabled bool) JSONOption {
+	return func(p *JSONProvider) {
+		p.template = enabled
+	}
+}
+
+// WithJSONDecrypt sets the function decrypting the JSON file in memory before decoding it, so
+// encrypted files can be loaded without being written to disk in plaintext, e.g. with
+// AgeIdentityFile for age-encrypted files, see DecryptFunc. The file is decrypted before being
+// rendered as a template, see WithJSONTemplate.
+//
+// Default: nil, the file is not encrypted.
+func WithJSONDecrypt(decrypt DecryptFunc) JSONOption {
+	return func(p *JSONProvider) {
+		p.decrypt = decrypt
+	}
+}
+
+// WithJSONSignature sets the verifier of the detached signature of the JSON file, so tampered
+// files are rejected before being decoded. The signature is read from sigPath, or from the path of
+// the file followed by ".sig" if sigPath is empty, and verified against the file as stored, before
+// it is decrypted or rendered. See Ed25519Verifier and MinisignVerifier.
+//
+// Default: nil, the signature is not verified.
+func WithJSONSignature(sigPath string, verify SignatureVerifier) JSONOption {
+	return func(p *JSONProvider) {
+		p.signature = &fileSignature{path: sigPath, verify: verify}
+	}
+}
+
 // NewJSONProvider creates a new file provider.
 func NewJSONProvider(opts ...JSONOption) *JSONProvider {
 	pvd := &JSONProvider{
@@ -65,6 +182,59 @@ func NewJSONProvider(opts ...JSONOption) *JSONProvider {
 
 // Load implements the Provider interface.
 func (p *JSONProvider) Load() (map[string]any, error) {
+	file, err := p.readDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(file))
+	if p.useNumber {
+		decoder.UseNumber()
+	}
+
+	var data map[string]any
+	if err = decoder.Decode(&data); err != nil {
+		return nil, fmt.Errorf("%w from %s: %w", ErrJSONDecodeFailed, p.filePath, err)
+	}
+
+	// like json.Unmarshal, reject anything but white space after the top-level value
+	if _, err = decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w from %s: unexpected data after top-level value", ErrJSONDecodeFailed, p.filePath)
+	}
+
+	return data, nil
+}
+
+// loadOrdered implements the orderedLoader interface.
+func (p *JSONProvider) loadOrdered() (orderedMap, error) {
+	file, err := p.readDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(file))
+	decoder.UseNumber()
+
+	value, err := decodeOrderedJSON(decoder, p.useNumber)
+	if err != nil {
+		return nil, fmt.Errorf("%w from %s: %w", ErrJSONDecodeFailed, p.filePath, err)
+	}
+
+	data, ok := value.(orderedMap)
+	if !ok && value != nil {
+		return nil, fmt.Errorf("%w from %s: top-level value is not an object", ErrJSONDecodeFailed, p.filePath)
+	}
+
+	if _, err = decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w from %s: unexpected data after top-level value", ErrJSONDecodeFailed, p.filePath)
+	}
+
+	return data, nil
+}
+
+// readDocument reads the JSON file, verifying its signature, decrypting it and rendering it as a
+// template as configured.
+func (p *JSONProvider) readDocument() ([]byte, error) {
 	if p.filePath == "" {
 		return nil, ErrJSONFilePathNotSet
 	}
@@ -74,12 +244,109 @@ func (p *JSONProvider) Load() (map[string]any, error) {
 		return nil, fmt.Errorf("%w %s: %w", ErrJSONFileReadFailed, p.filePath, err)
 	}
 
-	var data map[string]any
-	if err = json.Unmarshal(file, &data); err != nil {
-		return nil, fmt.Errorf("%w from %s: %w", ErrJSONDecodeFailed, p.filePath, err)
+	if err = verifyFileSignature(p.FSProvider, p.filePath, file, p.signature); err != nil {
+		return nil, err
 	}
 
-	return data, nil
+	if file, err = decryptFile(p.filePath, file, p.decrypt); err != nil {
+		return nil, err
+	}
+
+	if p.template {
+		if file, err = renderFileTemplate(p.filePath, file); err != nil {
+			return nil, err
+		}
+	}
+
+	return file, nil
+}
+
+// decodeOrderedJSON decodes the next JSON value of decoder, which must decode numbers as
+// json.Number, like json.Decoder.Decode but with the order of the keys of objects preserved.
+// Numbers are kept as json.Number if useNumber is true, and converted to float64 otherwise.
+func decodeOrderedJSON(decoder *json.Decoder, useNumber bool) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		if token == '[' {
+			list := []any{}
+
+			for decoder.More() {
+				item, iErr := decodeOrderedJSON(decoder, useNumber)
+				if iErr != nil {
+					return nil, iErr
+				}
+
+				list = append(list, item)
+			}
+
+			_, err = decoder.Token()
+
+			return list, err
+		}
+
+		object := orderedMap{}
+
+		for decoder.More() {
+			key, kErr := decoder.Token()
+			if kErr != nil {
+				return nil, kErr
+			}
+
+			value, vErr := decodeOrderedJSON(decoder, useNumber)
+			if vErr != nil {
+				return nil, vErr
+			}
+
+			// the keys of objects are always strings
+			name, _ := key.(string)
+			object = object.with(name, value)
+		}
+
+		_, err = decoder.Token()
+
+		return object, err
+	case json.Number:
+		if useNumber {
+			return token, nil
+		}
+
+		return token.Float64()
+	default:
+		return token, nil
+	}
+}
+
+// Validate implements the ValidatableProvider interface.
+func (p *JSONProvider) Validate() error {
+	if p.filePath == "" {
+		return ErrJSONFilePathNotSet
+	}
+
+	return validateFileWatch(p.watch, p.watchInterval)
+}
+
+// Watch implements the WatchableProvider interface.
+// Returns ErrWatchNotSupported unless watching is enabled with WithJSONWatch.
+func (p *JSONProvider) Watch(ctx context.Context) (<-chan map[string]any, error) {
+	if !p.watch {
+		return nil, ErrWatchNotSupported
+	}
+
+	if p.filePath == "" {
+		return nil, ErrJSONFilePathNotSet
+	}
+
+	return watchFile(ctx, p.FSProvider, p.filePath, p.watchInterval, p.Load), nil
+}
+
+// Source implements the SourceProvider interface.
+func (p *JSONProvider) Source() string {
+	return p.filePath
 }
 
 // Name implements the Provider interface.
@@ -1,8 +1,18 @@
 package gcfg_test
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"testing/fstest"
+	"time"
 
 	"github.com/ahmedkamalio/gcfg"
 	"github.com/stretchr/testify/assert"
@@ -46,3 +56,402 @@ func TestJSONProvider_WithJSONFile(t *testing.T) {
 
 	assert.Equal(t, "test_value", values["testKey"])
 }
+
+func TestJSONProvider_TrailingData(t *testing.T) {
+	t.Parallel()
+
+	for _, data := range []string{`{"a": 1} {"b": 2} garbage`, `{"a": 1} {"b": 2}`, `{"a": 1} x`} {
+		for _, useNumber := range []bool{false, true} {
+			fsys := fstest.MapFS{"config.json": &fstest.MapFile{Data: []byte(data)}}
+
+			_, err := gcfg.NewJSONProvider(
+				gcfg.WithJSONFilePath("config.json"),
+				gcfg.WithJSONFileFS(&fsys),
+				gcfg.WithJSONUseNumber(useNumber),
+			).Load()
+			require.ErrorIs(t, err, gcfg.ErrJSONDecodeFailed, data)
+		}
+	}
+
+	// trailing white space is accepted
+	fsys := fstest.MapFS{"config.json": &fstest.MapFile{Data: []byte("{\"a\": 1}\n\n")}}
+	values, err := gcfg.NewJSONProvider(gcfg.WithJSONFilePath("config.json"), gcfg.WithJSONFileFS(&fsys)).Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": 1.0}, values)
+}
+
+func TestJSONProvider_WithJSONUseNumber(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"config.json": &fstest.MapFile{
+			Data: []byte(`{"account": {"id": 9007199254740993, "ratio": 0.5}}`),
+		},
+	}
+
+	cfg := gcfg.New(gcfg.NewJSONProvider(
+		gcfg.WithJSONFilePath("config.json"),
+		gcfg.WithJSONFileFS(&fsys),
+		gcfg.WithJSONUseNumber(true),
+	), gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, json.Number("9007199254740993"), cfg.Get("account.id"))
+
+	var obj struct {
+		Account struct {
+			ID    int64
+			Ratio float64
+		}
+	}
+
+	require.NoError(t, cfg.Bind(&obj))
+	assert.Equal(t, int64(9007199254740993), obj.Account.ID)
+	assert.InDelta(t, 0.5, obj.Account.Ratio, 0)
+}
+
+func TestJSONProvider_WithJSONTemplate(t *testing.T) {
+	t.Setenv("GCFG_TEMPLATE_HOST", "db.internal")
+	t.Setenv("GCFG_TEMPLATE_SECRET", "czNjcjN0")
+
+	fsys := fstest.MapFS{
+		"config.json": &fstest.MapFile{
+			Data: []byte(`{
+				"host": "{{ env "GCFG_TEMPLATE_HOST" | required "host is not set" }}",
+				"port": {{ env "GCFG_TEMPLATE_PORT" | default "5432" }},
+				"secret": "{{ env "GCFG_TEMPLATE_SECRET" | b64dec }}"
+			}`),
+		},
+	}
+
+	p := gcfg.NewJSONProvider(
+		gcfg.WithJSONFilePath("config.json"),
+		gcfg.WithJSONFileFS(&fsys),
+		gcfg.WithJSONTemplate(true),
+	)
+
+	values, err := p.Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"host": "db.internal", "port": 5432.0, "secret": "s3cr3t"}, values)
+
+	t.Setenv("GCFG_TEMPLATE_HOST", "")
+
+	_, err = p.Load()
+	require.ErrorIs(t, err, gcfg.ErrTemplateRenderFailed)
+	require.ErrorIs(t, err, gcfg.ErrRequiredTemplateValue)
+	assert.Contains(t, err.Error(), "host is not set")
+
+	// without the option, the file is decoded as-is
+	_, err = gcfg.NewJSONProvider(gcfg.WithJSONFilePath("config.json"), gcfg.WithJSONFileFS(&fsys)).Load()
+	require.ErrorIs(t, err, gcfg.ErrJSONDecodeFailed)
+}
+
+func TestJSONProvider_WithJSONDecrypt(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"config.json.b64": &fstest.MapFile{
+			Data: []byte(base64.StdEncoding.EncodeToString([]byte(`{"password": "s3cr3t"}`))),
+		},
+	}
+
+	p := gcfg.NewJSONProvider(
+		gcfg.WithJSONFilePath("config.json.b64"),
+		gcfg.WithJSONFileFS(&fsys),
+		gcfg.WithJSONDecrypt(func(ciphertext []byte) ([]byte, error) {
+			return base64.StdEncoding.AppendDecode(nil, ciphertext)
+		}),
+	)
+
+	values, err := p.Load()
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", values["password"])
+
+	errNoIdentity := errors.New("no identity matched")
+
+	_, err = gcfg.NewJSONProvider(
+		gcfg.WithJSONFilePath("config.json.b64"),
+		gcfg.WithJSONFileFS(&fsys),
+		gcfg.WithJSONDecrypt(func([]byte) ([]byte, error) { return nil, errNoIdentity }),
+	).Load()
+	require.ErrorIs(t, err, gcfg.ErrFileDecryptFailed)
+	require.ErrorIs(t, err, errNoIdentity)
+}
+
+func TestJSONProvider_WithJSONBaseDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"level": "info"}`), 0o600))
+
+	values, err := gcfg.NewJSONProvider(
+		gcfg.WithJSONFilePath("config.json"),
+		gcfg.WithJSONBaseDir(dir),
+	).Load()
+	require.NoError(t, err)
+	assert.Equal(t, "info", values["level"])
+
+	_, err = gcfg.NewJSONProvider(
+		gcfg.WithJSONFilePath(filepath.Join(dir, "config.json")),
+		gcfg.WithJSONBaseDir(filepath.Join(dir, "nested")),
+	).Load()
+	require.ErrorContains(t, err, "outside allowed directory")
+}
+
+func TestWithBaseDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"level": "info"}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("PORT=8080"), 0o600))
+
+	other := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(other, "override.json"), []byte(`{"level": "debug"}`), 0o600))
+
+	cfg := gcfg.New(
+		gcfg.NewJSONProvider(gcfg.WithJSONFilePath("config.json")),
+		gcfg.WithBaseDir(dir),
+		gcfg.WithoutDefaultEnv(),
+	)
+	cfg.AddProvider(gcfg.NewDotEnvProvider(gcfg.WithDotEnvFileAppendToOSEnv(false)))
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, "info", cfg.Get("level"))
+	assert.Equal(t, "8080", cfg.Get("port"))
+
+	// Providers given their own base directory keep it.
+	cfg.AddProvider(gcfg.NewJSONProvider(
+		gcfg.WithJSONFilePath("override.json"),
+		gcfg.WithJSONBaseDir(other),
+	))
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, "debug", cfg.Get("level"))
+}
+
+func TestWithAllowSymlinks(t *testing.T) {
+	t.Parallel()
+
+	dir, other := t.TempDir(), t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "target.json"), []byte(`{"level": "info"}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(other, "outside.json"), []byte(`{"level": "debug"}`), 0o600))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "target.json"), filepath.Join(dir, "config.json")))
+	require.NoError(t, os.Symlink(filepath.Join(other, "outside.json"), filepath.Join(dir, "escape.json")))
+
+	load := func(path string, opts ...gcfg.Provider) error {
+		return gcfg.New(append([]gcfg.Provider{
+			gcfg.NewJSONProvider(gcfg.WithJSONFilePath(path)),
+			gcfg.WithBaseDir(dir),
+			gcfg.WithoutDefaultEnv(),
+		}, opts...)...).Load()
+	}
+
+	require.ErrorContains(t, load("config.json"), "symlink detected")
+	require.NoError(t, load("config.json", gcfg.WithAllowSymlinks(true)))
+	require.ErrorContains(t, load("escape.json", gcfg.WithAllowSymlinks(true)), "outside allowed directory")
+	// a later WithAllowSymlinks(false) disallows symlinks again
+	require.ErrorContains(t, load("config.json", gcfg.WithAllowSymlinks(true), gcfg.WithAllowSymlinks(false)),
+		"symlink detected")
+}
+
+func TestJSONProvider_WithJSONAllowSymlinks(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "target.json"), []byte(`{"level": "info"}`), 0o600))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "target.json"), filepath.Join(dir, "config.json")))
+
+	load := func(allow bool, opts ...gcfg.Provider) error {
+		return gcfg.New(append([]gcfg.Provider{
+			gcfg.NewJSONProvider(gcfg.WithJSONFilePath("config.json"), gcfg.WithJSONAllowSymlinks(allow)),
+			gcfg.WithBaseDir(dir),
+			gcfg.WithoutDefaultEnv(),
+		}, opts...)...).Load()
+	}
+
+	require.NoError(t, load(true))
+	// the setting of the provider takes precedence over the one of the Config
+	require.NoError(t, load(true, gcfg.WithAllowSymlinks(false)))
+	require.ErrorContains(t, load(false, gcfg.WithAllowSymlinks(true)), "symlink detected")
+}
+
+func TestWithMaxFileSize(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	large := `{"data": "` + strings.Repeat("x", 2<<20) + `"}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "large.json"), []byte(large), 0o600))
+
+	load := func(opts ...gcfg.Provider) error {
+		return gcfg.New(append([]gcfg.Provider{
+			gcfg.NewJSONProvider(gcfg.WithJSONFilePath("large.json")),
+			gcfg.WithBaseDir(dir),
+			gcfg.WithoutDefaultEnv(),
+		}, opts...)...).Load()
+	}
+
+	require.ErrorContains(t, load(), "config file too large")
+	require.ErrorContains(t, load(gcfg.WithMaxFileSize(1024)), "config file too large")
+	require.NoError(t, load(gcfg.WithMaxFileSize(4<<20)))
+	require.NoError(t, load(gcfg.WithMaxFileSize(-1)))
+}
+
+func TestJSONProvider_WithJSONMaxFileSize(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.json"), []byte(`{"level": "info"}`), 0o600))
+
+	load := func(size int64, opts ...gcfg.Provider) error {
+		return gcfg.New(append([]gcfg.Provider{
+			gcfg.NewJSONProvider(gcfg.WithJSONFilePath("config.json"), gcfg.WithJSONMaxFileSize(size)),
+			gcfg.WithBaseDir(dir),
+			gcfg.WithoutDefaultEnv(),
+		}, opts...)...).Load()
+	}
+
+	require.ErrorContains(t, load(8), "config file too large")
+	// the limit of the provider takes precedence over the one of the Config
+	require.ErrorContains(t, load(8, gcfg.WithMaxFileSize(-1)), "config file too large")
+	require.NoError(t, load(-1, gcfg.WithMaxFileSize(8)))
+}
+
+func TestJSONProvider_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorIs(t, gcfg.NewJSONProvider().Validate(), gcfg.ErrJSONFilePathNotSet)
+	require.NoError(t, gcfg.NewJSONProvider(gcfg.WithJSONFilePath("config.json")).Validate())
+
+	err := gcfg.NewJSONProvider(
+		gcfg.WithJSONFilePath("config.json"),
+		gcfg.WithJSONWatchInterval(time.Second),
+	).Validate()
+	require.ErrorIs(t, err, gcfg.ErrConflictingOptions)
+
+	err = gcfg.NewJSONProvider(
+		gcfg.WithJSONFilePath("config.json"),
+		gcfg.WithJSONWatch(true),
+		gcfg.WithJSONWatchInterval(-time.Second),
+	).Validate()
+	require.ErrorIs(t, err, gcfg.ErrInvalidWatchInterval)
+}
+
+func TestJSONProvider_Watch_Disabled(t *testing.T) {
+	t.Parallel()
+
+	p := gcfg.NewJSONProvider(gcfg.WithJSONFilePath("config.json"))
+
+	_, err := p.Watch(context.Background())
+	assert.ErrorIs(t, err, gcfg.ErrWatchNotSupported)
+}
+
+func TestJSONProvider_Watch(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"level": "info"}`), 0o600))
+
+	cfg := gcfg.New(gcfg.NewJSONProvider(
+		gcfg.WithJSONFilePath("config.json"),
+		gcfg.WithJSONFileFS(os.DirFS(dir)),
+		gcfg.WithJSONWatch(true),
+		gcfg.WithJSONWatchInterval(10*time.Millisecond),
+	))
+	require.NoError(t, cfg.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, cfg.Watch(ctx))
+
+	// Replace the file with an atomic rename, the way editors and config management tools do.
+	tmp := filepath.Join(dir, "config.json.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte(`{"level": "debug"}`), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+
+	assert.Eventually(t, func() bool {
+		return cfg.Get("level") == "debug"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestJSONProvider_Watch_SameSizeAndModTime(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"level": "info"}`), 0o600))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	cfg := gcfg.New(gcfg.NewJSONProvider(
+		gcfg.WithJSONFilePath("config.json"),
+		gcfg.WithJSONFileFS(os.DirFS(dir)),
+		gcfg.WithJSONWatch(true),
+		gcfg.WithJSONWatchInterval(10*time.Millisecond),
+	))
+	require.NoError(t, cfg.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, cfg.Watch(ctx))
+
+	// let the watcher hash the current contents
+	time.Sleep(100 * time.Millisecond)
+
+	// rewrite the file keeping its size and modification time, which only its contents tell apart
+	require.NoError(t, os.WriteFile(path, []byte(`{"level": "warn"}`), 0o600))
+	require.NoError(t, os.Chtimes(path, info.ModTime(), info.ModTime()))
+
+	assert.Eventually(t, func() bool {
+		return cfg.Get("level") == "warn"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// countingFS counts the files read from an fstest.MapFS.
+type countingFS struct {
+	fstest.MapFS
+
+	reads atomic.Int32
+}
+
+func (c *countingFS) Open(name string) (fs.File, error) {
+	c.reads.Add(1)
+
+	return c.MapFS.Open(name)
+}
+
+func (c *countingFS) ReadFile(name string) ([]byte, error) {
+	c.reads.Add(1)
+
+	return c.MapFS.ReadFile(name)
+}
+
+func TestJSONProvider_Watch_UnchangedFileNotRead(t *testing.T) {
+	t.Parallel()
+
+	fsys := &countingFS{MapFS: fstest.MapFS{
+		"config.json": {Data: []byte(`{"level": "info"}`), ModTime: time.Now().Add(-time.Hour)},
+	}}
+
+	cfg := gcfg.New(gcfg.NewJSONProvider(
+		gcfg.WithJSONFilePath("config.json"),
+		gcfg.WithJSONFileFS(fsys),
+		gcfg.WithJSONWatch(true),
+		gcfg.WithJSONWatchInterval(5*time.Millisecond),
+	))
+	require.NoError(t, cfg.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, cfg.Watch(ctx))
+
+	// the contents are hashed once, as the file was last modified long ago, and not read again
+	time.Sleep(50 * time.Millisecond)
+
+	reads := fsys.reads.Load()
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, reads, fsys.reads.Load())
+}
@@ -0,0 +1,223 @@
+package gcfg
+
+import (
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/ahmedkamalio/gcfg/internal/reflection"
+)
+
+// KeyNormalizer normalizes a key segment: a key of the values loaded by providers, or a segment
+// of a dotted key passed to methods like Get and Set. Segments normalized to "" are ignored when
+// merging values.
+type KeyNormalizer func(segment string) string
+
+// LowercaseKeys is the default KeyNormalizer: segments are trimmed and lower-cased, so keys are
+// case-insensitive.
+func LowercaseKeys(segment string) string {
+	return maps.NormalizeKey(segment)
+}
+
+// IdentityKeys is a KeyNormalizer keeping segments as-is, so keys are case-sensitive and values
+// with intentionally case-sensitive keys (e.g., Kubernetes label maps) are loaded intact.
+func IdentityKeys(segment string) string {
+	return segment
+}
+
+// WithKeyDelimiter sets the delimiter separating the segments of hierarchical keys, e.g. "/" so
+// keys containing dots (hostnames, versions) can be addressed: Get("hosts/api.example.com/port").
+// The delimiter is also used in the dotted paths returned by the Config (e.g., AllKeys, Change.Key).
+//
+// Default: ".".
+func WithKeyDelimiter(delimiter string) Option {
+	return func(c *Config) {
+		c.keys.delimiter = delimiter
+	}
+}
+
+// WithKeyNormalizer sets how keys are normalized, see KeyNormalizer.
+//
+// Default: LowercaseKeys.
+func WithKeyNormalizer(normalizer KeyNormalizer) Option {
+	return func(c *Config) {
+		c.keys.normalizer = normalizer
+	}
+}
+
+// keyFormat defines how the keys of a Config are normalized and split into path segments.
+type keyFormat struct {
+	normalizer KeyNormalizer
+	delimiter  string
+}
+
+// sep returns the delimiter separating the segments of dotted keys.
+func (f keyFormat) sep() string {
+	if f.delimiter == "" {
+		return keyPathSeparator
+	}
+
+	return f.delimiter
+}
+
+// normalizeSegment normalizes a single key segment.
+func (f keyFormat) normalizeSegment(segment string) string {
+	if f.normalizer == nil {
+		return LowercaseKeys(segment)
+	}
+
+	return f.normalizer(segment)
+}
+
+// segments splits a dotted key into its raw path segments. A delimiter preceded by a backslash is
+// part of the segment rather than a separator, e.g. `labels.app\.kubernetes\.io/name` has the
+// segments "labels" and "app.kubernetes.io/name"; a double backslash stands for a backslash.
+func (f keyFormat) segments(key string) []string {
+	return maps.SplitPath(key, f.sep())
+}
+
+// join joins path segments into a dotted key, escaping the delimiters within segments, the
+// inverse of segments.
+func (f keyFormat) join(segments ...string) string {
+	return maps.JoinSegments(segments, f.sep())
+}
+
+// split splits a dotted key into its normalized path segments.
+func (f keyFormat) split(key string) (pathParts []string, finalKey string) {
+	parts := f.segments(key)
+	for i := range parts {
+		parts[i] = f.normalizeSegment(parts[i])
+	}
+
+	return parts[:len(parts)-1], parts[len(parts)-1]
+}
+
+// normalize normalizes a dotted key the same way keys are normalized during merge.
+func (f keyFormat) normalize(key string) string {
+	pathParts, finalKey := f.split(key)
+
+	return f.join(append(pathParts, finalKey)...)
+}
+
+// path splits a dotted key into all its normalized path segments.
+func (f keyFormat) path(key string) []string {
+	pathParts, finalKey := f.split(key)
+
+	return append(pathParts, finalKey)
+}
+
+// find returns the value at the dotted key in values, and whether it exists. Segments may be
+// indices of []any values, e.g. "servers.0.host".
+func (f keyFormat) find(values map[string]any, key string) (any, bool) {
+	return maps.GetNested(values, f.path(key))
+}
+
+// lookup returns a copy of the value at the dotted key in values, or nil if absent.
+func (f keyFormat) lookup(values map[string]any, key string) any {
+	value, _ := f.find(values, key)
+
+	return reflection.Clone(value)
+}
+
+// merge deep merges src into dst, normalizing the keys of src.
+func (f keyFormat) merge(dst, src map[string]any) {
+	maps.MergeFunc(dst, src, f.normalizeSegment)
+}
+
+// mergeWithoutOverride deep merges src into dst without overriding existing values, normalizing
+// the keys of src.
+func (f keyFormat) mergeWithoutOverride(dst, src map[string]any) {
+	maps.MergeWithoutOverrideFunc(dst, src, f.normalizeSegment)
+}
+
+// recordOrigins records origin as the origin of every leaf key in values.
+func (f keyFormat) recordOrigins(origins map[string]Origin, values map[string]any, origin Origin) {
+	for key := range maps.Flatten(values, f.sep()) {
+		origins[f.normalize(key)] = origin
+	}
+}
+
+// WithPreserveKeyCase makes Values return keys in their original spelling (e.g., "maxConnections"
+// rather than "maxconnections"), so the configuration can be exported back to its source format,
+// while lookups remain normalized (see WithKeyNormalizer). When a key is spelled differently by
+// several sources, the spelling last loaded or set wins.
+func WithPreserveKeyCase() Option {
+	return func(c *Config) {
+		c.keySpellings = make(map[string]string)
+	}
+}
+
+// recordSpellings records in spellings the original spelling of the keys of values, nested under
+// the normalized dotted path prefix. Existing spellings are only replaced if override is true.
+func (f keyFormat) recordSpellings(spellings map[string]string, values map[string]any, prefix string, override bool) {
+	for key, value := range values {
+		segment := f.normalizeSegment(key)
+		if segment == "" {
+			continue
+		}
+
+		path := maps.JoinPath(prefix, segment, f.sep())
+
+		if _, exists := spellings[path]; override || !exists {
+			spellings[path] = key
+		}
+
+		if m, ok := value.(map[string]any); ok {
+			f.recordSpellings(spellings, m, path, override)
+		}
+	}
+}
+
+// recordKeySpellings records in spellings the original spelling of the segments of the dotted key,
+// and of the keys of value if it is a map. Existing spellings are only replaced if override is
+// true.
+func (f keyFormat) recordKeySpellings(spellings map[string]string, key string, value any, override bool) {
+	path := ""
+
+	for _, segment := range f.segments(key) {
+		normalized := f.normalizeSegment(segment)
+
+		if path != "" {
+			normalized = maps.JoinPath(path, normalized, f.sep())
+		} else {
+			normalized = maps.EscapeSegment(normalized, f.sep())
+		}
+
+		if _, exists := spellings[normalized]; override || !exists {
+			spellings[normalized] = segment
+		}
+
+		path = normalized
+	}
+
+	if m, ok := value.(map[string]any); ok {
+		f.recordSpellings(spellings, m, path, override)
+	}
+}
+
+// spell returns a copy of values with the keys nested under the normalized dotted path prefix
+// restored to their original spelling recorded in spellings.
+func spell(values map[string]any, spellings map[string]string, prefix, sep string) map[string]any {
+	spelled := make(map[string]any, len(values))
+
+	for key, value := range values {
+		path := maps.JoinPath(prefix, key, sep)
+
+		if m, ok := value.(map[string]any); ok {
+			value = spell(m, spellings, path, sep)
+		}
+
+		if original, ok := spellings[path]; ok {
+			key = original
+		}
+
+		spelled[key] = value
+	}
+
+	return spelled
+}
+
+// recordKeySpellings records the original spelling of the dotted key and of the keys of value,
+// if WithPreserveKeyCase is given. The caller must hold c.mu.
+func (c *Config) recordKeySpellings(key string, value any, override bool) {
+	if c.keySpellings != nil {
+		c.keys.recordKeySpellings(c.keySpellings, key, value, override)
+	}
+}
@@ -0,0 +1,261 @@
+package gcfg_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_DefaultKeyNormalizer(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"Database": map[string]any{"Host": "localhost"},
+	}})
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, map[string]any{"host": "localhost"}, cfg.Get("database"))
+	assert.Equal(t, "localhost", cfg.Get("DATABASE.HOST"))
+}
+
+func TestConfig_WithKeyNormalizer_Identity(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]any{
+		"app.kubernetes.io/Name": "api",
+		"Team":                   "platform",
+		"team":                   "infra",
+	}
+
+	cfg := gcfg.New(
+		&mockProvider{name: "mock", data: map[string]any{
+			"Metadata": map[string]any{"Labels": labels},
+		}},
+		gcfg.WithKeyNormalizer(gcfg.IdentityKeys),
+	)
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, labels, cfg.Get("Metadata.Labels"))
+	assert.Equal(t, "platform", cfg.Get("Metadata.Labels.Team"))
+	assert.Nil(t, cfg.Get("metadata.labels"))
+
+	cfg.Set("Metadata.Labels.Tier", "backend")
+	assert.Equal(t, "backend", cfg.Get("Metadata.Labels.Tier"))
+	assert.Nil(t, cfg.Get("metadata.labels.tier"))
+
+	origin, ok := cfg.Origin("Metadata.Labels.Team")
+	require.True(t, ok)
+	assert.Equal(t, "mock", origin.Provider)
+}
+
+func TestConfig_WithKeyNormalizer_Custom(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(
+		&mockProvider{name: "mock", data: map[string]any{"max-connections": 10}},
+		gcfg.WithKeyNormalizer(func(segment string) string {
+			return strings.ReplaceAll(gcfg.LowercaseKeys(segment), "-", "_")
+		}),
+	)
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, 10, cfg.Get("Max_Connections"))
+	assert.Equal(t, 10, cfg.Get("max-connections"))
+}
+
+func TestConfig_WithPreserveKeyCase(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"Server": map[string]any{"maxConnections": 10, "listenAddr": ":8080"},
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithPreserveKeyCase(), gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	cfg.Set("Server.readTimeout", "5s")
+	cfg.SetDefault("logLevel", "info")
+
+	assert.Equal(t, map[string]any{
+		"Server": map[string]any{
+			"maxConnections": 10,
+			"listenAddr":     ":8080",
+			"readTimeout":    "5s",
+		},
+		"logLevel": "info",
+	}, cfg.Values())
+
+	// Lookups remain case-insensitive.
+	assert.Equal(t, 10, cfg.Get("server.maxconnections"))
+	assert.Equal(t, 10, cfg.Get("Server.MaxConnections"))
+
+	// The spelling last loaded wins.
+	provider.data = map[string]any{"server": map[string]any{"MaxConnections": 20}}
+	require.NoError(t, cfg.Load())
+
+	values := cfg.Values()
+	assert.Equal(t, map[string]any{
+		"MaxConnections": 20,
+		"listenAddr":     ":8080",
+		"readTimeout":    "5s",
+	}, values["server"])
+}
+
+func TestConfig_Values_DefaultKeyCase(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"Server": map[string]any{"maxConnections": 10},
+	}}, gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, map[string]any{"server": map[string]any{"maxconnections": 10}}, cfg.Values())
+}
+
+func TestConfig_WithKeyDelimiter(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"hosts": map[string]any{
+			"api.example.com": map[string]any{"port": 8080},
+		},
+		"versions": map[string]any{"1.2.0": "stable"},
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithKeyDelimiter("/"), gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, 8080, cfg.Get("hosts/api.example.com/port"))
+	assert.Equal(t, "stable", cfg.Get("versions/1.2.0"))
+	assert.Nil(t, cfg.Get("hosts.api.example.com.port"))
+
+	cfg.Set("hosts/db.example.com/port", 5432)
+	assert.Equal(t, map[string]any{"port": 5432}, cfg.Get("hosts/db.example.com"))
+
+	assert.Equal(t, []string{
+		"hosts/api.example.com/port",
+		"hosts/db.example.com/port",
+		"versions/1.2.0",
+	}, cfg.AllKeys())
+
+	var changed []string
+
+	cfg.OnChange("hosts/api.example.com", func(_, _ any) {
+		changed = append(changed, "api")
+	})
+
+	provider.data = map[string]any{
+		"hosts": map[string]any{
+			"api.example.com": map[string]any{"port": 9090},
+		},
+		"versions": map[string]any{"1.2.0": "stable"},
+	}
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, []string{"api"}, changed)
+	assert.Equal(t, 9090, cfg.Sub("hosts/api.example.com").Get("port"))
+}
+
+func TestConfig_EscapedKeyDelimiter(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"metrics": map[string]any{
+			"labels": map[string]any{"app.kubernetes.io/name": "api"},
+		},
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithKeyNormalizer(gcfg.IdentityKeys), gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "api", cfg.Get(`metrics.labels.app\.kubernetes\.io/name`))
+	assert.Nil(t, cfg.Get("metrics.labels.app.kubernetes.io/name"))
+	assert.Equal(t, "api", cfg.Sub("metrics.labels").Get(`app\.kubernetes\.io/name`))
+
+	cfg.Set(`metrics.labels.app\.kubernetes\.io/version`, "1.0")
+	cfg.Set(`paths.C:\\`, "root")
+
+	assert.Equal(t, map[string]any{
+		"app.kubernetes.io/name":    "api",
+		"app.kubernetes.io/version": "1.0",
+	}, cfg.Get("metrics.labels"))
+	assert.Equal(t, map[string]any{`C:\`: "root"}, cfg.Get("paths"))
+
+	assert.True(t, cfg.Delete(`metrics.labels.app\.kubernetes\.io/version`))
+	assert.False(t, cfg.IsSet(`metrics.labels.app\.kubernetes\.io/version`))
+}
+
+func TestConfig_EscapedKeys_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"labels": map[string]any{"app.kubernetes.io/name": "api", `C:\data`: "dir"},
+		"server": map[string]any{"port": 8080},
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	keys := cfg.AllKeys()
+	assert.Contains(t, keys, `labels.app\.kubernetes\.io/name`)
+	assert.Contains(t, keys, `labels.c:\\data`)
+
+	for _, key := range keys {
+		assert.NotNil(t, cfg.Get(key), key)
+
+		origin, ok := cfg.Origin(key)
+		assert.True(t, ok, key)
+		assert.Equal(t, "mock", origin.Provider, key)
+	}
+
+	for key, value := range cfg.AllSettings() {
+		assert.Equal(t, value, cfg.Get(key), key)
+	}
+
+	cfg.Walk(func(path string, value any) bool {
+		assert.Equal(t, value, cfg.Get(path), path)
+
+		return true
+	})
+
+	var changed []string
+
+	cfg.OnChanges(func(changes gcfg.ChangeSet) {
+		for _, change := range changes {
+			changed = append(changed, change.Key)
+		}
+	})
+
+	provider.data["labels"].(map[string]any)["app.kubernetes.io/name"] = "web"
+	require.NoError(t, cfg.Load())
+
+	require.Equal(t, []string{`labels.app\.kubernetes\.io/name`}, changed)
+	assert.Equal(t, "web", cfg.Get(changed[0]))
+
+	cfg.Set(changed[0], "set")
+	assert.Equal(t, "set", cfg.Get(`labels.app\.kubernetes\.io/name`))
+	assert.Equal(t, "set", cfg.Get("labels").(map[string]any)["app.kubernetes.io/name"])
+}
+
+func TestConfig_EscapedKeys_RestartRequired(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"labels": map[string]any{"app.kubernetes.io/name": "api"},
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithoutDefaultEnv())
+	cfg.WithRestartRequired(`labels.app\.kubernetes\.io/name`)
+	require.NoError(t, cfg.Load())
+
+	provider.data["labels"].(map[string]any)["app.kubernetes.io/name"] = "web"
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "api", cfg.Get(`labels.app\.kubernetes\.io/name`))
+	assert.NotContains(t, cfg.AllSettings(), "labels.app.kubernetes.io/name")
+	require.Len(t, cfg.PendingRestart(), 1)
+	assert.Equal(t, "web", cfg.PendingRestart()[0].NewValue)
+}
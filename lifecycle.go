@@ -0,0 +1,252 @@
+package gcfg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/ahmedkamalio/gcfg/internal/reflection"
+)
+
+var (
+	// ErrComponentRejected indicates that a component rejected a new configuration.
+	ErrComponentRejected = errors.New("component rejected configuration")
+
+	// ErrComponentAlreadyRegistered indicates that a component with the same name is already registered.
+	ErrComponentAlreadyRegistered = errors.New("component already registered")
+
+	// ErrComponentDependencyNotFound indicates that a component depends on an unregistered component.
+	ErrComponentDependencyNotFound = errors.New("component dependency not found")
+
+	// ErrComponentDependencyCycle indicates a dependency cycle between components.
+	ErrComponentDependencyCycle = errors.New("component dependency cycle")
+
+	// ErrConcurrentModification indicates that the configuration kept being modified while a new
+	// configuration was applied to the components.
+	ErrConcurrentModification = errors.New("configuration modified while applying components")
+)
+
+// Component is implemented by application components that apply the configuration section
+// they registered interest in, bound to T.
+//
+// ApplyConfig is called on the initial Load and on every reload that changes the section.
+// Returning an error rejects the new configuration.
+type Component[T any] interface {
+	ApplyConfig(ctx context.Context, cfg T) error
+}
+
+// ComponentFunc is an adapter to allow the use of ordinary functions as components.
+type ComponentFunc[T any] func(ctx context.Context, cfg T) error
+
+// ApplyConfig implements the Component interface.
+func (f ComponentFunc[T]) ApplyConfig(ctx context.Context, cfg T) error {
+	return f(ctx, cfg)
+}
+
+// componentEntry is a type-erased registered component.
+type componentEntry struct {
+	name      string
+	prefix    string
+	dependsOn []string
+	apply     func(ctx context.Context, values map[string]any) error
+}
+
+// RegisterComponent registers a component that receives the configuration section rooted at
+// prefix (the whole configuration if prefix is empty), bound and validated into T.
+//
+// On reload, the affected components are called in dependency order (dependencies first).
+// If any component rejects the new configuration, the reload is aborted, the previous
+// configuration is kept, and the components that already accepted the new configuration are
+// called again, in reverse order, with the previous one.
+func RegisterComponent[T any](
+	c *Config,
+	name, prefix string,
+	comp Component[T],
+	dependsOn ...string,
+) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, entry := range c.components {
+		if entry.name == name {
+			return fmt.Errorf("%w: %s", ErrComponentAlreadyRegistered, name)
+		}
+	}
+
+	if prefix != "" {
+		prefix = c.keys.normalize(prefix)
+	}
+
+	c.components = append(c.components, &componentEntry{
+		name:      name,
+		prefix:    prefix,
+		dependsOn: append([]string{}, dependsOn...),
+		apply: func(ctx context.Context, values map[string]any) error {
+			var section T
+			if _, err := c.bindValues(prefix, sectionValues(values, prefix, c.keys.sep()), &section, BindOptions{validate: true}); err != nil {
+				return err
+			}
+
+			return comp.ApplyConfig(ctx, section)
+		},
+	})
+
+	return nil
+}
+
+// sectionValues returns the values nested under prefix, whose segments are joined with sep, or an
+// empty map if there are none.
+func sectionValues(values map[string]any, prefix, sep string) map[string]any {
+	if prefix == "" {
+		return values
+	}
+
+	section := maps.FindNestedMap(values, maps.SplitPath(prefix, sep), false)
+	if section == nil {
+		return make(map[string]any)
+	}
+
+	return section
+}
+
+// affects reports whether any of the changes, whose keys are delimited by sep, is within the
+// component section.
+func (e *componentEntry) affects(changes []Change, sep string) bool {
+	for _, change := range changes {
+		if e.prefix == "" || change.Key == e.prefix ||
+			strings.HasPrefix(change.Key, e.prefix+sep) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyComponents applies the given update to the affected components, rolling back the
+// components that accepted it if any of them rejects it.
+func (c *Config) applyComponents(ctx context.Context, update *configUpdate) error {
+	c.mu.RLock()
+	ordered, err := orderComponents(c.components)
+	loaded := c.loaded
+	previous := reflection.Clone(c.values)
+	c.mu.RUnlock()
+
+	if err != nil {
+		return err
+	}
+
+	applied := make([]*componentEntry, 0, len(ordered))
+
+	for _, entry := range ordered {
+		if loaded && !entry.affects(update.changes, c.keys.sep()) {
+			continue
+		}
+
+		if aErr := entry.apply(ctx, update.values); aErr != nil {
+			errs := []error{aErr}
+
+			if loaded {
+				for i := len(applied) - 1; i >= 0; i-- {
+					if rErr := applied[i].apply(ctx, previous); rErr != nil {
+						errs = append(errs, fmt.Errorf("rollback %s: %w", applied[i].name, rErr))
+					}
+				}
+			}
+
+			return fmt.Errorf("%w %s: %w", ErrComponentRejected, entry.name, errors.Join(errs...))
+		}
+
+		applied = append(applied, entry)
+	}
+
+	return nil
+}
+
+// restoreComponents applies the current configuration again to the components affected by update,
+// which was applied to them but not committed, in reverse dependency order.
+func (c *Config) restoreComponents(ctx context.Context, update *configUpdate) error {
+	c.mu.RLock()
+	ordered, err := orderComponents(c.components)
+	loaded := c.loaded
+	current := reflection.Clone(c.values)
+	c.mu.RUnlock()
+
+	if err != nil || !loaded {
+		return err
+	}
+
+	var errs []error
+
+	for i := len(ordered) - 1; i >= 0; i-- {
+		if !ordered[i].affects(update.changes, c.keys.sep()) {
+			continue
+		}
+
+		if rErr := ordered[i].apply(ctx, current); rErr != nil {
+			errs = append(errs, fmt.Errorf("rollback %s: %w", ordered[i].name, rErr))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// orderComponents sorts components so that dependencies come before their dependents,
+// keeping the registration order otherwise.
+func orderComponents(components []*componentEntry) ([]*componentEntry, error) {
+	byName := make(map[string]*componentEntry, len(components))
+	for _, entry := range components {
+		byName[entry.name] = entry
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[string]int, len(components))
+	ordered := make([]*componentEntry, 0, len(components))
+
+	var visit func(entry *componentEntry, chain []string) error
+
+	visit = func(entry *componentEntry, chain []string) error {
+		switch state[entry.name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf(
+				"%w: %s",
+				ErrComponentDependencyCycle,
+				strings.Join(append(chain, entry.name), " -> "),
+			)
+		}
+
+		state[entry.name] = visiting
+
+		for _, dep := range entry.dependsOn {
+			depEntry, ok := byName[dep]
+			if !ok {
+				return fmt.Errorf("%w: %s depends on %s", ErrComponentDependencyNotFound, entry.name, dep)
+			}
+
+			if err := visit(depEntry, append(chain, entry.name)); err != nil {
+				return err
+			}
+		}
+
+		state[entry.name] = visited
+		ordered = append(ordered, entry)
+
+		return nil
+	}
+
+	for _, entry := range components {
+		if err := visit(entry, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
@@ -0,0 +1,163 @@
+package gcfg_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dbConfig struct {
+	Host string
+	Pool int `validate:"min=1"`
+}
+
+type serverConfig struct {
+	Port int
+}
+
+func TestRegisterComponent_AppliesSections(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{"host": "localhost", "pool": 5},
+		"server":   map[string]any{"port": 8080},
+	}}
+	cfg := gcfg.New(mockP)
+
+	var (
+		calls    []string
+		dbCfg    dbConfig
+		serverCf serverConfig
+	)
+
+	require.NoError(t, gcfg.RegisterComponent(cfg, "server", "server",
+		gcfg.ComponentFunc[serverConfig](func(_ context.Context, c serverConfig) error {
+			calls = append(calls, "server")
+			serverCf = c
+
+			return nil
+		}), "database"))
+
+	require.NoError(t, gcfg.RegisterComponent(cfg, "database", "database",
+		gcfg.ComponentFunc[dbConfig](func(_ context.Context, c dbConfig) error {
+			calls = append(calls, "database")
+			dbCfg = c
+
+			return nil
+		})))
+
+	require.NoError(t, cfg.Load())
+
+	// Dependencies are applied first.
+	assert.Equal(t, []string{"database", "server"}, calls)
+	assert.Equal(t, dbConfig{Host: "localhost", Pool: 5}, dbCfg)
+	assert.Equal(t, serverConfig{Port: 8080}, serverCf)
+
+	// Only affected components are called on reload.
+	calls = nil
+	mockP.data = map[string]any{
+		"database": map[string]any{"host": "localhost", "pool": 5},
+		"server":   map[string]any{"port": 9090},
+	}
+
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, []string{"server"}, calls)
+	assert.Equal(t, serverConfig{Port: 9090}, serverCf)
+}
+
+func TestRegisterComponent_RejectionRollsBack(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{"host": "localhost", "pool": 5},
+	}}
+	cfg := gcfg.New(mockP)
+
+	var applied []dbConfig
+
+	require.NoError(t, gcfg.RegisterComponent(cfg, "pool", "database",
+		gcfg.ComponentFunc[dbConfig](func(_ context.Context, c dbConfig) error {
+			applied = append(applied, c)
+
+			return nil
+		})))
+
+	require.NoError(t, gcfg.RegisterComponent(cfg, "guard", "database",
+		gcfg.ComponentFunc[dbConfig](func(_ context.Context, c dbConfig) error {
+			if c.Host == "" {
+				//nolint:err113
+				return errors.New("host is required")
+			}
+
+			return nil
+		}), "pool"))
+
+	require.NoError(t, cfg.Load())
+
+	mockP.data = map[string]any{
+		"database": map[string]any{"host": "", "pool": 10},
+	}
+
+	err := cfg.Load()
+	require.ErrorIs(t, err, gcfg.ErrComponentRejected)
+	assert.Contains(t, err.Error(), "guard")
+
+	// The previous configuration is kept.
+	assert.Equal(t, "localhost", cfg.Get("database.host"))
+	assert.Equal(t, 5, cfg.Get("database.pool"))
+
+	// The accepting component was rolled back to the previous configuration.
+	assert.Equal(t, []dbConfig{
+		{Host: "localhost", Pool: 5},
+		{Host: "", Pool: 10},
+		{Host: "localhost", Pool: 5},
+	}, applied)
+}
+
+func TestRegisterComponent_ValidationRejects(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{"pool": 0},
+	}}
+	cfg := gcfg.New(mockP)
+
+	require.NoError(t, gcfg.RegisterComponent(cfg, "database", "database",
+		gcfg.ComponentFunc[dbConfig](func(context.Context, dbConfig) error {
+			return nil
+		})))
+
+	err := cfg.Load()
+	require.ErrorIs(t, err, gcfg.ErrComponentRejected)
+	assert.Nil(t, cfg.Get("database.pool"))
+}
+
+func TestRegisterComponent_Errors(t *testing.T) {
+	t.Parallel()
+
+	noop := gcfg.ComponentFunc[dbConfig](func(context.Context, dbConfig) error {
+		return nil
+	})
+
+	cfg := gcfg.New()
+
+	require.NoError(t, gcfg.RegisterComponent(cfg, "a", "", noop, "b"))
+	require.ErrorIs(
+		t,
+		gcfg.RegisterComponent(cfg, "a", "", noop),
+		gcfg.ErrComponentAlreadyRegistered,
+	)
+
+	require.ErrorIs(t, cfg.Load(), gcfg.ErrComponentDependencyNotFound)
+
+	require.NoError(t, gcfg.RegisterComponent(cfg, "b", "", noop, "a"))
+
+	err := cfg.Load()
+	require.ErrorIs(t, err, gcfg.ErrComponentDependencyCycle)
+	assert.Contains(t, err.Error(), "a -> b -> a")
+}
@@ -0,0 +1,89 @@
+package gcfg
+
+import "strings"
+
+// keyListener is a callback registered with OnChange.
+type keyListener struct {
+	key string
+	fn  func(oldValue, newValue any)
+}
+
+// affectedBy reports whether any of the changes is at, within or above the listener key.
+// Keys are delimited by sep.
+func (l *keyListener) affectedBy(changes []Change, sep string) bool {
+	for _, change := range changes {
+		if change.Key == l.key ||
+			strings.HasPrefix(change.Key, l.key+sep) ||
+			strings.HasPrefix(l.key, change.Key+sep) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// OnChange registers fn to be called whenever a reload (Load or a watched provider update)
+// changes the value of key or any value nested under it, e.g. OnChange("database", ...) is
+// notified of changes to "database.pool_size".
+//
+// fn receives the previous and the current value of key, nil if the key is absent. Listeners
+// are called once per reload, in registration order, after the new configuration is live and
+// before the post-load hooks. The initial load does not notify listeners.
+func (c *Config) OnChange(key string, fn func(oldValue, newValue any)) *Config {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.keyListeners = append(c.keyListeners, &keyListener{key: c.keys.normalize(key), fn: fn})
+
+	return c
+}
+
+// OnChanges registers fn to be called with the ChangeSet of every reload (Load or a watched
+// provider update) that changes the configuration.
+//
+// Listeners are called once per reload, in registration order, after the listeners registered
+// with OnChange. The initial load does not notify listeners.
+func (c *Config) OnChanges(fn func(changes ChangeSet)) *Config {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.changeListeners = append(c.changeListeners, fn)
+
+	return c
+}
+
+// prepareNotifications returns the calls to make to the listeners affected by changes, bound to
+// copies of the values so they can be made without holding c.mu. The caller must hold c.mu.
+func (c *Config) prepareNotifications(
+	previous, current map[string]any,
+	changes []Change,
+) []func() {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	var notifications []func()
+
+	for _, l := range c.keyListeners {
+		if !l.affectedBy(changes, c.keys.sep()) {
+			continue
+		}
+
+		fn := l.fn
+		oldValue, newValue := c.keys.lookup(previous, l.key), c.keys.lookup(current, l.key)
+
+		notifications = append(notifications, func() {
+			fn(oldValue, newValue)
+		})
+	}
+
+	for _, fn := range c.changeListeners {
+		changeSet := cloneChanges(changes)
+
+		notifications = append(notifications, func() {
+			fn(changeSet)
+		})
+	}
+
+	return notifications
+}
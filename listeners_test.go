@@ -0,0 +1,104 @@
+package gcfg_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_OnChange(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{"pool_size": 10, "host": "localhost"},
+	}}
+	levelP := &mockProvider{name: "levels", data: map[string]any{"level": "info"}}
+
+	type call struct{ oldValue, newValue any }
+
+	var poolCalls, databaseCalls, levelCalls []call
+
+	cfg := gcfg.New(mockP, levelP).
+		OnChange("Database.Pool_Size", func(oldValue, newValue any) {
+			poolCalls = append(poolCalls, call{oldValue, newValue})
+		}).
+		OnChange("database", func(oldValue, newValue any) {
+			databaseCalls = append(databaseCalls, call{oldValue, newValue})
+		}).
+		OnChange("level", func(oldValue, newValue any) {
+			levelCalls = append(levelCalls, call{oldValue, newValue})
+		})
+
+	// The initial load does not notify listeners.
+	require.NoError(t, cfg.Load())
+	assert.Empty(t, poolCalls)
+	assert.Empty(t, databaseCalls)
+
+	mockP.data = map[string]any{
+		"database": map[string]any{"pool_size": 20, "host": "localhost"},
+	}
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, []call{{10, 20}}, poolCalls)
+	assert.Equal(t, []call{{
+		map[string]any{"pool_size": 10, "host": "localhost"},
+		map[string]any{"pool_size": 20, "host": "localhost"},
+	}}, databaseCalls)
+	assert.Empty(t, levelCalls)
+
+	// Keys a provider no longer supplies are kept, whereas the keys of removed providers are
+	// removed, and reported with a nil new value.
+	mockP.data = map[string]any{"database": map[string]any{"host": "localhost"}}
+	require.True(t, cfg.RemoveProvider("levels"))
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, []call{{10, 20}}, poolCalls)
+	assert.Equal(t, []call{{"info", nil}}, levelCalls)
+}
+
+func TestConfig_OnChanges(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"host": "localhost",
+		"port": 5432,
+	}}
+	flagsP := &mockProvider{name: "flags", data: map[string]any{"debug": true}}
+
+	var changeSets []gcfg.ChangeSet
+
+	cfg := gcfg.New(mockP, flagsP).OnChanges(func(changes gcfg.ChangeSet) {
+		changeSets = append(changeSets, changes)
+	})
+
+	require.NoError(t, cfg.Load())
+	assert.Empty(t, changeSets)
+
+	// Reloads without changes do not notify listeners.
+	require.NoError(t, cfg.Load())
+	assert.Empty(t, changeSets)
+
+	mockP.data = map[string]any{
+		"host":  "db.internal",
+		"port":  5432,
+		"level": "info",
+	}
+	require.True(t, cfg.RemoveProvider("flags"))
+	require.NoError(t, cfg.Load())
+
+	require.Len(t, changeSets, 1)
+
+	changes := changeSets[0]
+	assert.Equal(t, []string{"debug", "host", "level"}, changes.Keys())
+	assert.Equal(t, []gcfg.Change{
+		{Key: "level", Type: gcfg.ChangeAdded, NewValue: "info", Provider: "mock"},
+	}, changes.Added())
+	assert.Equal(t, []gcfg.Change{
+		{Key: "host", Type: gcfg.ChangeUpdated, OldValue: "localhost", NewValue: "db.internal", Provider: "mock"},
+	}, changes.Updated())
+	assert.Equal(t, []gcfg.Change{
+		{Key: "debug", Type: gcfg.ChangeRemoved, OldValue: true, Provider: "flags"},
+	}, changes.Removed())
+}
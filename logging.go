@@ -0,0 +1,51 @@
+package gcfg
+
+import (
+	"log/slog"
+)
+
+// loggerSetter is implemented by built-in providers that emit logs, so they can inherit the
+// logger of the Config they are registered with.
+type loggerSetter interface {
+	setLogger(logger *slog.Logger)
+}
+
+// WithLogger sets the logger used to report provider loads, overridden keys and reloads at debug
+// level, and failures at warn level. The logger is also passed to the registered built-in
+// providers, e.g. so the environment providers report the unsafe variables they skip.
+// Secret values (see WithSecret and Handler) are masked. A nil logger disables logging.
+//
+// WithLogger should be called before the configuration is loaded.
+func (c *Config) WithLogger(logger *slog.Logger) *Config {
+	c.logger.Store(logger)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, p := range c.providers {
+		propagateLogger(p.Provider, logger)
+	}
+
+	return c
+}
+
+// log returns the logger set with WithLogger, or a logger discarding all records.
+func (c *Config) log() *slog.Logger {
+	return loggerOrDiscard(c.logger.Load())
+}
+
+// propagateLogger passes logger to p if it is a built-in provider emitting logs.
+func propagateLogger(p Provider, logger *slog.Logger) {
+	if ls, ok := asProvider[loggerSetter](p); ok {
+		ls.setLogger(logger)
+	}
+}
+
+// loggerOrDiscard returns logger, or a logger discarding all records if logger is nil.
+func loggerOrDiscard(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return slog.New(slog.DiscardHandler)
+	}
+
+	return logger
+}
@@ -0,0 +1,57 @@
+package gcfg_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_WithLogger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	base := &mockProvider{name: "base", data: map[string]any{
+		"host":     "localhost",
+		"password": "hunter2",
+	}}
+	override := &mockProvider{name: "override", data: map[string]any{
+		"host":     "db.internal",
+		"password": "correct-horse",
+	}}
+	failing := &mockProvider{name: "failing"}
+
+	cfg := gcfg.New(&mockProvider{name: "Environment Variables"}, base, override, failing).WithLogger(logger)
+	require.NoError(t, cfg.Load())
+
+	out := buf.String()
+	assert.Contains(t, out, `msg="gcfg: provider loaded" provider=base`)
+	assert.Contains(t, out, `msg="gcfg: key overridden" key=host value=db.internal provider=override previous=base`)
+	assert.Contains(t, out, `msg="gcfg: key added" key=host`)
+	assert.Contains(t, out, `msg="gcfg: configuration reloaded"`)
+	assert.NotContains(t, out, "hunter2")
+	assert.NotContains(t, out, "correct-horse")
+
+	failing.err = assert.AnError
+	require.Error(t, cfg.Load())
+	assert.Contains(t, buf.String(), `level=WARN msg="gcfg: provider load failed" provider=failing`)
+}
+
+func TestConfig_WithLogger_EnvProvider(t *testing.T) {
+	t.Setenv("PATH", "/usr/bin")
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	cfg := gcfg.New().WithLogger(logger)
+	require.NoError(t, cfg.Load())
+
+	assert.Contains(t, buf.String(), `msg="gcfg: skipped unsafe environment variable" name=PATH`)
+}
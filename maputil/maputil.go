@@ -0,0 +1,74 @@
+// Package maputil provides utilities for converting between map[string]any and Go data structures
+// and for deep merging maps, as used by gcfg to bind configuration values.
+//
+// Binding matches map keys to struct fields using the gcfg tag, then the json tag (if present),
+// then the case-insensitive field name, and converts between common Go types (e.g., the string "5432" can
+// be bound to an int field). Nested structs, slices, arrays, maps and pointers are handled
+// recursively.
+package maputil
+
+import "github.com/ahmedkamalio/gcfg/internal/maps"
+
+var (
+	// ErrDestIsNil is returned when the destination is nil.
+	ErrDestIsNil = maps.ErrDestIsNil
+	// ErrDestMustBePointer is returned when the destination of Bind is not a non-nil pointer to a struct.
+	ErrDestMustBePointer = maps.ErrDestMustBePointer
+	// ErrDestMustPointToStruct is returned when the destination of Bind does not point to a struct
+	// or a map.
+	ErrDestMustPointToStruct = maps.ErrDestMustPointToStruct
+	// ErrSrcIsNil is returned when the source of Unbind is nil.
+	ErrSrcIsNil = maps.ErrSrcIsNil
+	// ErrSrcMustBeStruct is returned when the source of Unbind is not a struct or a pointer to a struct.
+	ErrSrcMustBeStruct = maps.ErrSrcMustBeStruct
+	// ErrMissingKey is returned by Bind when the key of a field tagged `required:"true"` is absent.
+	ErrMissingKey = maps.ErrMissingKey
+	// ErrConvertDestMustBePointer is returned when the destination of Convert is not a non-nil pointer.
+	ErrConvertDestMustBePointer = maps.ErrConvertDestMustBePointer
+)
+
+// BindError is an error binding the value at a key into a struct field, returned (joined with the
+// errors of the other invalid keys) by Bind.
+type BindError = maps.BindError
+
+// Bind binds src into dest, which must be a non-nil pointer to a struct or a map.
+func Bind(src map[string]any, dest any) error {
+	return maps.Bind(src, dest)
+}
+
+// Unbind converts src, a struct or a pointer to a struct, into dest. Keys use the gcfg tag, then
+// the json tag (if present), then the field name.
+func Unbind(src any, dest map[string]any) error {
+	return maps.Unbind(src, dest)
+}
+
+// Convert converts v into the value pointed to by dest, using the same conversion rules as Bind.
+func Convert(v any, dest any) error {
+	return maps.Convert(v, dest)
+}
+
+// Merge deep merges src into dst, overriding the existing values of dst. Keys are lower-cased and
+// trimmed, and empty keys are ignored.
+func Merge(dst, src map[string]any) {
+	maps.Merge(dst, src)
+}
+
+// MergeWithoutOverride deep merges src into dst, keeping the existing values of dst. Keys are
+// lower-cased and trimmed, and empty keys are ignored.
+func MergeWithoutOverride(dst, src map[string]any) {
+	maps.MergeWithoutOverride(dst, src)
+}
+
+// Flatten converts a nested map into a flat map keyed by the sep-joined paths of its leaf values
+// (e.g., {"database": {"host": "localhost"}} into {"database.host": "localhost"} with sep ".").
+// Occurrences of sep and "\" within keys are escaped with a backslash, e.g. the key "app.kubernetes.io"
+// becomes `app\.kubernetes\.io`, so Expand restores the original keys.
+func Flatten(m map[string]any, sep string) map[string]any {
+	return maps.Flatten(m, sep)
+}
+
+// Expand converts a flat map keyed by sep-joined paths back into a nested map, the inverse of
+// Flatten. A separator preceded by a backslash is part of the key rather than a separator.
+func Expand(flat map[string]any, sep string) map[string]any {
+	return maps.Expand(flat, sep)
+}
@@ -0,0 +1,99 @@
+package maputil_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg/maputil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type payload struct {
+	Name string   `json:"name"`
+	Port int      `json:"port"`
+	Tags []string `json:"tags"`
+}
+
+func TestBindUnbind(t *testing.T) {
+	t.Parallel()
+
+	var dest payload
+
+	require.NoError(t, maputil.Bind(map[string]any{
+		"name": "api",
+		"port": "8080",
+		"tags": []any{"a", "b"},
+	}, &dest))
+
+	assert.Equal(t, payload{Name: "api", Port: 8080, Tags: []string{"a", "b"}}, dest)
+
+	values := make(map[string]any)
+	require.NoError(t, maputil.Unbind(dest, values))
+
+	var roundTrip payload
+
+	require.NoError(t, maputil.Bind(values, &roundTrip))
+	assert.Equal(t, dest, roundTrip)
+
+	require.ErrorIs(t, maputil.Bind(values, dest), maputil.ErrDestMustBePointer)
+	require.ErrorIs(t, maputil.Unbind(nil, values), maputil.ErrSrcIsNil)
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	var port int
+
+	require.NoError(t, maputil.Convert("5432", &port))
+	assert.Equal(t, 5432, port)
+
+	require.ErrorIs(t, maputil.Convert("5432", port), maputil.ErrConvertDestMustBePointer)
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		override bool
+		expected map[string]any
+	}{
+		{
+			name:     "override",
+			override: true,
+			expected: map[string]any{"server": map[string]any{"host": "0.0.0.0", "port": 9090}},
+		},
+		{
+			name:     "without override",
+			override: false,
+			expected: map[string]any{"server": map[string]any{"host": "0.0.0.0", "port": 8080}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dst := map[string]any{"server": map[string]any{"port": 8080}}
+			src := map[string]any{"Server": map[string]any{"host": "0.0.0.0", "port": 9090}}
+
+			if tt.override {
+				maputil.Merge(dst, src)
+			} else {
+				maputil.MergeWithoutOverride(dst, src)
+			}
+
+			assert.Equal(t, tt.expected, dst)
+		})
+	}
+}
+
+func TestFlattenExpand(t *testing.T) {
+	t.Parallel()
+
+	nested := map[string]any{"database": map[string]any{"host": "localhost", "port": 5432}}
+	flat := maputil.Flatten(nested, ".")
+
+	assert.Equal(t, map[string]any{"database.host": "localhost", "database.port": 5432}, flat)
+	assert.Equal(t, nested, maputil.Expand(flat, "."))
+}
@@ -0,0 +1,92 @@
+package gcfg
+
+import (
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/ahmedkamalio/gcfg/internal/reflection"
+)
+
+// MergeOptions defines options for merging configurations.
+type MergeOptions struct {
+	override bool
+}
+
+// MergeOption is a functional option for configuring MergeFrom behavior by modifying MergeOptions.
+type MergeOption func(*MergeOptions)
+
+// WithMergeOverride sets whether MergeFrom overrides the existing values.
+func WithMergeOverride(override bool) MergeOption {
+	return func(o *MergeOptions) {
+		o.override = override
+	}
+}
+
+// MergeFrom merges the current values of other into the configuration, so configurations assembled
+// independently (e.g., library defaults and application configuration) can be composed.
+//
+// By default, the merged values override the existing ones, as if set via Set. With
+// WithMergeOverride(false), they only fill in the missing keys, as if set via SetDefaults. Returns
+// ErrNilValues if other is nil.
+func (c *Config) MergeFrom(other *Config, options ...MergeOption) error {
+	if err := c.checkFrozen(AuditOpMergeFrom); err != nil {
+		return err
+	}
+
+	if other == nil {
+		return ErrNilValues
+	}
+
+	opts := MergeOptions{
+		override: true,
+	}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	other.mu.RLock()
+	values := other.exportValues(reflection.Clone(other.values))
+	other.mu.RUnlock()
+
+	var events []*AuditEvent
+
+	c.mu.Lock()
+
+	applied := reflection.Clone(c.values)
+
+	if opts.override {
+		c.keys.merge(c.overrides, reflection.Clone(values))
+		c.keys.merge(c.values, values)
+
+		for key, value := range maps.Flatten(values, c.keys.sep()) {
+			c.recordValueOrigins(key, value, Origin{Provider: overridesOriginName})
+		}
+	} else {
+		c.keys.mergeWithoutOverride(c.defaults, reflection.Clone(values))
+		c.keys.mergeWithoutOverride(c.values, values)
+	}
+
+	if c.keySpellings != nil {
+		c.keys.recordSpellings(c.keySpellings, values, "", opts.override)
+	}
+
+	for _, change := range diffValues(applied, c.values, c.keys.sep()) {
+		if change.Type == ChangeRemoved {
+			// Sections replaced by a value; the value has its own event.
+			continue
+		}
+
+		if !opts.override {
+			c.origins[change.Key] = Origin{Provider: defaultsOriginName}
+		}
+
+		events = append(events, c.auditEvent(AuditOpMergeFrom, change.Key,
+			change.OldValue, change.Type != ChangeAdded, change.NewValue))
+	}
+
+	c.rev++
+	c.mu.Unlock()
+
+	c.emitAudit(events...)
+
+	return nil
+}
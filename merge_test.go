@@ -0,0 +1,77 @@
+package gcfg_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_MergeFrom(t *testing.T) {
+	t.Parallel()
+
+	app := gcfg.New(&mockProvider{name: "app", data: map[string]any{
+		"server": map[string]any{"port": 8080},
+	}})
+	require.NoError(t, app.Load())
+
+	lib := gcfg.New(&mockProvider{name: "lib", data: map[string]any{
+		"server": map[string]any{"port": 9090, "timeout": "30s"},
+	}})
+	require.NoError(t, lib.Load())
+
+	require.NoError(t, app.MergeFrom(lib))
+
+	assert.Equal(t, 9090, app.Get("server.port"))
+	assert.Equal(t, "30s", app.Get("server.timeout"))
+
+	origin, ok := app.Origin("server.port")
+	require.True(t, ok)
+	assert.Equal(t, "Overrides", origin.Provider)
+
+	// Like values set via Set, merged values are replaced by the values the providers supply on
+	// the next load, and kept otherwise.
+	require.NoError(t, app.Load())
+	assert.Equal(t, 8080, app.Get("server.port"))
+	assert.Equal(t, "30s", app.Get("server.timeout"))
+
+	require.ErrorIs(t, app.MergeFrom(nil), gcfg.ErrNilValues)
+}
+
+func TestConfig_MergeFrom_WithoutOverride(t *testing.T) {
+	t.Parallel()
+
+	app := gcfg.New(&mockProvider{name: "app", data: map[string]any{
+		"server": map[string]any{"port": 8080},
+	}})
+	require.NoError(t, app.Load())
+
+	lib := gcfg.New(&mockProvider{name: "lib", data: map[string]any{
+		"server": map[string]any{"port": 9090, "timeout": "30s"},
+	}})
+	require.NoError(t, lib.Load())
+
+	var events []gcfg.AuditEvent
+
+	app.OnAudit(func(event gcfg.AuditEvent) {
+		events = append(events, event)
+	})
+
+	require.NoError(t, app.MergeFrom(lib, gcfg.WithMergeOverride(false)))
+
+	assert.Equal(t, 8080, app.Get("server.port"))
+	assert.Equal(t, "30s", app.Get("server.timeout"))
+
+	origin, ok := app.Origin("server.timeout")
+	require.True(t, ok)
+	assert.Equal(t, "Defaults", origin.Provider)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, gcfg.AuditOpMergeFrom, events[0].Operation)
+	assert.Equal(t, "server.timeout", events[0].Key)
+	assert.Contains(t, events[0].Caller, "merge_test.go:")
+
+	// The other configuration is not modified.
+	assert.Equal(t, 9090, lib.Get("server.port"))
+}
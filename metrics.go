@@ -0,0 +1,42 @@
+package gcfg
+
+import (
+	"time"
+)
+
+// MetricsSink receives metrics about configuration loads and binds. Implement it to back the
+// metrics with a monitoring system such as Prometheus, see Config.WithMetrics.
+//
+// Methods may be called concurrently and must not call back into the Config.
+type MetricsSink interface {
+	// ObserveLoad is called after every load (Load or a watched provider update) with its
+	// duration and error, nil if it succeeded.
+	ObserveLoad(duration time.Duration, err error)
+	// IncProviderFailure is called whenever the named provider fails to load.
+	IncProviderFailure(provider string)
+	// IncReload is called whenever a new configuration is committed (Load, a watched provider
+	// update or Restore).
+	IncReload()
+	// IncBindError is called whenever binding fails (Bind, BindLive or a component binding).
+	IncBindError()
+}
+
+// metricsSinkHolder allows storing a MetricsSink in an atomic.Value.
+type metricsSinkHolder struct {
+	sink MetricsSink
+}
+
+// WithMetrics sets the sink receiving metrics about configuration loads and binds.
+// A nil sink disables metrics.
+func (c *Config) WithMetrics(sink MetricsSink) *Config {
+	c.metrics.Store(metricsSinkHolder{sink: sink})
+
+	return c
+}
+
+// metricsSink returns the registered metrics sink, nil if none.
+func (c *Config) metricsSink() MetricsSink {
+	holder, _ := c.metrics.Load().(metricsSinkHolder)
+
+	return holder.sink
+}
@@ -0,0 +1,87 @@
+package gcfg_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockMetricsSink is a mock implementation of the MetricsSink interface for testing.
+type mockMetricsSink struct {
+	mu               sync.Mutex
+	loads            int
+	loadErrors       int
+	providerFailures map[string]int
+	reloads          int
+	bindErrors       int
+}
+
+func (m *mockMetricsSink) ObserveLoad(_ time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.loads++
+
+	if err != nil {
+		m.loadErrors++
+	}
+}
+
+func (m *mockMetricsSink) IncProviderFailure(provider string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.providerFailures == nil {
+		m.providerFailures = make(map[string]int)
+	}
+
+	m.providerFailures[provider]++
+}
+
+func (m *mockMetricsSink) IncReload() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.reloads++
+}
+
+func (m *mockMetricsSink) IncBindError() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.bindErrors++
+}
+
+func TestConfig_WithMetrics(t *testing.T) {
+	t.Parallel()
+
+	sink := &mockMetricsSink{}
+	failing := &mockProvider{name: "failing", err: assert.AnError}
+
+	cfg := gcfg.New(failing).WithMetrics(sink)
+
+	require.Error(t, cfg.Load())
+
+	failing.err = nil
+	require.NoError(t, cfg.Load())
+
+	var dest struct {
+		Host string `validate:"required"`
+	}
+	require.Error(t, cfg.Bind(&dest))
+
+	assert.Equal(t, 2, sink.loads)
+	assert.Equal(t, 1, sink.loadErrors)
+	assert.Equal(t, map[string]int{"failing": 1}, sink.providerFailures)
+	assert.Equal(t, 1, sink.reloads)
+	assert.Equal(t, 1, sink.bindErrors)
+
+	// Metrics can be disabled.
+	cfg.WithMetrics(nil)
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, 2, sink.loads)
+}
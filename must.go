@@ -0,0 +1,44 @@
+package gcfg
+
+import (
+	"fmt"
+)
+
+// MustGet is like Get, but panics if the key is absent or the value cannot be converted to T.
+// It is intended for startup-time configuration, where a missing or invalid value should abort
+// the program.
+func MustGet[T any](c *Config, key string) T {
+	out, err := Get[T](c, key)
+	if err != nil {
+		panic(fmt.Errorf("gcfg: %w", err))
+	}
+
+	return out
+}
+
+// MustString is like GetString, but panics if the key is absent or the value cannot be converted.
+func (c *Config) MustString(key string) string {
+	return MustGet[string](c, key)
+}
+
+// MustInt is like GetInt, but panics if the key is absent or the value cannot be converted.
+func (c *Config) MustInt(key string) int {
+	return MustGet[int](c, key)
+}
+
+// MustBool is like GetBool, but panics if the key is absent or the value cannot be converted.
+func (c *Config) MustBool(key string) bool {
+	return MustGet[bool](c, key)
+}
+
+// MustFloat64 is like GetFloat64, but panics if the key is absent or the value cannot be converted.
+func (c *Config) MustFloat64(key string) float64 {
+	return MustGet[float64](c, key)
+}
+
+// MustBind is like Bind, but panics if binding or validation fails.
+func (c *Config) MustBind(dest any, options ...BindOption) {
+	if err := c.Bind(dest, options...); err != nil {
+		panic(fmt.Errorf("gcfg: failed to bind configuration: %w", err))
+	}
+}
@@ -0,0 +1,56 @@
+package gcfg_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Must(t *testing.T) {
+	t.Parallel()
+
+	cfg := newGettersConfig(t)
+
+	assert.Equal(t, "localhost", cfg.MustString("database.host"))
+	assert.Equal(t, 5432, cfg.MustInt("database.port"))
+	assert.True(t, cfg.MustBool("debug"))
+	assert.InEpsilon(t, 0.75, cfg.MustFloat64("ratio"), 0)
+	assert.Equal(t, []int{80, 443}, gcfg.MustGet[[]int](cfg, "ports"))
+
+	assert.PanicsWithError(t, "gcfg: key not found: missing", func() {
+		cfg.MustString("missing")
+	})
+	assert.Panics(t, func() {
+		cfg.MustInt("invalid")
+	})
+	assert.Panics(t, func() {
+		cfg.MustBool("missing")
+	})
+	assert.Panics(t, func() {
+		cfg.MustFloat64("invalid")
+	})
+}
+
+func TestConfig_MustBind(t *testing.T) {
+	t.Parallel()
+
+	cfg := newGettersConfig(t)
+
+	var valid struct {
+		Debug bool
+	}
+
+	assert.NotPanics(t, func() {
+		cfg.MustBind(&valid)
+	})
+	assert.True(t, valid.Debug)
+
+	var invalid struct {
+		Missing string `validate:"required"`
+	}
+
+	assert.Panics(t, func() {
+		cfg.MustBind(&invalid)
+	})
+}
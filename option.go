@@ -0,0 +1,91 @@
+package gcfg
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Option configures cross-cutting behavior of a Config, and is passed to New alongside the
+// providers:
+//
+//	cfg := gcfg.New(
+//		gcfg.NewJSONProvider(gcfg.WithJSONFilePath("config.json")),
+//		gcfg.WithLogger(logger),
+//		gcfg.WithoutDefaultEnv(),
+//	)
+//
+// Option implements Provider only so it can be passed to New; options are applied to the Config
+// instead of being registered as providers, and are ignored by AddProvider.
+type Option func(c *Config)
+
+var _ Provider = Option(nil)
+
+// Name implements the Provider interface.
+func (o Option) Name() string {
+	return ""
+}
+
+// Load implements the Provider interface. Options don't supply values.
+func (o Option) Load() (map[string]any, error) {
+	return nil, nil //nolint:nilnil
+}
+
+// WithValidatorInstance sets the go-playground validator used to validate bound structs (see Bind),
+// e.g. one with custom validations registered. It is ignored when WithValidator is given.
+//
+// Default: validator.New().
+func WithValidatorInstance(validate *validator.Validate) Option {
+	return func(c *Config) {
+		if validate != nil {
+			c.validate = validate
+		}
+	}
+}
+
+// WithValidator replaces the go-playground validator used to validate bound structs (see Bind)
+// with v. A nil v disables validation altogether.
+func WithValidator(v Validator) Option {
+	return func(c *Config) {
+		if v == nil {
+			v = noopValidator{}
+		}
+
+		c.structValidator = v
+	}
+}
+
+// WithLogger sets the logger of the Config, see Config.WithLogger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Config) {
+		c.WithLogger(logger)
+	}
+}
+
+// WithClock sets the function returning the current time, used to timestamp loads, reload
+// records and audit events, e.g. to make them deterministic in tests.
+//
+// Default: time.Now.
+func WithClock(now func() time.Time) Option {
+	return func(c *Config) {
+		c.clock = now
+	}
+}
+
+// WithoutDefaultEnv prevents New from registering an environment variables provider when none
+// is given.
+func WithoutDefaultEnv() Option {
+	return func(c *Config) {
+		c.withoutDefaultEnv = true
+	}
+}
+
+// now returns the current time according to the clock set with WithClock.
+func (c *Config) now() time.Time {
+	if c.clock == nil {
+		return time.Now()
+	}
+
+	return c.clock()
+}
@@ -0,0 +1,97 @@
+package gcfg_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_WithoutDefaultEnv(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock"}, gcfg.WithoutDefaultEnv())
+	assert.Equal(t, []string{"mock"}, cfg.Providers())
+
+	cfg.Reset()
+	assert.Empty(t, cfg.Providers())
+
+	assert.Equal(t, []string{"Environment Variables", "mock"}, gcfg.New(&mockProvider{name: "mock"}).Providers())
+}
+
+func TestConfig_AddProvider_IgnoresOptions(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{"a": 1}}, gcfg.WithoutDefaultEnv())
+	cfg.AddProvider(gcfg.WithLogger(slog.New(slog.DiscardHandler)))
+
+	assert.Equal(t, []string{"mock"}, cfg.Providers())
+	require.NoError(t, cfg.Load())
+	assert.Len(t, cfg.ProviderStatuses(), 1)
+	assert.Equal(t, 1, cfg.Get("a"))
+}
+
+func TestNew_WithValidatorInstance(t *testing.T) {
+	t.Parallel()
+
+	validate := validator.New()
+	require.NoError(t, validate.RegisterValidation("even", func(fl validator.FieldLevel) bool {
+		return fl.Field().Int()%2 == 0
+	}))
+
+	cfg := gcfg.New(
+		&mockProvider{name: "mock", data: map[string]any{"workers": 3}},
+		gcfg.WithValidatorInstance(validate),
+	)
+	require.NoError(t, cfg.Load())
+
+	var dest struct {
+		Workers int `validate:"even"`
+	}
+
+	err := cfg.Bind(&dest)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "even")
+}
+
+func TestNew_WithLogger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	cfg := gcfg.New(&mockProvider{name: "mock"}, gcfg.WithLogger(logger))
+	require.NoError(t, cfg.Load())
+
+	assert.Contains(t, buf.String(), `msg="gcfg: provider loaded" provider=mock`)
+}
+
+func TestNew_WithClock(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	var events []gcfg.AuditEvent
+
+	cfg := gcfg.New(
+		&mockProvider{name: "mock", data: map[string]any{"host": "localhost"}},
+		gcfg.WithClock(func() time.Time { return now }),
+	).WithChangeLog(1).OnAudit(func(event gcfg.AuditEvent) {
+		events = append(events, event)
+	})
+	require.NoError(t, cfg.Load())
+	cfg.Set("host", "db.internal")
+
+	history := cfg.ChangeLog()
+	require.Len(t, history, 1)
+	assert.Equal(t, now, history[0].Time)
+
+	require.Len(t, events, 1)
+	assert.Equal(t, now, events[0].Time)
+}
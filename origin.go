@@ -0,0 +1,44 @@
+package gcfg
+
+// SourceProvider is implemented by providers that can describe the source they load from,
+// such as a file path or a URL.
+type SourceProvider interface {
+	Provider
+	Source() string
+}
+
+// Origin describes where the effective value of a configuration key comes from.
+type Origin struct {
+	// Provider is the name of the provider that supplied the value, "Defaults" for values set
+	// via SetDefault or SetDefaults, and "Overrides" for values set via Set.
+	Provider string
+	// Source is the source the provider loaded the value from (e.g., a file path or URL),
+	// empty if the provider doesn't implement SourceProvider.
+	Source string
+}
+
+// Origin returns the origin of the effective value of key, e.g. Origin("database.host").
+// Returns false if key is not a leaf value of the configuration.
+func (c *Config) Origin(key string) (Origin, bool) {
+	if key == "" {
+		return Origin{}, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	origin, ok := c.origins[c.keys.normalize(key)]
+
+	return origin, ok
+}
+
+// providerOrigin returns the origin of the values loaded by p, looking through provider decorators.
+func providerOrigin(p Provider) Origin {
+	origin := Origin{Provider: p.Name()}
+
+	if sp, ok := asProvider[SourceProvider](p); ok {
+		origin.Source = sp.Source()
+	}
+
+	return origin
+}
@@ -0,0 +1,54 @@
+package gcfg_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Origin(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"config.json": &fstest.MapFile{
+			Data: []byte(`{"database": {"host": "localhost", "port": 5432}}`),
+		},
+	}
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{"port": 6432},
+	}}
+
+	cfg := gcfg.New(
+		gcfg.NewJSONProvider(gcfg.WithJSONFilePath("config.json"), gcfg.WithJSONFileFS(&fsys)),
+		gcfg.WithPriority(mockP, 1),
+	)
+	cfg.SetDefault("database.name", "app")
+	require.NoError(t, cfg.Load())
+	cfg.Set("database.user", "admin")
+
+	origin, ok := cfg.Origin("Database.Host")
+	require.True(t, ok)
+	assert.Equal(t, gcfg.Origin{Provider: "JSON", Source: "config.json"}, origin)
+
+	origin, ok = cfg.Origin("database.port")
+	require.True(t, ok)
+	assert.Equal(t, gcfg.Origin{Provider: "mock"}, origin)
+
+	origin, ok = cfg.Origin("database.name")
+	require.True(t, ok)
+	assert.Equal(t, gcfg.Origin{Provider: "Defaults"}, origin)
+
+	origin, ok = cfg.Origin("database.user")
+	require.True(t, ok)
+	assert.Equal(t, gcfg.Origin{Provider: "Overrides"}, origin)
+
+	_, ok = cfg.Origin("database")
+	assert.False(t, ok)
+
+	_, ok = cfg.Origin("missing")
+	assert.False(t, ok)
+}
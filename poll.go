@@ -0,0 +1,94 @@
+package gcfg
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// pollProvider watches a decorated provider by periodically reloading it.
+type pollProvider struct {
+	providerWrapper
+
+	interval time.Duration
+}
+
+var _ WatchableProvider = (*pollProvider)(nil)
+
+// Poll decorates the given provider so that it can be watched (see Config.Watch) by reloading it
+// every interval and emitting its values whenever they differ from the previous load. This lets
+// providers without change notifications (e.g., HTTP endpoints or object storage) participate in
+// hot reload.
+//
+// Failed loads are skipped; the values are emitted once the provider loads successfully again.
+func Poll(p Provider, interval time.Duration) Provider {
+	return &pollProvider{
+		providerWrapper: providerWrapper{Provider: p},
+		interval:        interval,
+	}
+}
+
+// Watch implements the WatchableProvider interface.
+func (p *pollProvider) Watch(ctx context.Context) (<-chan map[string]any, error) {
+	if p.interval <= 0 {
+		return nil, ErrWatchNotSupported
+	}
+
+	ticks := make(chan struct{})
+
+	go func() {
+		defer close(ticks)
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			select {
+			case ticks <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return emitChangedLoads(ctx, ticks, p.Load), nil
+}
+
+// emitChangedLoads reloads the values with load on every trigger, and emits them if they differ
+// from the previously emitted values. The values are baselined before emitChangedLoads returns.
+// Failed loads are skipped. The returned channel is closed once triggers is closed or ctx is done.
+func emitChangedLoads(
+	ctx context.Context,
+	triggers <-chan struct{},
+	load func() (map[string]any, error),
+) <-chan map[string]any {
+	last, _ := load()
+	out := make(chan map[string]any)
+
+	go func() {
+		defer close(out)
+
+		for range triggers {
+			values, err := load()
+			if err != nil || reflect.DeepEqual(values, last) {
+				continue
+			}
+
+			last = values
+
+			select {
+			case out <- values:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,106 @@
+package gcfg_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRemoteProvider is a concurrency-safe provider whose data can be changed while it is polled.
+type mockRemoteProvider struct {
+	mu    sync.Mutex
+	data  map[string]any
+	loads int
+}
+
+func (m *mockRemoteProvider) Name() string {
+	return "remote"
+}
+
+func (m *mockRemoteProvider) Load() (map[string]any, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.loads++
+
+	return map[string]any{"level": m.data["level"]}, nil
+}
+
+func (m *mockRemoteProvider) set(key string, value any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.data[key] = value
+}
+
+func (m *mockRemoteProvider) loadCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.loads
+}
+
+func TestPoll_EmitsOnlyChanges(t *testing.T) {
+	t.Parallel()
+
+	remote := &mockRemoteProvider{data: map[string]any{"level": "info"}}
+
+	p, ok := gcfg.Poll(remote, time.Millisecond).(gcfg.WatchableProvider)
+	require.True(t, ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch, err := p.Watch(ctx)
+	require.NoError(t, err)
+
+	// Unchanged loads are not emitted.
+	assert.Eventually(t, func() bool {
+		return remote.loadCount() > 3
+	}, time.Second, time.Millisecond)
+	assert.Empty(t, ch)
+
+	remote.set("level", "debug")
+
+	select {
+	case values := <-ch:
+		assert.Equal(t, map[string]any{"level": "debug"}, values)
+	case <-time.After(time.Second):
+		t.Fatal("expected polled values")
+	}
+}
+
+func TestPoll_ConfigWatch(t *testing.T) {
+	t.Parallel()
+
+	remote := &mockRemoteProvider{data: map[string]any{"level": "info"}}
+
+	cfg := gcfg.New(gcfg.Poll(remote, time.Millisecond))
+	require.NoError(t, cfg.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, cfg.Watch(ctx))
+
+	remote.set("level", "debug")
+
+	assert.Eventually(t, func() bool {
+		return cfg.Get("level") == "debug"
+	}, time.Second, time.Millisecond)
+}
+
+func TestPoll_InvalidInterval(t *testing.T) {
+	t.Parallel()
+
+	p, ok := gcfg.Poll(&mockRemoteProvider{}, 0).(gcfg.WatchableProvider)
+	require.True(t, ok)
+
+	_, err := p.Watch(context.Background())
+	assert.ErrorIs(t, err, gcfg.ErrWatchNotSupported)
+}
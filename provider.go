@@ -1,5 +1,9 @@
 package gcfg
 
+import (
+	"context"
+)
+
 // Provider defines the interface for configuration providers.
 // Implement this interface to create custom providers like env, json, yml, etc.
 type Provider interface {
@@ -8,3 +12,30 @@ type Provider interface {
 	// Keys should be hierarchical paths (e.g., "database.host").
 	Load() (map[string]any, error)
 }
+
+// ValidatableProvider is an optional interface implemented by providers that can check their
+// configuration up front, so misconfiguration is reported by NewE rather than by the first load.
+type ValidatableProvider interface {
+	Provider
+	// Validate returns an error describing the misconfiguration of the provider, if any.
+	Validate() error
+}
+
+// WritableProvider is an optional interface implemented by providers backed by a store that
+// accepts writes (e.g., Consul, etcd, Redis or SQL), so runtime overrides can be persisted back
+// to the authoritative store with Config.Push.
+type WritableProvider interface {
+	Provider
+	// Write persists value under the given dotted key (e.g., "database.host").
+	Write(ctx context.Context, key string, value any) error
+}
+
+// WatchableProvider is an optional interface implemented by providers that can detect changes in
+// their source, enabling hot reload with Config.Watch.
+type WatchableProvider interface {
+	Provider
+	// Watch starts watching the source and returns a channel that receives the full, freshly
+	// loaded values whenever they change. The channel must be closed once ctx is done.
+	// Returns ErrWatchNotSupported if the provider cannot watch its source.
+	Watch(ctx context.Context) (<-chan map[string]any, error)
+}
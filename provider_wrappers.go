@@ -0,0 +1,297 @@
+package gcfg
+
+import (
+	"context"
+	stdmaps "maps"
+	"regexp"
+	"slices"
+	"sort"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+)
+
+const (
+	// keyPathSeparator is the separator used to join nested keys into a single path.
+	keyPathSeparator = "."
+
+	// DefaultPriority is the priority of providers that do not declare one.
+	DefaultPriority = 0
+)
+
+// PrioritizedProvider is implemented by providers that declare their merge priority.
+// Providers with a higher priority override providers with a lower priority; providers
+// with the same priority keep their registration order.
+type PrioritizedProvider interface {
+	Provider
+	Priority() int
+}
+
+// KeyTransformFunc rewrites a provider key before it is merged into the configuration.
+// The key is the full path of a leaf value, delimited by the key delimiter of the Config (e.g.,
+// "database.host", see WithKeyDelimiter), with delimiters within segments escaped with a
+// backslash (e.g., `labels.app\.kubernetes\.io/name`); returning an empty string drops the value.
+type KeyTransformFunc func(key string) string
+
+// providerWrapper is the base for providers that decorate another provider.
+type providerWrapper struct {
+	Provider
+}
+
+// Unwrap returns the decorated provider.
+func (w *providerWrapper) Unwrap() Provider {
+	return w.Provider
+}
+
+// Watch implements the WatchableProvider interface by forwarding to the decorated provider.
+func (w *providerWrapper) Watch(ctx context.Context) (<-chan map[string]any, error) {
+	wp, ok := w.Provider.(WatchableProvider)
+	if !ok {
+		return nil, ErrWatchNotSupported
+	}
+
+	return wp.Watch(ctx)
+}
+
+// watchAndProcess watches the decorated provider and processes every emitted value with process.
+// Values that fail to be processed are skipped.
+func (w *providerWrapper) watchAndProcess(
+	ctx context.Context,
+	process func(map[string]any) (map[string]any, error),
+) (<-chan map[string]any, error) {
+	in, err := w.Watch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan map[string]any)
+
+	go func() {
+		defer close(out)
+
+		for values := range in {
+			processed, pErr := process(values)
+			if pErr != nil {
+				continue
+			}
+
+			select {
+			case out <- processed:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// keyTransformProvider rewrites the keys of a decorated provider.
+type keyTransformProvider struct {
+	providerWrapper
+
+	transform KeyTransformFunc
+
+	// sep is the key delimiter of the Config the provider is registered with, see
+	// propagateKeyDelimiter.
+	sep string
+}
+
+var _ WatchableProvider = (*keyTransformProvider)(nil)
+
+// WithKeyTransform decorates the given provider so that every key it loads is rewritten by
+// transform before being merged into the configuration. This is useful to strip prefixes,
+// rename legacy keys or convert naming styles (e.g., kebab-case to snake_case).
+//
+// The returned keys are split on the key delimiter to rebuild the configuration hierarchy, so a
+// transform may also move values between sections (e.g., "db.host" -> "database.host"). When
+// several keys are rewritten to the same key, the value of the last key in sorted order wins.
+func WithKeyTransform(p Provider, transform KeyTransformFunc) Provider {
+	return &keyTransformProvider{
+		providerWrapper: providerWrapper{Provider: p},
+		transform:       transform,
+	}
+}
+
+// Load implements the Provider interface.
+func (p *keyTransformProvider) Load() (map[string]any, error) {
+	values, err := p.Provider.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.transformKeys(values), nil
+}
+
+// Watch implements the WatchableProvider interface.
+func (p *keyTransformProvider) Watch(ctx context.Context) (<-chan map[string]any, error) {
+	return p.watchAndProcess(ctx, func(values map[string]any) (map[string]any, error) {
+		return p.transformKeys(values), nil
+	})
+}
+
+func (p *keyTransformProvider) transformKeys(values map[string]any) map[string]any {
+	if p.transform == nil {
+		return values
+	}
+
+	sep := p.delimiter()
+	flat := maps.Flatten(values, sep)
+
+	transformed := make(map[string]any, len(flat))
+	for _, k := range slices.Sorted(stdmaps.Keys(flat)) {
+		if newKey := p.transform(k); newKey != "" {
+			transformed[newKey] = flat[k]
+		}
+	}
+
+	return maps.Expand(transformed, sep)
+}
+
+// delimiter returns the key delimiter of the Config the provider is registered with, or "." if
+// unregistered.
+func (p *keyTransformProvider) delimiter() string {
+	if p.sep == "" {
+		return keyPathSeparator
+	}
+
+	return p.sep
+}
+
+// setKeyDelimiter implements the keyDelimiterSetter interface.
+func (p *keyTransformProvider) setKeyDelimiter(sep string) {
+	p.sep = sep
+}
+
+// keyDelimiterSetter is implemented by provider decorators handling keys, so they can inherit the
+// key delimiter of the Config they are registered with.
+type keyDelimiterSetter interface {
+	setKeyDelimiter(sep string)
+}
+
+// propagateKeyDelimiter passes sep to every provider decorator handling keys in the decorator
+// chain of p.
+func propagateKeyDelimiter(p Provider, sep string) {
+	for p != nil {
+		if ks, ok := p.(keyDelimiterSetter); ok {
+			ks.setKeyDelimiter(sep)
+		}
+
+		w, ok := p.(interface{ Unwrap() Provider })
+		if !ok {
+			return
+		}
+
+		p = w.Unwrap()
+	}
+}
+
+// WithAllowedKeys decorates the given provider so that it only contributes the keys matching one of
+// the glob patterns (or nested under a matching key), so an untrusted source can't override other
+// settings, e.g. database credentials:
+//
+//	gcfg.WithAllowedKeys(gcfg.NewEnvProvider(), "logging.*", "server.*")
+//
+// Patterns are matched case-insensitively against the full keys, delimited by the key delimiter of
+// the Config (see WithKeyDelimiter), "*" matches any sequence of characters, including the
+// delimiter, and "?" any single character. Other keys are dropped.
+func WithAllowedKeys(p Provider, patterns ...string) Provider {
+	return filterKeys(p, patterns, true)
+}
+
+// WithDeniedKeys decorates the given provider so that the keys matching one of the glob patterns (or
+// nested under a matching key) are dropped, e.g. WithDeniedKeys(p, "database.*", "*password*").
+// Patterns are matched like those of WithAllowedKeys.
+func WithDeniedKeys(p Provider, patterns ...string) Provider {
+	return filterKeys(p, patterns, false)
+}
+
+// filterKeys decorates p so that it only contributes the keys matching one of the glob patterns if
+// allow is true, or the other keys if allow is false.
+func filterKeys(p Provider, patterns []string, allow bool) Provider {
+	compiled := compileKeyGlobs(patterns)
+
+	kp := &keyTransformProvider{providerWrapper: providerWrapper{Provider: p}}
+	kp.transform = func(key string) string {
+		if matchesKeyOrParent(compiled, key, kp.delimiter()) != allow {
+			return ""
+		}
+
+		return key
+	}
+
+	return kp
+}
+
+// compileKeyGlobs compiles the glob patterns of keys, see compileKeyGlob.
+func compileKeyGlobs(patterns []string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, len(patterns))
+	for i, pattern := range patterns {
+		compiled[i] = compileKeyGlob(pattern)
+	}
+
+	return compiled
+}
+
+// priorityProvider assigns an explicit priority to a decorated provider.
+type priorityProvider struct {
+	providerWrapper
+
+	priority int
+}
+
+var _ PrioritizedProvider = (*priorityProvider)(nil)
+
+// WithPriority decorates the given provider with an explicit merge priority, so the override
+// order is declared rather than implied by the registration order.
+// Providers with a higher priority override providers with a lower priority.
+//
+// The implicit environment provider has DefaultPriority; to control its position, register
+// an environment provider explicitly, e.g. WithPriority(NewEnvProvider(), 100).
+func WithPriority(p Provider, priority int) Provider {
+	return &priorityProvider{
+		providerWrapper: providerWrapper{Provider: p},
+		priority:        priority,
+	}
+}
+
+// Priority implements the PrioritizedProvider interface.
+func (p *priorityProvider) Priority() int {
+	return p.priority
+}
+
+// asProvider finds the first provider in the decorator chain of p that implements T.
+func asProvider[T any](p Provider) (T, bool) {
+	for p != nil {
+		if target, ok := p.(T); ok {
+			return target, true
+		}
+
+		w, ok := p.(interface{ Unwrap() Provider })
+		if !ok {
+			break
+		}
+
+		p = w.Unwrap()
+	}
+
+	var zero T
+
+	return zero, false
+}
+
+// providerPriority returns the priority of p, looking through provider decorators.
+func providerPriority(p Provider) int {
+	if pp, ok := asProvider[PrioritizedProvider](p); ok {
+		return pp.Priority()
+	}
+
+	return DefaultPriority
+}
+
+// sortProviders stably sorts providers by ascending priority, so that higher priority
+// providers are merged last.
+func sortProviders(providers []*providerEntry) {
+	sort.SliceStable(providers, func(i, j int) bool {
+		return providerPriority(providers[i].Provider) < providerPriority(providers[j].Provider)
+	})
+}
@@ -0,0 +1,239 @@
+package gcfg_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithKeyTransform_RewritesKeys(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"legacy": map[string]any{
+			"db-host": "localhost",
+		},
+		"log-level": "debug",
+	}}
+
+	cfg := gcfg.New(gcfg.WithKeyTransform(mockP, func(key string) string {
+		key = strings.ReplaceAll(key, "-", "_")
+		if rest, ok := strings.CutPrefix(key, "legacy.db_"); ok {
+			return "database." + rest
+		}
+
+		return key
+	}))
+
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "localhost", cfg.Get("database.host"))
+	assert.Equal(t, "debug", cfg.Get("log_level"))
+	assert.Nil(t, cfg.Get("legacy"))
+}
+
+func TestWithKeyTransform_DropsEmptyKeys(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"keep":   "value",
+		"ignore": "value",
+	}}
+
+	cfg := gcfg.New(gcfg.WithKeyTransform(mockP, func(key string) string {
+		if key == "ignore" {
+			return ""
+		}
+
+		return key
+	}))
+
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "value", cfg.Get("keep"))
+	assert.Nil(t, cfg.Get("ignore"))
+}
+
+func TestWithKeyTransform_KeepsDottedKeys(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"labels": map[string]any{"app.kubernetes.io/name": "api"},
+	}}
+
+	cfg := gcfg.New(gcfg.WithKeyTransform(mockP, strings.ToLower), gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, map[string]any{"app.kubernetes.io/name": "api"}, cfg.Get("labels"))
+	assert.Equal(t, "api", cfg.Get(`labels.app\.kubernetes\.io/name`))
+}
+
+func TestWithKeyTransform_KeyDelimiter(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"hosts": map[string]any{"api.example.com": map[string]any{"port": 8080}},
+	}}
+
+	var seen []string
+
+	cfg := gcfg.New(gcfg.WithKeyTransform(mockP, func(key string) string {
+		seen = append(seen, key)
+
+		return key
+	}), gcfg.WithKeyDelimiter("/"), gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, []string{"hosts/api.example.com/port"}, seen)
+	assert.Equal(t, 8080, cfg.Get("hosts/api.example.com/port"))
+}
+
+func TestWithKeyTransform_CollidingKeys(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"log-level": "debug",
+		"log_level": "info",
+	}}
+
+	for range 20 {
+		cfg := gcfg.New(gcfg.WithKeyTransform(mockP, func(key string) string {
+			return strings.ReplaceAll(key, "-", "_")
+		}), gcfg.WithoutDefaultEnv())
+		require.NoError(t, cfg.Load())
+
+		// the last key in sorted order wins
+		assert.Equal(t, "info", cfg.Get("log_level"))
+	}
+}
+
+func TestWithKeyTransform_PreservesNameAndErrors(t *testing.T) {
+	t.Parallel()
+
+	//nolint:err113
+	mockP := &mockProvider{name: "mock", err: errors.New("load failed")}
+	p := gcfg.WithKeyTransform(mockP, strings.ToUpper)
+
+	assert.Equal(t, "mock", p.Name())
+
+	_, err := p.Load()
+	require.Error(t, err)
+}
+
+func TestWithAllowedKeys_DropsOtherKeys(t *testing.T) {
+	t.Parallel()
+
+	trusted := &mockProvider{name: "trusted", data: map[string]any{
+		"database": map[string]any{"password": "secret"},
+	}}
+	untrusted := &mockProvider{name: "untrusted", data: map[string]any{
+		"logging":  map[string]any{"level": "debug", "format": map[string]any{"color": true}},
+		"server":   map[string]any{"port": 9090},
+		"database": map[string]any{"password": "hijacked"},
+		"debug":    true,
+	}}
+
+	cfg := gcfg.New(trusted, gcfg.WithAllowedKeys(untrusted, "logging.*", "SERVER"))
+
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "debug", cfg.Get("logging.level"))
+	assert.Equal(t, true, cfg.Get("logging.format.color"))
+	assert.Equal(t, 9090, cfg.Get("server.port"))
+	assert.Equal(t, "secret", cfg.Get("database.password"))
+	assert.Nil(t, cfg.Get("debug"))
+}
+
+func TestWithDeniedKeys_DropsMatchingKeys(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{"host": "localhost", "admin_password": "secret"},
+		"payments": map[string]any{"api": map[string]any{"key": "sk_live"}},
+	}}
+
+	cfg := gcfg.New(gcfg.WithDeniedKeys(mockP, "*password", "payments"))
+
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "localhost", cfg.Get("database.host"))
+	assert.Nil(t, cfg.Get("database.admin_password"))
+	assert.Nil(t, cfg.Get("payments"))
+}
+
+func TestWithAllowedKeys_KeyDelimiter(t *testing.T) {
+	t.Parallel()
+
+	data := map[string]any{
+		"hosts":    map[string]any{"api.example.com": map[string]any{"port": 8080}},
+		"database": map[string]any{"password": "secret"},
+	}
+
+	allowed := gcfg.New(
+		gcfg.WithAllowedKeys(&mockProvider{name: "mock", data: data}, "hosts/*"),
+		gcfg.WithKeyDelimiter("/"),
+		gcfg.WithoutDefaultEnv(),
+	)
+	require.NoError(t, allowed.Load())
+
+	assert.Equal(t, 8080, allowed.Get("hosts/api.example.com/port"))
+	assert.Nil(t, allowed.Get("database/password"))
+
+	denied := gcfg.New(
+		gcfg.WithDeniedKeys(&mockProvider{name: "mock", data: data}, "database/*"),
+		gcfg.WithKeyDelimiter("/"),
+		gcfg.WithoutDefaultEnv(),
+	)
+	require.NoError(t, denied.Load())
+
+	assert.Equal(t, 8080, denied.Get("hosts/api.example.com/port"))
+	assert.Nil(t, denied.Get("database/password"))
+	assert.Nil(t, denied.Get("database"))
+}
+
+func TestWithPriority_OverridesRegistrationOrder(t *testing.T) {
+	t.Parallel()
+
+	high := &mockProvider{name: "high", data: map[string]any{"key": "high"}}
+	low := &mockProvider{name: "low", data: map[string]any{"key": "low", "other": "low"}}
+
+	cfg := gcfg.New(gcfg.WithPriority(high, 100), low)
+
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "high", cfg.Get("key"))
+	assert.Equal(t, "low", cfg.Get("other"))
+}
+
+func TestWithPriority_SamePriorityKeepsOrder(t *testing.T) {
+	t.Parallel()
+
+	first := &mockProvider{name: "first", data: map[string]any{"key": "first"}}
+	second := &mockProvider{name: "second", data: map[string]any{"key": "second"}}
+
+	cfg := gcfg.New(gcfg.WithPriority(first, 10), gcfg.WithPriority(second, 10))
+
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "second", cfg.Get("key"))
+}
+
+func TestWithPriority_ThroughOtherDecorators(t *testing.T) {
+	t.Parallel()
+
+	high := &mockProvider{name: "high", data: map[string]any{"key": "high"}}
+	low := &mockProvider{name: "low", data: map[string]any{"key": "low"}}
+
+	cfg := gcfg.New(
+		gcfg.WithKeyTransform(gcfg.WithPriority(high, 1), strings.ToLower),
+		low,
+	)
+
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "high", cfg.Get("key"))
+}
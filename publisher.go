@@ -0,0 +1,63 @@
+package gcfg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrPublishComputeFailed indicates failure to compute a value to publish.
+var ErrPublishComputeFailed = errors.New("failed to compute published value")
+
+// LeaderElector reports whether the current instance is the leader of its cluster.
+type LeaderElector interface {
+	IsLeader(ctx context.Context) bool
+}
+
+// LeaderFunc is an adapter to allow the use of ordinary functions as leader electors.
+type LeaderFunc func(ctx context.Context) bool
+
+// IsLeader implements the LeaderElector interface.
+func (f LeaderFunc) IsLeader(ctx context.Context) bool {
+	return f(ctx)
+}
+
+// Publisher distributes computed configuration (e.g., derived shard maps) to a cluster through a
+// writable provider. Only the leader instance publishes; followers consume the published values
+// by registering the same provider in their Config.
+type Publisher struct {
+	provider WritableProvider
+	leader   LeaderElector
+}
+
+// NewPublisher creates a publisher writing to provider whenever leader reports leadership.
+func NewPublisher(provider WritableProvider, leader LeaderElector) *Publisher {
+	return &Publisher{
+		provider: provider,
+		leader:   leader,
+	}
+}
+
+// Publish computes and writes the value of key if the current instance is the leader, and
+// reports whether it was published. compute is only called on the leader, so expensive
+// derivations are not repeated by every instance.
+func (p *Publisher) Publish(
+	ctx context.Context,
+	key string,
+	compute func(ctx context.Context) (any, error),
+) (bool, error) {
+	if !p.leader.IsLeader(ctx) {
+		return false, nil
+	}
+
+	value, err := compute(ctx)
+	if err != nil {
+		return false, fmt.Errorf("%w %s: %w", ErrPublishComputeFailed, key, err)
+	}
+
+	if wErr := p.provider.Write(ctx, key, value); wErr != nil {
+		return false, fmt.Errorf("%w %s: %w", ErrProviderWriteFailed, p.provider.Name(), wErr)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,96 @@
+package gcfg_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublisher_Leader(t *testing.T) {
+	t.Parallel()
+
+	store := &mockWritableProvider{mockProvider: mockProvider{name: "store"}}
+	publisher := gcfg.NewPublisher(store, gcfg.LeaderFunc(func(context.Context) bool {
+		return true
+	}))
+
+	published, err := publisher.Publish(
+		context.Background(),
+		"shards",
+		func(context.Context) (any, error) {
+			return []any{"a", "b"}, nil
+		},
+	)
+	require.NoError(t, err)
+
+	assert.True(t, published)
+	assert.Equal(t, map[string]any{"shards": []any{"a", "b"}}, store.written)
+}
+
+func TestPublisher_Follower(t *testing.T) {
+	t.Parallel()
+
+	store := &mockWritableProvider{mockProvider: mockProvider{name: "store"}}
+	publisher := gcfg.NewPublisher(store, gcfg.LeaderFunc(func(context.Context) bool {
+		return false
+	}))
+
+	published, err := publisher.Publish(
+		context.Background(),
+		"shards",
+		func(context.Context) (any, error) {
+			t.Fatal("compute must not be called on followers")
+
+			return nil, nil
+		},
+	)
+	require.NoError(t, err)
+
+	assert.False(t, published)
+	assert.Nil(t, store.written)
+}
+
+func TestPublisher_Errors(t *testing.T) {
+	t.Parallel()
+
+	leader := gcfg.LeaderFunc(func(context.Context) bool {
+		return true
+	})
+
+	//nolint:err113
+	computeErr := errors.New("compute failed")
+
+	publisher := gcfg.NewPublisher(
+		&mockWritableProvider{mockProvider: mockProvider{name: "store"}},
+		leader,
+	)
+
+	_, err := publisher.Publish(
+		context.Background(),
+		"shards",
+		func(context.Context) (any, error) {
+			return nil, computeErr
+		},
+	)
+	require.ErrorIs(t, err, gcfg.ErrPublishComputeFailed)
+	require.ErrorIs(t, err, computeErr)
+
+	//nolint:err113
+	failing := &mockWritableProvider{
+		mockProvider: mockProvider{name: "store"},
+		writeErr:     errors.New("write failed"),
+	}
+
+	_, err = gcfg.NewPublisher(failing, leader).Publish(
+		context.Background(),
+		"shards",
+		func(context.Context) (any, error) {
+			return "value", nil
+		},
+	)
+	require.ErrorIs(t, err, gcfg.ErrProviderWriteFailed)
+}
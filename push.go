@@ -0,0 +1,71 @@
+package gcfg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrKeyNotFound indicates that the requested key does not exist in the configuration.
+	ErrKeyNotFound = errors.New("key not found")
+
+	// ErrNoWritableProvider indicates that no registered provider implements WritableProvider.
+	ErrNoWritableProvider = errors.New("no writable provider registered")
+
+	// ErrProviderWriteFailed indicates failure to write a value to a provider.
+	ErrProviderWriteFailed = errors.New("failed to write to provider")
+)
+
+// Push persists the current value of key to the authoritative store.
+//
+// The value is written to the provider that supplied it if that provider implements
+// WritableProvider, and otherwise to the highest priority writable provider. This is typically
+// used after Set to keep runtime overrides consistent across a fleet.
+func (c *Config) Push(ctx context.Context, key string) error {
+	value, exists := c.Find(key)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrKeyNotFound, key)
+	}
+
+	target, err := c.writableProviderFor(c.keys.normalize(key))
+	if err != nil {
+		return err
+	}
+
+	if wErr := target.Write(ctx, key, value); wErr != nil {
+		return fmt.Errorf("%w %s: %w", ErrProviderWriteFailed, target.Name(), wErr)
+	}
+
+	return nil
+}
+
+// writableProviderFor returns the writable provider that should receive writes for key.
+func (c *Config) writableProviderFor(key string) (WritableProvider, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var fallback WritableProvider
+
+	origin := c.origins[key].Provider
+
+	for _, p := range c.providers {
+		wp, ok := asProvider[WritableProvider](p.Provider)
+		if !ok {
+			continue
+		}
+
+		if p.Name() == origin {
+			return wp, nil
+		}
+
+		// Providers are sorted by priority, the last one has the highest priority.
+		fallback = wp
+	}
+
+	if fallback == nil {
+		return nil, ErrNoWritableProvider
+	}
+
+	return fallback, nil
+}
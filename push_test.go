@@ -0,0 +1,91 @@
+package gcfg_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockWritableProvider is a mock implementation of the WritableProvider interface for testing.
+type mockWritableProvider struct {
+	mockProvider
+
+	written  map[string]any
+	writeErr error
+}
+
+func (m *mockWritableProvider) Write(_ context.Context, key string, value any) error {
+	if m.writeErr != nil {
+		return m.writeErr
+	}
+
+	if m.written == nil {
+		m.written = make(map[string]any)
+	}
+
+	m.written[key] = value
+
+	return nil
+}
+
+func TestConfig_Push_ToOriginProvider(t *testing.T) {
+	t.Parallel()
+
+	origin := &mockWritableProvider{mockProvider: mockProvider{
+		name: "origin",
+		data: map[string]any{"pool": 5},
+	}}
+	other := &mockWritableProvider{mockProvider: mockProvider{name: "other"}}
+
+	cfg := gcfg.New(origin, other)
+
+	require.NoError(t, cfg.Load())
+
+	require.NoError(t, cfg.Push(context.Background(), "pool"))
+
+	assert.Equal(t, map[string]any{"pool": 5}, origin.written)
+	assert.Nil(t, other.written)
+}
+
+func TestConfig_Push_ToHighestPriorityProvider(t *testing.T) {
+	t.Parallel()
+
+	low := &mockWritableProvider{mockProvider: mockProvider{name: "low"}}
+	high := &mockWritableProvider{mockProvider: mockProvider{name: "high"}}
+
+	cfg := gcfg.New(gcfg.WithPriority(high, 10), low)
+
+	require.NoError(t, cfg.Load())
+
+	cfg.Set("feature.enabled", true)
+
+	require.NoError(t, cfg.Push(context.Background(), "feature.enabled"))
+
+	assert.Equal(t, map[string]any{"feature.enabled": true}, high.written)
+	assert.Nil(t, low.written)
+}
+
+func TestConfig_Push_Errors(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New()
+	cfg.Set("key", "value")
+
+	require.ErrorIs(t, cfg.Push(context.Background(), "missing"), gcfg.ErrKeyNotFound)
+	require.ErrorIs(t, cfg.Push(context.Background(), "key"), gcfg.ErrNoWritableProvider)
+
+	//nolint:err113
+	failing := &mockWritableProvider{
+		mockProvider: mockProvider{name: "failing"},
+		writeErr:     errors.New("write failed"),
+	}
+	cfg.AddProvider(failing)
+
+	err := cfg.Push(context.Background(), "key")
+	require.ErrorIs(t, err, gcfg.ErrProviderWriteFailed)
+	assert.Contains(t, err.Error(), "write failed")
+}
@@ -0,0 +1,290 @@
+package gcfg
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/ahmedkamalio/gcfg/internal/reflection"
+)
+
+// redactedValue replaces secret values in redacted output.
+const redactedValue = "[REDACTED]"
+
+// secretKeyFragments lists the key fragments identifying secret values.
+var secretKeyFragments = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"apikey",
+	"api_key",
+	"privatekey",
+	"private_key",
+	"credential",
+}
+
+// WithSecret marks keys (and everything nested under them) as secret, so their values are
+// masked in Values, in the output of Handler and in the logs, while Get and Bind still return the
+// real values. Fields tagged `secret:"true"` are marked as secret once their struct is bound
+// (e.g., by Bind or BindKey):
+//
+//	type DatabaseConfig struct {
+//		Host     string
+//		Password string `secret:"true"`
+//	}
+func (c *Config) WithSecret(keys ...string) *Config {
+	normalized := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		if key != "" {
+			normalized = append(normalized, c.keys.normalize(key))
+		}
+	}
+
+	c.markSecret(normalized...)
+
+	return c
+}
+
+// markSecret marks the normalized keys as secret.
+func (c *Config) markSecret(keys ...string) {
+	for {
+		current := c.secrets.Load()
+
+		var secrets []string
+		if current != nil {
+			secrets = slices.Clone(*current)
+		}
+
+		added := false
+
+		for _, key := range keys {
+			if !slices.Contains(secrets, key) {
+				secrets = append(secrets, key)
+				added = true
+			}
+		}
+
+		if !added || c.secrets.CompareAndSwap(current, &secrets) {
+			return
+		}
+	}
+}
+
+// markSecretFields marks as secret the keys of the fields of dest tagged `secret:"true"`, nested
+// under the dotted key prefix.
+func (c *Config) markSecretFields(prefix string, dest any, opts BindOptions) {
+	sep := c.keys.sep()
+
+	keys := maps.TaggedKeys(reflect.TypeOf(dest), "secret", sep, opts.binder())
+	if len(keys) == 0 {
+		return
+	}
+
+	for i, key := range keys {
+		if prefix != "" {
+			key = prefix + sep + key
+		}
+
+		keys[i] = c.keys.normalize(key)
+	}
+
+	c.markSecret(keys...)
+}
+
+// WithSensitiveKeys marks the keys matching the glob patterns (and everything nested under them) as
+// secret, like WithSecret, including keys that are never bound to a struct. Patterns are matched
+// case-insensitively against the full dotted keys, and "*" matches any sequence of characters,
+// including the key separator, and "?" any single character, e.g.
+//
+//	gcfg.WithSensitiveKeys("*password*", "*token*", "*secret*", "payments.*.key")
+func WithSensitiveKeys(patterns ...string) Option {
+	return func(c *Config) {
+		for _, pattern := range patterns {
+			c.sensitiveKeys = append(c.sensitiveKeys, compileKeyGlob(pattern))
+		}
+	}
+}
+
+// compileKeyGlob compiles the glob pattern of keys to a case-insensitive regular expression, where
+// "*" matches any sequence of characters and "?" any single character.
+func compileKeyGlob(pattern string) *regexp.Regexp {
+	expr := strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(pattern))
+
+	return regexp.MustCompile("(?i)^" + expr + "$")
+}
+
+// WithSensitiveKeyRegexp is like WithSensitiveKeys, with the keys matched by regular expressions
+// against the full normalized dotted keys, e.g. regexp.MustCompile(`(^|\.)(dsn|private_key)$`).
+func WithSensitiveKeyRegexp(patterns ...*regexp.Regexp) Option {
+	return func(c *Config) {
+		c.sensitiveKeys = append(c.sensitiveKeys, patterns...)
+	}
+}
+
+// isMarkedSecret reports whether the normalized key is, or is nested under, a key marked as
+// secret or matching a sensitive key pattern.
+func (c *Config) isMarkedSecret(key string) bool {
+	if c.isSensitiveKey(key) {
+		return true
+	}
+
+	secrets := c.secrets.Load()
+	if secrets == nil {
+		return false
+	}
+
+	for _, secret := range *secrets {
+		if key == secret || strings.HasPrefix(key, secret+c.keys.sep()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSensitiveKey reports whether the normalized key, or one of its parents, matches a sensitive
+// key pattern, see WithSensitiveKeys.
+func (c *Config) isSensitiveKey(key string) bool {
+	return matchesKeyOrParent(c.sensitiveKeys, key, c.keys.sep())
+}
+
+// matchesKeyOrParent reports whether key, or one of its parents split on sep, matches one of
+// patterns.
+func matchesKeyOrParent(patterns []*regexp.Regexp, key, sep string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+
+	segments := maps.SplitPath(key, sep)
+
+	for n := len(segments); n > 0; n-- {
+		key = maps.JoinSegments(segments[:n], sep)
+
+		for _, pattern := range patterns {
+			if pattern.MatchString(key) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isSecretKey reports whether key looks like it holds a secret value.
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+
+	for _, fragment := range secretKeyFragments {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// redactValues returns a copy of values, nested under the normalized dotted key prefix, in which
+// the values of keys marked as secret, and of keys looking like secrets if heuristic is true, are
+// replaced with redactedValue.
+func (c *Config) redactValues(values map[string]any, prefix string, heuristic bool) map[string]any {
+	redacted := make(map[string]any, len(values))
+
+	for k, v := range values {
+		path := maps.JoinPath(prefix, k, c.keys.sep())
+
+		if (heuristic && isSecretKey(k)) || c.isMarkedSecret(path) {
+			redacted[k] = redactedValue
+
+			continue
+		}
+
+		redacted[k] = c.redactValue(v, path, heuristic)
+	}
+
+	return redacted
+}
+
+func (c *Config) redactValue(value any, path string, heuristic bool) any {
+	switch val := value.(type) {
+	case map[string]any:
+		return c.redactValues(val, path, heuristic)
+	case []any:
+		redacted := make([]any, len(val))
+		for i, item := range val {
+			redacted[i] = c.redactValue(item, path, heuristic)
+		}
+
+		return redacted
+	case string:
+		if heuristic {
+			return redactURLPassword(val)
+		}
+
+		return val
+	default:
+		return val
+	}
+}
+
+// redactURLPassword returns value with the password of the URL it holds, if any, masked, e.g.
+// "postgres://app:xxxxx@db/app".
+func redactURLPassword(value string) string {
+	if !strings.Contains(value, "://") {
+		return value
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return value
+	}
+
+	if _, ok := u.User.Password(); !ok {
+		return value
+	}
+
+	return u.Redacted()
+}
+
+// RedactedValues returns a copy of the configuration values like Values, in which the values of
+// keys looking like secrets (e.g., "password", "api_key" or "token") and the passwords of URLs are
+// masked too, for dumping the configuration for diagnostics.
+func (c *Config) RedactedValues() map[string]any {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.exportValues(c.redactValues(reflection.Clone(c.values), "", true))
+}
+
+// String implements the fmt.Stringer interface, formatting RedactedValues as JSON, so printing or
+// logging the Config never leaks secrets.
+func (c *Config) String() string {
+	data, err := c.MarshalJSON()
+	if err != nil {
+		return fmt.Sprint(c.RedactedValues())
+	}
+
+	return string(data)
+}
+
+// MarshalJSON implements the json.Marshaler interface, marshaling RedactedValues.
+func (c *Config) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.RedactedValues())
+}
+
+// maskedValue returns the value at key for logging, masking it if key is marked as secret or
+// looks like it holds a secret.
+func (c *Config) maskedValue(key string, value any) any {
+	key = c.keys.normalize(key)
+	if isSecretKey(key) || c.isMarkedSecret(key) {
+		return redactedValue
+	}
+
+	return c.redactValue(value, key, true)
+}
@@ -0,0 +1,154 @@
+package gcfg_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_WithSecret(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{"host": "localhost", "dsn": "postgres://u:p@db"},
+		"stripe":   map[string]any{"key": "sk_live"},
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithLogger(logger), gcfg.WithoutDefaultEnv()).WithSecret("Stripe")
+	require.NoError(t, cfg.Load())
+
+	var dbCfg struct {
+		Host string
+		DSN  string `secret:"true"`
+	}
+
+	require.NoError(t, cfg.BindKey("database", &dbCfg))
+	assert.Equal(t, "postgres://u:p@db", dbCfg.DSN)
+	assert.Equal(t, "postgres://u:p@db", cfg.Get("database.dsn"))
+
+	assert.Equal(t, map[string]any{
+		"database": map[string]any{"host": "localhost", "dsn": "[REDACTED]"},
+		"stripe":   "[REDACTED]",
+	}, cfg.Values())
+	assert.Equal(t, map[string]any{"host": "localhost", "dsn": "[REDACTED]"}, cfg.Sub("database").Values())
+
+	rec := httptest.NewRecorder()
+	gcfg.Handler(cfg).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/config", nil))
+
+	var resp struct {
+		Values map[string]any `json:"values"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "[REDACTED]", resp.Values["database"].(map[string]any)["dsn"])
+	assert.Equal(t, "[REDACTED]", resp.Values["stripe"])
+
+	buf.Reset()
+
+	provider.data = map[string]any{
+		"database": map[string]any{"host": "localhost", "dsn": "postgres://u:rotated@db"},
+		"stripe":   map[string]any{"key": "sk_rotated"},
+	}
+	require.NoError(t, cfg.Load())
+
+	out := buf.String()
+	assert.Contains(t, out, "key=database.dsn")
+	assert.NotContains(t, out, "postgres://")
+	assert.NotContains(t, out, "sk_")
+
+	// Merging copies the real values.
+	merged := gcfg.New(gcfg.WithoutDefaultEnv())
+	require.NoError(t, merged.MergeFrom(cfg))
+	assert.Equal(t, "sk_rotated", merged.Get("stripe.key"))
+}
+
+func TestConfig_RedactedValues(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{
+			"host":     "localhost",
+			"password": "s3cr3t",
+			"url":      "postgres://app:s3cr3t@db/app",
+		},
+		"github_token": "ghp_123",
+		"stripe":       map[string]any{"key": "sk_live"},
+		"servers":      []any{"https://api.example.com"},
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithoutDefaultEnv()).WithSecret("stripe.key")
+	require.NoError(t, cfg.Load())
+
+	want := map[string]any{
+		"database": map[string]any{
+			"host":     "localhost",
+			"password": "[REDACTED]",
+			"url":      "postgres://app:xxxxx@db/app",
+		},
+		"github_token": "[REDACTED]",
+		"stripe":       map[string]any{"key": "[REDACTED]"},
+		"servers":      []any{"https://api.example.com"},
+	}
+	assert.Equal(t, want, cfg.RedactedValues())
+
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(data), cfg.String())
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, want, got)
+
+	assert.NotContains(t, fmt.Sprintf("%v", cfg), "s3cr3t")
+
+	// the values are unchanged
+	assert.Equal(t, "s3cr3t", cfg.Get("database.password"))
+}
+
+func TestWithSensitiveKeys(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{"host": "localhost", "dsn": "postgres://db", "admin_password": "pw"},
+		"payments": map[string]any{"stripe": map[string]any{"key": "sk_live", "account": "acct"}},
+		"vault":    map[string]any{"role": "app"},
+	}}
+
+	cfg := gcfg.New(provider,
+		gcfg.WithLogger(logger),
+		gcfg.WithoutDefaultEnv(),
+		gcfg.WithSensitiveKeys("*PASSWORD*", "payments.*.key", "vault"),
+		gcfg.WithSensitiveKeyRegexp(regexp.MustCompile(`(^|\.)dsn$`)),
+	)
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, map[string]any{
+		"database": map[string]any{"host": "localhost", "dsn": "[REDACTED]", "admin_password": "[REDACTED]"},
+		"payments": map[string]any{"stripe": map[string]any{"key": "[REDACTED]", "account": "acct"}},
+		"vault":    "[REDACTED]",
+	}, cfg.Values())
+	assert.Equal(t, "[REDACTED]", cfg.RedactedValues()["vault"])
+	assert.Equal(t, "sk_live", cfg.Get("payments.stripe.key"))
+
+	buf.Reset()
+
+	provider.data = map[string]any{"payments": map[string]any{"stripe": map[string]any{"key": "sk_rotated"}}}
+	require.NoError(t, cfg.Load())
+	assert.NotContains(t, buf.String(), "sk_")
+}
@@ -0,0 +1,426 @@
+package gcfg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	stdmaps "maps"
+	"strings"
+	"time"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/ahmedkamalio/gcfg/internal/reflection"
+)
+
+const (
+	// defaultsOriginName is the origin of values set via SetDefault and SetDefaults.
+	defaultsOriginName = "Defaults"
+	// overridesOriginName is the origin of values set via Set.
+	overridesOriginName = "Overrides"
+)
+
+// configUpdate holds the next configuration state computed from freshly loaded provider values.
+type configUpdate struct {
+	values  map[string]any
+	origins map[string]Origin
+	// results holds the freshly loaded provider values.
+	results map[*providerEntry]map[string]any
+	// changes lists the changes applied by the update.
+	changes []Change
+	// withheld lists the changes to restart-required keys withheld from the update.
+	withheld []Change
+	// defaults and overrides replace the corresponding layers if not nil.
+	defaults, overrides map[string]any
+	// spellings replaces the original key spellings if not nil, see WithPreserveKeyCase.
+	spellings map[string]string
+	// rev is the revision of the configuration the update was computed from.
+	rev uint64
+	// err is the error expanding or resolving the references of the values, see WithEnvExpansion
+	// and WithInterpolation.
+	err error
+}
+
+// reload runs a load cycle: it executes the pre-load hooks, loads provider values with load,
+// applies the resulting update to the registered components, commits it and executes the
+// post-load hooks.
+func (c *Config) reload(
+	ctx context.Context,
+	load func() (map[*providerEntry]map[string]any, error),
+) (err error) {
+	c.loadMu.Lock()
+	defer c.loadMu.Unlock()
+
+	if c.IsFrozen() {
+		return ErrConfigFrozen
+	}
+
+	if sink := c.metricsSink(); sink != nil {
+		start := time.Now()
+
+		defer func() {
+			sink.ObserveLoad(time.Since(start), err)
+		}()
+	}
+
+	for _, ext := range c.extensions {
+		if pErr := ext.PreLoad(ctx, c); pErr != nil {
+			return fmt.Errorf("%w %s: %w", ErrExtensionPreLoadHookFailed, ext.Name(), pErr)
+		}
+	}
+
+	results, err := load()
+	if err != nil {
+		return err
+	}
+
+	if err = c.applyUpdate(ctx, func() *configUpdate {
+		return c.prepareUpdate(results)
+	}); err != nil {
+		return err
+	}
+
+	for _, ext := range c.extensions {
+		if pErr := ext.PostLoad(ctx, c); pErr != nil {
+			return fmt.Errorf("%w %s: %w", ErrExtensionPostLoadHookFailed, ext.Name(), pErr)
+		}
+	}
+
+	return nil
+}
+
+// maxUpdateAttempts bounds the attempts of applyUpdate to apply an update to components while the
+// configuration is modified concurrently.
+const maxUpdateAttempts = 3
+
+// applyUpdate computes an update with prepare, checks it against the schema (see WithSchema),
+// applies it to the registered components, commits it and notifies the listeners. prepare is
+// called with c.mu held. If the configuration was modified while the components were applied, the
+// whole cycle is retried with a recomputed update; after maxUpdateAttempts, the update is
+// abandoned with ErrConcurrentModification rather than committed without having been applied to
+// the components. If the update fails, the components it was applied to are restored to the
+// current configuration. The caller must hold c.loadMu.
+func (c *Config) applyUpdate(ctx context.Context, prepare func() *configUpdate) error {
+	// applied holds the changes of the updates applied to the components, but not committed.
+	var applied *configUpdate
+
+	fail := func(err error) error {
+		if applied == nil {
+			return err
+		}
+
+		return errors.Join(err, c.restoreComponents(ctx, applied))
+	}
+
+	for attempt := 1; ; attempt++ {
+		c.mu.Lock()
+		update := prepare()
+		c.mu.Unlock()
+
+		if update.err != nil {
+			return fail(update.err)
+		}
+
+		if err := c.checkSchema(update.values); err != nil {
+			return fail(err)
+		}
+
+		if err := c.applyComponents(ctx, update); err != nil {
+			return fail(err)
+		}
+
+		if applied == nil {
+			applied = &configUpdate{}
+		}
+
+		applied.changes = append(applied.changes, update.changes...)
+
+		c.mu.Lock()
+
+		if update.rev != c.rev {
+			c.mu.Unlock()
+
+			if attempt < maxUpdateAttempts {
+				continue
+			}
+
+			return fail(fmt.Errorf("%w after %d attempts", ErrConcurrentModification, attempt))
+		}
+
+		c.commitAndNotify(update)
+
+		return nil
+	}
+}
+
+// commitAndNotify commits update and notifies the listeners. The caller must hold c.mu, which is
+// released.
+func (c *Config) commitAndNotify(update *configUpdate) {
+	var notifications []func()
+	if c.loaded {
+		notifications = c.prepareNotifications(c.values, update.values, update.changes)
+	}
+
+	c.commitUpdate(update)
+	bindErrs := c.rebindLive()
+	c.mu.Unlock()
+
+	if sink := c.metricsSink(); sink != nil {
+		sink.IncReload()
+	}
+
+	c.logReload(update)
+
+	for _, bErr := range bindErrs {
+		c.reportReloadError(bErr)
+	}
+
+	for _, notify := range notifications {
+		notify()
+	}
+}
+
+// logReload logs the changes applied by update.
+func (c *Config) logReload(update *configUpdate) {
+	logger := c.log()
+
+	for _, change := range update.changes {
+		logger.Debug("gcfg: key "+change.Type.String(),
+			"key", change.Key,
+			"old", c.maskedValue(change.Key, change.OldValue),
+			"new", c.maskedValue(change.Key, change.NewValue),
+			"provider", change.Provider,
+		)
+	}
+
+	for _, change := range update.withheld {
+		logger.Warn("gcfg: change to restart-required key withheld", "key", change.Key)
+	}
+
+	logger.Debug("gcfg: configuration reloaded", "changes", len(update.changes))
+}
+
+// logOverriddenKeys logs the keys of values overriding the ones already recorded in origins by
+// another provider or layer. Values retained from the previous load of the same provider are not
+// reported.
+func (c *Config) logOverriddenKeys(logger *slog.Logger, origins map[string]Origin, values map[string]any, origin Origin) {
+	for key, value := range maps.Flatten(values, c.keys.sep()) {
+		if previous, overridden := origins[c.keys.normalize(key)]; overridden && previous.Provider != origin.Provider {
+			logger.Debug("gcfg: key overridden",
+				"key", key,
+				"value", c.maskedValue(key, value),
+				"provider", origin.Provider,
+				"previous", previous.Provider,
+			)
+		}
+	}
+}
+
+// prepareUpdate computes the next configuration state by merging the defaults, the values
+// retained from the current configuration (see retainedValues), the overrides and the provider
+// values, which override the values set with Set. Providers missing from results contribute the
+// values they last loaded. The caller must hold c.mu.
+func (c *Config) prepareUpdate(results map[*providerEntry]map[string]any) *configUpdate {
+	next := reflection.Clone(c.defaults)
+	nextOrigins := make(map[string]Origin)
+	c.keys.recordOrigins(nextOrigins, next, Origin{Provider: defaultsOriginName})
+
+	retained, retainedOrigins := c.retainedValues()
+	c.keys.merge(next, retained)
+	stdmaps.Copy(nextOrigins, retainedOrigins)
+
+	overridesOrigin := Origin{Provider: overridesOriginName}
+	overrides := reflection.Clone(c.overrides)
+	c.keys.merge(next, overrides)
+	c.keys.recordOrigins(nextOrigins, overrides, overridesOrigin)
+
+	var spellings map[string]string
+	if c.keySpellings != nil {
+		spellings = stdmaps.Clone(c.keySpellings)
+	}
+
+	logger := c.log()
+	logOverrides := logger.Enabled(context.Background(), slog.LevelDebug)
+
+	// errs holds the errors expanding and resolving references
+	var errs []error
+
+	// literal holds the keys whose values are taken literally rather than resolved as references:
+	// the retained values, which are resolved already, the values set with Set, and the values of
+	// the default environment variables provider, which loads the whole environment, with
+	// unrelated values that may not be valid references
+	literal := make(map[string]bool)
+	c.markLiteral(literal, retained, true)
+	c.markLiteral(literal, overrides, true)
+
+	for _, p := range c.providers {
+		values, ok := results[p]
+		if !ok {
+			values = p.values
+		}
+
+		origin := providerOrigin(p.Provider)
+
+		if logOverrides {
+			c.logOverriddenKeys(logger, nextOrigins, values, origin)
+		}
+
+		// Merge values, later providers override
+		values = reflection.Clone(values)
+
+		if (c.envExpansion || c.fileReferences) && !p.defaultEnv {
+			if eErr := c.expandProviderValues(values); eErr != nil {
+				errs = append(errs, fmt.Errorf("provider %s: %w", p.Name(), eErr))
+			}
+		}
+
+		if c.decryptionKey != nil {
+			if dErr := c.decryptProviderValues(values); dErr != nil {
+				errs = append(errs, fmt.Errorf("provider %s: %w", p.Name(), dErr))
+			}
+		}
+
+		c.keys.merge(next, values)
+		c.keys.recordOrigins(nextOrigins, values, origin)
+		c.markLiteral(literal, values, p.defaultEnv)
+		c.dropOverrides(overrides, values)
+
+		if spellings != nil {
+			c.keys.recordSpellings(spellings, values, "", true)
+		}
+	}
+
+	// the references are resolved once all the layers are merged, so they see the effective
+	// configuration
+	if c.interpolation {
+		errs = append(errs, c.interpolateValues(next, literal))
+	}
+
+	changes := c.diffWith(next, nextOrigins)
+
+	var withheld []Change
+	if c.loaded {
+		changes, withheld = c.withholdRestartRequired(next, nextOrigins, changes)
+	}
+
+	return &configUpdate{
+		values:    next,
+		origins:   nextOrigins,
+		results:   results,
+		changes:   changes,
+		withheld:  withheld,
+		overrides: overrides,
+		spellings: spellings,
+		rev:       c.rev,
+		err:       errors.Join(errs...),
+	}
+}
+
+// retainedValues returns the values of the current configuration supplied by the registered
+// providers, with their origins. They are kept by a load even if the providers no longer supply
+// them, whereas the values of removed providers are dropped. The caller must hold c.mu.
+func (c *Config) retainedValues() (map[string]any, map[string]Origin) {
+	registered := make(map[string]bool, len(c.providers))
+	for _, p := range c.providers {
+		registered[p.Name()] = true
+	}
+
+	values := make(map[string]any)
+	origins := make(map[string]Origin)
+
+	for key, value := range maps.Flatten(c.values, c.keys.sep()) {
+		key = c.keys.normalize(key)
+
+		origin, ok := c.origins[key]
+		if !ok || !registered[origin.Provider] {
+			continue
+		}
+
+		maps.SetNested(values, c.keys.segments(key), reflection.Clone(value))
+		origins[key] = origin
+	}
+
+	return values, origins
+}
+
+// dropOverrides removes from overrides the keys supplied by values, loaded by a provider, whose
+// values replace the ones set with Set for good.
+func (c *Config) dropOverrides(overrides, values map[string]any) {
+	for key := range maps.Flatten(values, c.keys.sep()) {
+		maps.DeleteNested(overrides, c.keys.segments(c.keys.normalize(key)))
+	}
+}
+
+// markLiteral marks the keys of values as taken literally in literal if isLiteral is true, and as
+// resolved otherwise.
+func (c *Config) markLiteral(literal map[string]bool, values map[string]any, isLiteral bool) {
+	for key := range maps.Flatten(values, c.keys.sep()) {
+		if key = c.keys.normalize(key); isLiteral {
+			literal[key] = true
+		} else {
+			delete(literal, key)
+		}
+	}
+}
+
+// diffWith computes the changes from the current configuration to next, attributed to the
+// providers that supplied them. The caller must hold c.mu.
+func (c *Config) diffWith(next map[string]any, nextOrigins map[string]Origin) []Change {
+	return attributedDiff(c.values, next, c.origins, nextOrigins, c.keys.sep())
+}
+
+// commitUpdate makes the given update the live configuration. The caller must hold c.mu.
+func (c *Config) commitUpdate(update *configUpdate) {
+	now := c.now()
+
+	for p, values := range update.results {
+		p.values = values
+		p.loadedAt = now
+		p.lastErr = nil
+	}
+
+	if update.defaults != nil {
+		c.defaults = update.defaults
+	}
+
+	if update.overrides != nil {
+		c.overrides = update.overrides
+	}
+
+	if update.spellings != nil {
+		c.keySpellings = update.spellings
+	}
+
+	c.values = update.values
+	c.origins = update.origins
+	c.pendingRestart = update.withheld
+	c.loaded = true
+	c.loadedAt = now
+	c.rev++
+
+	if c.changeLog != nil {
+		c.changeLog.add(ReloadRecord{Time: now, Changes: update.changes})
+	}
+}
+
+// recordValueOrigins records origin as the origin of value set at key, replacing the origins of
+// any values previously nested under key. The caller must hold c.mu.
+func (c *Config) recordValueOrigins(key string, value any, origin Origin) {
+	key = c.keys.normalize(key)
+
+	for k := range c.origins {
+		if k == key || strings.HasPrefix(k, key+c.keys.sep()) {
+			delete(c.origins, k)
+		}
+	}
+
+	if m, ok := value.(map[string]any); ok && len(m) > 0 {
+		for k := range maps.Flatten(m, c.keys.sep()) {
+			c.origins[key+c.keys.sep()+c.keys.normalize(k)] = origin
+		}
+
+		return
+	}
+
+	c.origins[key] = origin
+}
@@ -0,0 +1,87 @@
+package gcfg
+
+import (
+	"time"
+
+	"github.com/ahmedkamalio/gcfg/internal/reflection"
+)
+
+// ResetOptions defines options for resetting a Config.
+type ResetOptions struct {
+	keepProviders bool
+	keepDefaults  bool
+}
+
+// ResetOption is a functional option for configuring Reset behavior by modifying ResetOptions.
+type ResetOption func(*ResetOptions)
+
+// WithKeepProviders sets whether Reset keeps the registered providers.
+func WithKeepProviders(keep bool) ResetOption {
+	return func(o *ResetOptions) {
+		o.keepProviders = keep
+	}
+}
+
+// WithKeepDefaults sets whether Reset keeps the values set via SetDefault and SetDefaults.
+func WithKeepDefaults(keep bool) ResetOption {
+	return func(o *ResetOptions) {
+		o.keepDefaults = keep
+	}
+}
+
+// Reset clears all configuration values, returning the Config to the state it was in after New,
+// so it can be re-initialized with Load.
+//
+// By default, the registered providers are replaced with the default environment variables
+// provider (unless WithoutDefaultEnv was given to New), and the defaults are cleared; use WithKeepProviders and WithKeepDefaults to keep them.
+// Overrides set via Set are always cleared. Extensions, components, listeners and hooks stay
+// registered, and, as with the first load, the listeners aren't notified of the next load.
+func (c *Config) Reset(options ...ResetOption) {
+	if c.ignoreFrozen("Reset", "") {
+		return
+	}
+
+	opts := &ResetOptions{}
+	for _, option := range options {
+		option(opts)
+	}
+
+	c.loadMu.Lock()
+	defer c.loadMu.Unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if opts.keepProviders {
+		for _, p := range c.providers {
+			p.values = nil
+			p.loadedAt = time.Time{}
+			p.lastErr = nil
+			p.errCount = 0
+		}
+	} else {
+		c.providers = nil
+
+		if !c.withoutDefaultEnv {
+			c.providers = append(c.providers, c.defaultEnvProvider())
+		}
+	}
+
+	if !opts.keepDefaults {
+		c.defaults = make(map[string]any)
+	}
+
+	c.values = reflection.Clone(c.defaults)
+	c.overrides = make(map[string]any)
+	c.origins = make(map[string]Origin)
+	c.keys.recordOrigins(c.origins, c.values, Origin{Provider: defaultsOriginName})
+
+	if c.keySpellings != nil {
+		c.keySpellings = make(map[string]string)
+	}
+
+	c.pendingRestart = nil
+	c.loaded = false
+	c.loadedAt = time.Time{}
+	c.rev++
+}
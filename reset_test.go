@@ -0,0 +1,82 @@
+package gcfg_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Reset(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{"reset_host": "localhost"}}
+
+	cfg := gcfg.New(provider)
+	cfg.SetDefault("reset_port", 5432)
+	require.NoError(t, cfg.Load())
+	cfg.Set("reset_level", "debug")
+
+	version := cfg.Version()
+
+	cfg.Reset()
+
+	assert.Empty(t, cfg.Values())
+	assert.Greater(t, cfg.Version(), version)
+
+	for _, status := range cfg.ProviderStatuses() {
+		assert.NotEqual(t, "mock", status.Name)
+	}
+
+	require.NoError(t, cfg.Load())
+	assert.False(t, cfg.IsSet("reset_host"))
+	assert.False(t, cfg.IsSet("reset_port"))
+}
+
+func TestConfig_Reset_KeepProvidersAndDefaults(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{"host": "localhost"}}
+
+	cfg := gcfg.New(provider)
+	cfg.SetDefault("port", 5432)
+	require.NoError(t, cfg.Load())
+	cfg.Set("level", "debug")
+
+	cfg.Reset(gcfg.WithKeepProviders(true), gcfg.WithKeepDefaults(true))
+
+	assert.Equal(t, map[string]any{"port": 5432}, cfg.Values())
+
+	origin, ok := cfg.Origin("port")
+	require.True(t, ok)
+	assert.Equal(t, "Defaults", origin.Provider)
+
+	for _, status := range cfg.ProviderStatuses() {
+		assert.True(t, status.LastLoad.IsZero())
+	}
+
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, "localhost", cfg.Get("host"))
+	assert.Equal(t, 5432, cfg.Get("port"))
+	assert.False(t, cfg.IsSet("level"))
+}
+
+func TestConfig_Reset_ListenersSkipNextLoad(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{"host": "localhost"}}
+
+	calls := 0
+
+	cfg := gcfg.New(provider).OnChange("host", func(_, _ any) { calls++ })
+	require.NoError(t, cfg.Load())
+
+	cfg.Reset(gcfg.WithKeepProviders(true))
+
+	provider.data = map[string]any{"host": "db.internal"}
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "db.internal", cfg.Get("host"))
+	assert.Zero(t, calls)
+}
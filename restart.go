@@ -0,0 +1,91 @@
+package gcfg
+
+import (
+	"strings"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+)
+
+// WithRestartRequired marks keys (and everything nested under them) as restart-required.
+//
+// The first Load applies all values, but on subsequent loads changes to restart-required keys
+// are withheld from the live configuration and reported by PendingRestart instead, so that
+// components which cannot honor them at runtime keep a consistent view.
+// Keys that are not marked are hot-reloadable.
+func (c *Config) WithRestartRequired(keys ...string) *Config {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+
+		c.restartKeys = append(c.restartKeys, c.keys.normalize(key))
+	}
+
+	return c
+}
+
+// PendingRestart returns the changes to restart-required keys that were withheld by the latest
+// Load, with OldValue holding the live value and NewValue the value that will apply after a restart.
+func (c *Config) PendingRestart() []Change {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return append([]Change{}, c.pendingRestart...)
+}
+
+// isRestartRequired reports whether the given normalized key is marked as restart-required.
+func (c *Config) isRestartRequired(key string) bool {
+	for _, rk := range c.restartKeys {
+		if key == rk || strings.HasPrefix(key, rk+c.keys.sep()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withholdRestartRequired reverts changes to restart-required keys in next and returns the
+// applied and withheld changes.
+func (c *Config) withholdRestartRequired(
+	next map[string]any,
+	nextOrigins map[string]Origin,
+	changes []Change,
+) (applied, withheld []Change) {
+	if len(c.restartKeys) == 0 {
+		return changes, nil
+	}
+
+	applied = make([]Change, 0, len(changes))
+
+	for _, change := range changes {
+		if c.isRestartRequired(change.Key) {
+			withheld = append(withheld, change)
+		} else {
+			applied = append(applied, change)
+		}
+	}
+
+	// Revert additions first, so that restoring removed or updated values
+	// can recreate parent maps replaced by a withheld scalar.
+	for _, change := range withheld {
+		if change.Type == ChangeAdded {
+			maps.DeleteNested(next, c.keys.segments(change.Key))
+			delete(nextOrigins, change.Key)
+		}
+	}
+
+	for _, change := range withheld {
+		if change.Type != ChangeAdded {
+			maps.SetNested(next, c.keys.segments(change.Key), change.OldValue)
+
+			if origin, ok := c.origins[change.Key]; ok {
+				nextOrigins[change.Key] = origin
+			}
+		}
+	}
+
+	return applied, withheld
+}
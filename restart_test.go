@@ -0,0 +1,90 @@
+package gcfg_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_WithRestartRequired_AppliesInitialLoad(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"server": map[string]any{"port": 8080},
+	}}
+
+	cfg := gcfg.New(mockP).WithRestartRequired("server.port")
+
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, 8080, cfg.Get("server.port"))
+	assert.Empty(t, cfg.PendingRestart())
+}
+
+func TestConfig_WithRestartRequired_WithholdsChanges(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"server":  map[string]any{"port": 8080},
+		"logging": map[string]any{"level": "info"},
+	}}
+
+	cfg := gcfg.New(mockP).WithRestartRequired("Server")
+
+	require.NoError(t, cfg.Load())
+
+	mockP.data = map[string]any{
+		"server":  map[string]any{"port": 9090, "host": "0.0.0.0"},
+		"logging": map[string]any{"level": "debug"},
+	}
+
+	require.NoError(t, cfg.Load())
+
+	// Hot-reloadable keys are applied.
+	assert.Equal(t, "debug", cfg.Get("logging.level"))
+
+	// Restart-required keys are withheld.
+	assert.Equal(t, 8080, cfg.Get("server.port"))
+	assert.Nil(t, cfg.Get("server.host"))
+
+	assert.Equal(t, []gcfg.Change{
+		{Key: "server.host", Type: gcfg.ChangeAdded, NewValue: "0.0.0.0", Provider: "mock"},
+		{
+			Key:      "server.port",
+			Type:     gcfg.ChangeUpdated,
+			OldValue: 8080,
+			NewValue: 9090,
+			Provider: "mock",
+		},
+	}, cfg.PendingRestart())
+
+	// Reverting the source clears the pending report.
+	mockP.data = map[string]any{
+		"server": map[string]any{"port": 8080},
+	}
+
+	require.NoError(t, cfg.Load())
+
+	assert.Empty(t, cfg.PendingRestart())
+}
+
+func TestConfig_WithRestartRequired_ExcludedFromChangeLog(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{"port": 8080, "level": "info"}}
+
+	cfg := gcfg.New(mockP).WithRestartRequired("port").WithChangeLog(5)
+
+	require.NoError(t, cfg.Load())
+
+	mockP.data = map[string]any{"port": 9090, "level": "debug"}
+
+	require.NoError(t, cfg.Load())
+
+	records := cfg.ChangeLog()
+	require.Len(t, records, 2)
+	require.Len(t, records[1].Changes, 1)
+	assert.Equal(t, "level", records[1].Changes[0].Key)
+}
@@ -0,0 +1,391 @@
+package gcfg
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	stdmaps "maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+)
+
+// SampleFormat is the format of a sample configuration file, see GenerateSample.
+type SampleFormat int
+
+const (
+	// SampleJSON renders a JSON document. JSON has no comments, so field docs are omitted.
+	SampleJSON SampleFormat = iota
+	// SampleYAML renders a YAML document, with field docs as comments.
+	SampleYAML
+	// SampleDotEnv renders a .env file, with field docs as comments.
+	SampleDotEnv
+)
+
+// ErrUnsupportedSampleFormat indicates that a sample configuration format is unknown.
+var ErrUnsupportedSampleFormat = errors.New("unsupported sample format")
+
+// GenerateSample renders a sample configuration file in the given format from the fields of
+// dest, a struct or a pointer to one, so new deployments can start from a complete skeleton:
+//
+//	type AppConfig struct {
+//		Server struct {
+//			Port int `gcfg:"port" default:"8080" doc:"Port the HTTP server listens on"`
+//		} `doc:"HTTP server settings"`
+//	}
+//
+//	sample, err := gcfg.GenerateSample(AppConfig{}, gcfg.SampleYAML)
+//
+// Fields are keyed the same way as by Bind, and their `doc` tag, `required` tag and deprecation
+// message are rendered as comments. Each key holds the value of its field in dest, or its
+// `default` tag value if the field is zero. Slices are comma-separated in the .env format, whose
+// variable names are built with the prefix and separator of the given environment variables
+// provider options (e.g. WithEnvPrefix("APP_") renders server.port as APP_SERVER__PORT).
+func GenerateSample(dest any, format SampleFormat, envOptions ...EnvOption) ([]byte, error) {
+	nodes, err := sampleFields(dest)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	switch format {
+	case SampleJSON:
+		writeJSONSample(&buf, nodes, "")
+		buf.WriteByte('\n')
+	case SampleYAML:
+		writeYAMLSample(&buf, nodes, "")
+	case SampleDotEnv:
+		env := NewEnvProvider(envOptions...)
+		if err = env.Validate(); err != nil {
+			return nil, err
+		}
+
+		writeDotEnvSample(&buf, nodes, env.prefix, env.separator, nil)
+	default:
+		return nil, fmt.Errorf("%w %d", ErrUnsupportedSampleFormat, format)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// sampleFields returns the fields of dest, a struct or a pointer to one, with the default
+// values of their tags in place of their zero values.
+func sampleFields(dest any) ([]maps.FieldNode, error) {
+	rv := reflect.ValueOf(dest)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return nil, maps.ErrDestMustPointToStruct
+	}
+
+	defaults := reflect.New(rv.Type())
+	if err := maps.BindValue(map[string]any{}, defaults.Interface(), maps.BindOptions{}); err != nil {
+		// every key is missing from the empty configuration, only invalid defaults are errors
+		if err = withoutMissingKeys(err); err != nil {
+			return nil, err
+		}
+	}
+
+	nodes := maps.FieldTree(rv, maps.BindOptions{})
+	fillZeroFields(nodes, maps.FieldTree(defaults.Elem(), maps.BindOptions{}))
+
+	return nodes, nil
+}
+
+// withoutMissingKeys returns err, joined bind errors, without the errors of missing keys.
+func withoutMissingKeys(err error) error {
+	joined, ok := err.(interface{ Unwrap() []error }) //nolint:errorlint
+	if !ok {
+		if errors.Is(err, ErrMissingRequiredKey) {
+			return nil
+		}
+
+		return err
+	}
+
+	return errors.Join(slices.DeleteFunc(joined.Unwrap(), func(e error) bool {
+		return errors.Is(e, ErrMissingRequiredKey)
+	})...)
+}
+
+// fillZeroFields replaces the zero values of nodes with the values of the same fields in defaults.
+func fillZeroFields(nodes, defaults []maps.FieldNode) {
+	for i := range nodes {
+		if nodes[i].Children != nil {
+			fillZeroFields(nodes[i].Children, defaults[i].Children)
+		} else if nodes[i].Value.IsZero() {
+			nodes[i].Value = defaults[i].Value
+		}
+	}
+}
+
+// fieldComments returns the comment lines describing the field of node.
+func fieldComments(node maps.FieldNode) []string {
+	var lines []string
+
+	if doc := node.Field.Tag.Get("doc"); doc != "" {
+		lines = append(lines, strings.Split(doc, "\n")...)
+	}
+
+	if required, _ := strconv.ParseBool(node.Field.Tag.Get("required")); required {
+		lines = append(lines, "Required.")
+	}
+
+	if msg := node.Field.Tag.Get("deprecated"); msg != "" {
+		lines = append(lines, "Deprecated: "+msg)
+	}
+
+	return lines
+}
+
+// writeJSONSample writes nodes as the members of a JSON object indented by indent.
+func writeJSONSample(buf *bytes.Buffer, nodes []maps.FieldNode, indent string) {
+	if len(nodes) == 0 {
+		buf.WriteString("{}")
+
+		return
+	}
+
+	buf.WriteString("{\n")
+
+	for i, node := range nodes {
+		key, _ := json.Marshal(node.Key)
+
+		buf.WriteString(indent + "  ")
+		buf.Write(key)
+		buf.WriteString(": ")
+
+		if node.Children != nil {
+			writeJSONSample(buf, node.Children, indent+"  ")
+		} else {
+			buf.Write(sampleJSON(node.Value, indent+"  ", "  "))
+		}
+
+		if i < len(nodes)-1 {
+			buf.WriteByte(',')
+		}
+
+		buf.WriteByte('\n')
+	}
+
+	buf.WriteString(indent + "}")
+}
+
+// writeYAMLSample writes nodes as the entries of a YAML mapping indented by indent.
+func writeYAMLSample(buf *bytes.Buffer, nodes []maps.FieldNode, indent string) {
+	for _, node := range nodes {
+		for _, line := range fieldComments(node) {
+			buf.WriteString(indent + "# " + line + "\n")
+		}
+
+		switch {
+		case len(node.Children) > 0:
+			buf.WriteString(indent + node.Key + ":\n")
+			writeYAMLSample(buf, node.Children, indent+"  ")
+		case node.Children != nil:
+			buf.WriteString(indent + node.Key + ": {}\n")
+		default:
+			// JSON scalars and flow collections are valid YAML
+			buf.WriteString(indent + node.Key + ": ")
+			buf.Write(sampleJSON(node.Value, "", ""))
+			buf.WriteByte('\n')
+		}
+	}
+}
+
+// writeDotEnvSample writes nodes, nested under the keys path, as environment variables.
+func writeDotEnvSample(buf *bytes.Buffer, nodes []maps.FieldNode, prefix, sep string, path []string) {
+	for _, node := range nodes {
+		keyPath := append(slices.Clip(path), node.Key)
+
+		if node.Children != nil {
+			if comments := fieldComments(node); len(comments) > 0 {
+				if buf.Len() > 0 {
+					buf.WriteByte('\n')
+				}
+
+				for _, line := range comments {
+					buf.WriteString("# " + line + "\n")
+				}
+			}
+
+			writeDotEnvSample(buf, node.Children, prefix, sep, keyPath)
+
+			continue
+		}
+
+		for _, line := range fieldComments(node) {
+			buf.WriteString("# " + line + "\n")
+		}
+
+		writeDotEnvValue(buf, envVarName(prefix, sep, keyPath), sampleValue(node.Value), sep)
+	}
+}
+
+// writeDotEnvValue writes the variable name with value v. Slices of scalars are comma-separated,
+// and the entries of maps and other slices are written as nested variables.
+func writeDotEnvValue(buf *bytes.Buffer, name string, v any, sep string) {
+	switch v := v.(type) {
+	case map[string]any:
+		for _, key := range slices.Sorted(stdmaps.Keys(v)) {
+			writeDotEnvValue(buf, name+sep+strings.ToUpper(key), v[key], sep)
+		}
+	case []any:
+		if slices.ContainsFunc(v, isComposite) {
+			for i, item := range v {
+				writeDotEnvValue(buf, name+sep+strconv.Itoa(i), item, sep)
+			}
+
+			return
+		}
+
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+
+		buf.WriteString(name + "=" + quoteDotEnv(strings.Join(items, ",")) + "\n")
+	case nil:
+		buf.WriteString(name + "=\n")
+	default:
+		buf.WriteString(name + "=" + quoteDotEnv(fmt.Sprint(v)) + "\n")
+	}
+}
+
+// isComposite reports whether the sample value v is a map or a slice.
+func isComposite(v any) bool {
+	switch v.(type) {
+	case map[string]any, []any:
+		return true
+	default:
+		return false
+	}
+}
+
+// envVarName returns the name of the environment variable of the key path.
+func envVarName(prefix, sep string, path []string) string {
+	return prefix + strings.ToUpper(strings.Join(path, sep))
+}
+
+// quoteDotEnv quotes the .env value str if it contains spaces, comment markers or quotes.
+func quoteDotEnv(str string) string {
+	if !strings.ContainsAny(str, " \t#\"'") {
+		return str
+	}
+
+	if !strings.Contains(str, `"`) {
+		return `"` + str + `"`
+	}
+
+	return "'" + str + "'"
+}
+
+// sampleJSON returns the JSON encoding of the sample value of v, see json.MarshalIndent.
+func sampleJSON(v reflect.Value, prefix, indent string) []byte {
+	var (
+		data []byte
+		err  error
+	)
+
+	if indent == "" {
+		data, err = json.Marshal(sampleValue(v))
+	} else {
+		data, err = json.MarshalIndent(sampleValue(v), prefix, indent)
+	}
+
+	if err != nil {
+		return []byte("null")
+	}
+
+	return data
+}
+
+// sampleValue returns v as a JSON value: durations and types implementing encoding.TextMarshaler
+// or fmt.Stringer are given as strings, maps are keyed by strings, and structs are given as
+// objects keyed like by Bind.
+func sampleValue(v reflect.Value) any {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+
+		v = v.Elem()
+	}
+
+	if !v.IsValid() {
+		return nil
+	}
+
+	if v.Type() == reflect.TypeFor[time.Duration]() {
+		return time.Duration(v.Int()).String()
+	}
+
+	// copy v so that methods with pointer receivers can be called
+	ptr := reflect.New(v.Type())
+	ptr.Elem().Set(v)
+
+	if m, ok := ptr.Interface().(encoding.TextMarshaler); ok {
+		if text, err := m.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Slice, reflect.Array:
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = sampleValue(v.Index(i))
+		}
+
+		return items
+	case reflect.Map:
+		m := make(map[string]any, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			m[fmt.Sprint(iter.Key().Interface())] = sampleValue(iter.Value())
+		}
+
+		return m
+	case reflect.Struct:
+		if s, ok := ptr.Interface().(fmt.Stringer); ok {
+			return s.String()
+		}
+
+		return sampleObject(maps.FieldTree(v, maps.BindOptions{}))
+	default:
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// sampleObject returns nodes as a JSON object, e.g. for the structs of slice elements.
+func sampleObject(nodes []maps.FieldNode) map[string]any {
+	m := make(map[string]any, len(nodes))
+
+	for _, node := range nodes {
+		if node.Children != nil {
+			m[node.Key] = sampleObject(node.Children)
+		} else {
+			m[node.Key] = sampleValue(node.Value)
+		}
+	}
+
+	return m
+}
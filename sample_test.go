@@ -0,0 +1,120 @@
+package gcfg_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sampleConfig struct {
+	Server struct {
+		Host    string        `gcfg:"host" default:"localhost" doc:"Host the HTTP server binds to"`
+		Port    int           `gcfg:"port" default:"8080" required:"true"`
+		Timeout time.Duration `gcfg:"timeout" default:"5s"`
+	} `gcfg:"server" doc:"HTTP server settings"`
+	Tags      []string          `gcfg:"tags" default:"a,b"`
+	Labels    map[string]string `gcfg:"labels"`
+	LegacyURL string            `gcfg:"legacy_url" deprecated:"use server.host instead"`
+}
+
+func TestGenerateSample(t *testing.T) {
+	t.Parallel()
+
+	dest := sampleConfig{Labels: map[string]string{"team": "core platform"}}
+	dest.Server.Port = 9090
+
+	tests := []struct {
+		name       string
+		format     gcfg.SampleFormat
+		envOptions []gcfg.EnvOption
+		want       string
+	}{
+		{
+			name:   "YAML",
+			format: gcfg.SampleYAML,
+			want: `# HTTP server settings
+server:
+  # Host the HTTP server binds to
+  host: "localhost"
+  # Required.
+  port: 9090
+  timeout: "5s"
+tags: ["a","b"]
+labels: {"team":"core platform"}
+# Deprecated: use server.host instead
+legacy_url: ""
+`,
+		},
+		{
+			name:       "dotenv",
+			format:     gcfg.SampleDotEnv,
+			envOptions: []gcfg.EnvOption{gcfg.WithEnvPrefix("APP_")},
+			want: `# HTTP server settings
+# Host the HTTP server binds to
+APP_SERVER__HOST=localhost
+# Required.
+APP_SERVER__PORT=9090
+APP_SERVER__TIMEOUT=5s
+APP_TAGS=a,b
+APP_LABELS__TEAM="core platform"
+# Deprecated: use server.host instead
+APP_LEGACY_URL=
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sample, err := gcfg.GenerateSample(&dest, tt.format, tt.envOptions...)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(sample))
+		})
+	}
+}
+
+func TestGenerateSample_JSON(t *testing.T) {
+	t.Parallel()
+
+	sample, err := gcfg.GenerateSample(sampleConfig{}, gcfg.SampleJSON)
+	require.NoError(t, err)
+
+	// the sample loads and binds back into the struct
+	provider := &mockProvider{name: "sample"}
+	require.NoError(t, json.Unmarshal(sample, &provider.data))
+
+	cfg := gcfg.New(provider, gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	var dest sampleConfig
+	require.NoError(t, cfg.Bind(&dest))
+	assert.Equal(t, "localhost", dest.Server.Host)
+	assert.Equal(t, 8080, dest.Server.Port)
+	assert.Equal(t, 5*time.Second, dest.Server.Timeout)
+	assert.Equal(t, []string{"a", "b"}, dest.Tags)
+}
+
+func TestGenerateSample_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := gcfg.GenerateSample(42, gcfg.SampleYAML)
+	require.Error(t, err)
+
+	_, err = gcfg.GenerateSample(sampleConfig{}, gcfg.SampleFormat(42))
+	require.ErrorIs(t, err, gcfg.ErrUnsupportedSampleFormat)
+
+	_, err = gcfg.GenerateSample(sampleConfig{}, gcfg.SampleDotEnv, gcfg.WithEnvSeparator(""))
+	require.ErrorIs(t, err, gcfg.ErrEnvSeparatorNotSet)
+
+	type invalidDefault struct {
+		Port int `default:"http"`
+	}
+
+	_, err = gcfg.GenerateSample(invalidDefault{}, gcfg.SampleYAML)
+	require.Error(t, err)
+}
@@ -0,0 +1,86 @@
+package gcfg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ahmedkamalio/gcfg/internal/jsonschema"
+)
+
+var (
+	// ErrInvalidSchema indicates that a JSON Schema document is malformed or unsupported.
+	ErrInvalidSchema = errors.New("invalid JSON schema")
+
+	// ErrSchemaViolation indicates that the configuration doesn't conform to its JSON Schema.
+	ErrSchemaViolation = errors.New("configuration violates schema")
+)
+
+// SchemaError describes a configuration value violating a JSON Schema keyword. The errors
+// returned by ValidateSchema, and by Load when WithSchema is given, join a SchemaError per
+// violation; match them with errors.As.
+type SchemaError = jsonschema.Error
+
+// ValidateSchema checks the merged configuration against the JSON Schema document schema.
+//
+// Property names are matched against the normalized keys (lowercase by default, see
+// WithKeyNormalizer), and values are checked as loaded, so e.g. a port read from an environment
+// variable is a string. Supported keywords are type, enum, const, properties, required,
+// additionalProperties, items, minItems, maxItems, minimum, maximum, exclusiveMinimum,
+// exclusiveMaximum, minLength, maxLength, pattern, allOf, anyOf, oneOf, not and local $ref;
+// other keywords are ignored.
+//
+// It returns ErrInvalidSchema if the document is malformed, and ErrSchemaViolation joining a
+// *SchemaError per violation otherwise.
+func (c *Config) ValidateSchema(schema []byte) error {
+	compiled, err := compileSchema(schema)
+	if err != nil {
+		return err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return validateSchema(compiled, c.values)
+}
+
+// WithSchema validates every loaded configuration against the JSON Schema document schema
+// before applying it (see ValidateSchema). A Load or reload producing a configuration that
+// violates the schema fails with ErrSchemaViolation, and the previous configuration is kept.
+// If the document is malformed, every Load fails with ErrInvalidSchema.
+func WithSchema(schema []byte) Option {
+	return func(c *Config) {
+		c.schema, c.schemaErr = compileSchema(schema)
+	}
+}
+
+// checkSchema validates values against the schema given to WithSchema, if any.
+func (c *Config) checkSchema(values map[string]any) error {
+	if c.schemaErr != nil {
+		return c.schemaErr
+	}
+
+	if c.schema == nil {
+		return nil
+	}
+
+	return validateSchema(c.schema, values)
+}
+
+// compileSchema compiles the JSON Schema document schema.
+func compileSchema(schema []byte) (*jsonschema.Schema, error) {
+	compiled, err := jsonschema.Compile(schema)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	return compiled, nil
+}
+
+// validateSchema validates values against schema.
+func validateSchema(schema *jsonschema.Schema, values map[string]any) error {
+	if err := schema.Validate(values); err != nil {
+		return fmt.Errorf("%w: %w", ErrSchemaViolation, err)
+	}
+
+	return nil
+}
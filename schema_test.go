@@ -0,0 +1,168 @@
+package gcfg_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSchema = `{
+	"type": "object",
+	"required": ["server"],
+	"properties": {
+		"server": {
+			"type": "object",
+			"properties": {
+				"port": {"type": "integer", "minimum": 1024},
+				"host": {"type": "string"}
+			}
+		}
+	}
+}`
+
+func TestConfig_ValidateSchema(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"server": map[string]any{"port": 80, "host": 1},
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	err := cfg.ValidateSchema([]byte(testSchema))
+	require.ErrorIs(t, err, gcfg.ErrSchemaViolation)
+
+	var schemaErr *gcfg.SchemaError
+
+	require.ErrorAs(t, err, &schemaErr)
+	assert.Equal(t, "server.host", schemaErr.Key)
+	assert.Equal(t, "type", schemaErr.Keyword)
+	assert.Contains(t, err.Error(), "key server.port: must be >= 1024, got 80")
+
+	cfg.Set("server.port", 8080)
+	cfg.Set("server.host", "localhost")
+	require.NoError(t, cfg.ValidateSchema([]byte(testSchema)))
+
+	require.ErrorIs(t, cfg.ValidateSchema([]byte(`{"type": 1}`)), gcfg.ErrInvalidSchema)
+}
+
+func TestWithSchema(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"server": map[string]any{"port": 8080},
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithoutDefaultEnv(), gcfg.WithSchema([]byte(testSchema)))
+	require.NoError(t, cfg.Load())
+
+	// a reload violating the schema is rejected and the previous configuration kept
+	provider.data = map[string]any{"server": map[string]any{"port": 80}}
+
+	err := cfg.Load()
+	require.ErrorIs(t, err, gcfg.ErrSchemaViolation)
+	assert.Equal(t, 8080, cfg.Get("server.port"))
+}
+
+func TestWithSchema_ModifiedWhileApplyingComponents(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"server":   map[string]any{"host": "localhost"},
+		"database": map[string]any{"host": "localhost", "pool": 5},
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithoutDefaultEnv(), gcfg.WithSchema([]byte(testSchema)))
+
+	var sets, pool int
+
+	require.NoError(t, gcfg.RegisterComponent(cfg, "database", "database",
+		gcfg.ComponentFunc[dbConfig](func(_ context.Context, c dbConfig) error {
+			pool = c.Pool
+
+			if c.Pool == 10 {
+				sets++
+
+				// modifies the configuration while the reload is applied
+				cfg.Set("server.port", 80)
+			}
+
+			return nil
+		})))
+
+	require.NoError(t, cfg.Load())
+
+	provider.data = map[string]any{
+		"server":   map[string]any{"host": "localhost"},
+		"database": map[string]any{"host": "localhost", "pool": 10},
+	}
+
+	// the recomputed update is checked against the schema too
+	require.ErrorIs(t, cfg.Load(), gcfg.ErrSchemaViolation)
+	assert.Equal(t, 1, sets)
+	assert.Equal(t, 5, cfg.Get("database.pool"))
+	// the component is restored to the current configuration
+	assert.Equal(t, 5, pool)
+}
+
+func TestWithSchema_ModifiedOnEveryAttempt(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"server":   map[string]any{"host": "localhost"},
+		"database": map[string]any{"host": "localhost", "pool": 5},
+	}}
+
+	cfg := gcfg.New(provider, gcfg.WithoutDefaultEnv(), gcfg.WithSchema([]byte(testSchema)))
+
+	var (
+		modify bool
+		pool   int
+	)
+
+	port := 8080
+
+	require.NoError(t, gcfg.RegisterComponent(cfg, "database", "database",
+		gcfg.ComponentFunc[dbConfig](func(_ context.Context, c dbConfig) error {
+			pool = c.Pool
+
+			if modify {
+				port++
+				cfg.Set("server.port", port)
+			}
+
+			return nil
+		})))
+
+	require.NoError(t, cfg.Load())
+
+	modify = true
+	provider.data = map[string]any{
+		"server":   map[string]any{"host": "localhost"},
+		"database": map[string]any{"host": "localhost", "pool": 10},
+	}
+
+	// the reload is abandoned after a bounded number of attempts rather than committed without
+	// having been applied to the components
+	require.ErrorIs(t, cfg.Load(), gcfg.ErrConcurrentModification)
+	assert.Equal(t, 5, cfg.Get("database.pool"))
+	assert.Equal(t, port, cfg.Get("server.port"))
+	// the component is restored to the current configuration
+	assert.Equal(t, 5, pool)
+}
+
+func TestWithSchema_Invalid(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{}},
+		gcfg.WithoutDefaultEnv(), gcfg.WithSchema([]byte(`{`)))
+
+	err := cfg.Load()
+	require.ErrorIs(t, err, gcfg.ErrInvalidSchema)
+	assert.False(t, errors.Is(err, gcfg.ErrSchemaViolation))
+}
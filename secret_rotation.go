@@ -0,0 +1,230 @@
+package gcfg
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ahmedkamalio/gcfg/internal/reflection"
+)
+
+// secretRetryInterval is the interval at which the secrets failing to be rotated are retried.
+const secretRetryInterval = 5 * time.Second
+
+// WatchSecrets rotates the secrets resolved by the resolvers registered with
+// RegisterSecretLeaseResolver as soon as they expire, rather than when next read, and notifies the
+// listeners registered with OnChange and OnChanges of the keys whose secret changed. The changes
+// hold the secrets, not their references.
+//
+// Secrets failing to be resolved are kept and retried periodically, and the errors reported to the
+// handler registered with OnReloadError. Secrets no longer referenced by the configuration are
+// dropped. Rotation stops when ctx is done.
+func (c *Config) WatchSecrets(ctx context.Context) {
+	added := c.secretCache.watch()
+
+	go func() {
+		for {
+			var (
+				timer  *time.Timer
+				expiry <-chan time.Time
+			)
+
+			if next, ok := c.secretCache.nextExpiry(); ok {
+				timer = time.NewTimer(max(next.Sub(c.now()), 0))
+				expiry = timer.C
+			}
+
+			select {
+			case <-ctx.Done():
+			case <-added:
+			case <-expiry:
+				c.rotateSecrets(ctx)
+			}
+
+			if timer != nil {
+				timer.Stop()
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+}
+
+// watch returns the channel signaled when a secret is cached.
+func (sc *secretCache) watch() <-chan struct{} {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if sc.added == nil {
+		sc.added = make(chan struct{}, 1)
+	}
+
+	return sc.added
+}
+
+// nextExpiry returns the time the first expiring secret expires at, if any expires.
+func (sc *secretCache) nextExpiry() (time.Time, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	var next time.Time
+
+	for _, secret := range sc.secrets {
+		if !secret.expires.IsZero() && (next.IsZero() || secret.expires.Before(next)) {
+			next = secret.expires
+		}
+	}
+
+	return next, !next.IsZero()
+}
+
+// expired returns the references of the secrets expired at now.
+func (sc *secretCache) expired(now time.Time) []string {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	var refs []string
+
+	for ref, secret := range sc.secrets {
+		if secret.expired(now) {
+			refs = append(refs, ref)
+		}
+	}
+
+	slices.Sort(refs)
+
+	return refs
+}
+
+// postpone postpones the expiry of the secret of ref to expires.
+func (sc *secretCache) postpone(ref string, expires time.Time) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if secret, ok := sc.secrets[ref]; ok {
+		secret.expires = expires
+		sc.secrets[ref] = secret
+	}
+}
+
+// drop removes the secret of ref from the cache.
+func (sc *secretCache) drop(ref string) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	delete(sc.secrets, ref)
+}
+
+// secretRotation is the previous and the current value of a rotated secret.
+type secretRotation struct {
+	previous, current string
+}
+
+// rotateSecrets resolves the expired secrets again, and notifies the listeners of the keys whose
+// secret changed.
+func (c *Config) rotateSecrets(ctx context.Context) {
+	expired := c.secretCache.expired(c.now())
+	if len(expired) == 0 {
+		return
+	}
+
+	referenced := c.secretReferences()
+	rotated := make(map[string]secretRotation)
+
+	for _, value := range expired {
+		ref, ok := ParseSecretRef(value)
+		if !ok || !referenced[value] {
+			c.secretCache.drop(value)
+
+			continue
+		}
+
+		secret, previous, err := c.fetchSecret(ctx, value, ref)
+		if err != nil {
+			c.secretCache.postpone(value, c.now().Add(secretRetryInterval))
+			c.reportReloadError(err)
+
+			continue
+		}
+
+		if previous != nil && previous.value != secret.value {
+			rotated[value] = secretRotation{previous: previous.value, current: secret.value}
+		}
+	}
+
+	if len(rotated) > 0 {
+		c.notifySecretRotations(rotated)
+	}
+}
+
+// secretReferences returns the secret references held by the configuration values.
+func (c *Config) secretReferences() map[string]bool {
+	refs := make(map[string]bool)
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var errs []error
+
+	walkStrings(reflection.Clone(c.values), "", c.keys.sep(), func(_, str string) (any, error) {
+		if strings.HasPrefix(str, secretRefScheme+"://") {
+			refs[str] = true
+		}
+
+		return str, nil
+	}, &errs)
+
+	return refs
+}
+
+// notifySecretRotations notifies the listeners of the keys holding the rotated secret references.
+func (c *Config) notifySecretRotations(rotated map[string]secretRotation) {
+	c.mu.RLock()
+
+	previous, current := reflection.Clone(c.values), reflection.Clone(c.values)
+
+	var (
+		changes []Change
+		errs    []error
+	)
+
+	walkStrings(previous, "", c.keys.sep(), func(key, str string) (any, error) {
+		r, ok := rotated[str]
+		if !ok {
+			return str, nil
+		}
+
+		changes = append(changes, Change{
+			Key:      key,
+			Type:     ChangeUpdated,
+			OldValue: r.previous,
+			NewValue: r.current,
+			Provider: c.origins[key].Provider,
+		})
+
+		return r.previous, nil
+	}, &errs)
+
+	walkStrings(current, "", c.keys.sep(), func(_, str string) (any, error) {
+		if r, ok := rotated[str]; ok {
+			return r.current, nil
+		}
+
+		return str, nil
+	}, &errs)
+
+	slices.SortFunc(changes, func(a, b Change) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	notifications := c.prepareNotifications(previous, current, changes)
+
+	c.mu.RUnlock()
+
+	for _, notify := range notifications {
+		notify()
+	}
+}
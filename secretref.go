@@ -0,0 +1,272 @@
+package gcfg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	stdmaps "maps"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// secretRefScheme is the scheme of the values referencing secrets, see SecretRef.
+const secretRefScheme = "secretref"
+
+var (
+	// ErrSecretResolverNotFound indicates that no resolver is registered for the backend of a
+	// secret reference.
+	ErrSecretResolverNotFound = errors.New("secret resolver not found")
+
+	// ErrSecretResolutionFailed indicates that a resolver failed to resolve a secret reference.
+	ErrSecretResolutionFailed = errors.New("failed to resolve secret")
+)
+
+// SecretRef is a reference to a secret held by a secret manager, given as a configuration value
+// of the form "secretref://<backend>/<path>[#<key>]", e.g. "secretref://vault/kv/app#db_password"
+// or "secretref://awssm/my-secret".
+type SecretRef struct {
+	// Backend names the resolver of the secret, e.g. "vault".
+	Backend string
+	// Path is the path of the secret in the backend, e.g. "kv/app".
+	Path string
+	// Key selects a value of a secret holding several ones, e.g. "db_password"; empty if absent.
+	Key string
+}
+
+// String returns the configuration value of the reference.
+func (r SecretRef) String() string {
+	ref := secretRefScheme + "://" + r.Backend + "/" + r.Path
+	if r.Key != "" {
+		ref += "#" + r.Key
+	}
+
+	return ref
+}
+
+// ParseSecretRef parses value as a secret reference, and reports whether it is one.
+func ParseSecretRef(value string) (SecretRef, bool) {
+	if !strings.HasPrefix(value, secretRefScheme+"://") {
+		return SecretRef{}, false
+	}
+
+	u, err := url.Parse(value)
+	if err != nil || u.Host == "" {
+		return SecretRef{}, false
+	}
+
+	return SecretRef{Backend: u.Host, Path: strings.TrimPrefix(u.Path, "/"), Key: u.Fragment}, true
+}
+
+// SecretResolver fetches the secret referenced by ref from a secret manager. Resolvers are called
+// while the configuration is read, and must not call the Config themselves.
+type SecretResolver func(ctx context.Context, ref SecretRef) (string, error)
+
+// Secret is a secret fetched by a SecretLeaseResolver.
+type Secret struct {
+	// Value is the value of the secret.
+	Value string
+	// TTL is the duration the secret is valid for, e.g. the duration of its lease; zero if it
+	// doesn't expire.
+	TTL time.Duration
+}
+
+// SecretLeaseResolver is like SecretResolver, for secrets that expire, e.g. leased or rotated ones.
+type SecretLeaseResolver func(ctx context.Context, ref SecretRef) (Secret, error)
+
+// RegisterSecretResolver registers the resolver of the secret references of backend (see
+// SecretRef). Secrets are resolved lazily, when the keys referencing them are read with Get, Find
+// and the typed getters, or bound with Bind, BindKey, BindLive and the components, so only the
+// secrets actually used are fetched. Resolved secrets are cached. Values, Handler and the logs
+// show the references rather than the secrets.
+//
+// A failed resolution fails the binding with ErrSecretResolutionFailed, while Get and Find log a
+// warning and return nil.
+func (c *Config) RegisterSecretResolver(backend string, resolver SecretResolver) *Config {
+	return c.RegisterSecretLeaseResolver(backend, func(ctx context.Context, ref SecretRef) (Secret, error) {
+		value, err := resolver(ctx, ref)
+
+		return Secret{Value: value}, err
+	})
+}
+
+// RegisterSecretLeaseResolver is like RegisterSecretResolver, for secrets that expire: the secrets
+// are cached for their TTL, then resolved again when next read. See WatchSecrets to be notified of
+// the rotated secrets.
+func (c *Config) RegisterSecretLeaseResolver(backend string, resolver SecretLeaseResolver) *Config {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	resolvers := make(map[string]SecretLeaseResolver)
+	if current := c.secretResolvers.Load(); current != nil {
+		resolvers = stdmaps.Clone(*current)
+	}
+
+	resolvers[backend] = resolver
+	c.secretResolvers.Store(&resolvers)
+
+	return c
+}
+
+// secretCache caches the resolved secrets by reference.
+type secretCache struct {
+	mu      sync.Mutex
+	secrets map[string]cachedSecret
+	// added is signaled when a secret is cached, see WatchSecrets.
+	added chan struct{}
+}
+
+// cachedSecret is a resolved secret.
+type cachedSecret struct {
+	value string
+	// expires is the time the secret expires at, zero if it doesn't expire.
+	expires time.Time
+}
+
+// expired reports whether the secret is expired at now.
+func (s cachedSecret) expired(now time.Time) bool {
+	return !s.expires.IsZero() && !now.Before(s.expires)
+}
+
+// get returns the cached secret of ref, if any and not expired at now.
+func (sc *secretCache) get(ref string, now time.Time) (string, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	secret, ok := sc.secrets[ref]
+	if !ok || secret.expired(now) {
+		return "", false
+	}
+
+	return secret.value, true
+}
+
+// put caches the secret of ref, and returns the secret it replaces, if any.
+func (sc *secretCache) put(ref string, secret cachedSecret) (cachedSecret, bool) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if sc.secrets == nil {
+		sc.secrets = make(map[string]cachedSecret)
+	}
+
+	previous, ok := sc.secrets[ref]
+	sc.secrets[ref] = secret
+
+	if sc.added != nil {
+		select {
+		case sc.added <- struct{}{}:
+		default:
+		}
+	}
+
+	return previous, ok
+}
+
+// hasSecretResolvers reports whether secret resolvers are registered.
+func (c *Config) hasSecretResolvers() bool {
+	resolvers := c.secretResolvers.Load()
+
+	return resolvers != nil && len(*resolvers) > 0
+}
+
+// resolveSecret returns the secret referenced by value, a secret reference.
+func (c *Config) resolveSecret(ctx context.Context, value string, ref SecretRef) (string, error) {
+	if secret, ok := c.secretCache.get(value, c.now()); ok {
+		return secret, nil
+	}
+
+	secret, _, err := c.fetchSecret(ctx, value, ref)
+
+	return secret.value, err
+}
+
+// fetchSecret fetches the secret referenced by value, a secret reference, and caches it. It returns
+// the secret it replaces in the cache, if any.
+func (c *Config) fetchSecret(ctx context.Context, value string, ref SecretRef) (cachedSecret, *cachedSecret, error) {
+	var resolver SecretLeaseResolver
+	if resolvers := c.secretResolvers.Load(); resolvers != nil {
+		resolver = (*resolvers)[ref.Backend]
+	}
+
+	if resolver == nil {
+		return cachedSecret{}, nil, fmt.Errorf("%w %q", ErrSecretResolverNotFound, ref.Backend)
+	}
+
+	fetched, err := resolver(ctx, ref)
+	if err != nil {
+		return cachedSecret{}, nil, fmt.Errorf("%w %s: %w", ErrSecretResolutionFailed, ref, err)
+	}
+
+	secret := cachedSecret{value: fetched.Value}
+	if fetched.TTL > 0 {
+		secret.expires = c.now().Add(fetched.TTL)
+	}
+
+	if previous, ok := c.secretCache.put(value, secret); ok {
+		return secret, &previous, nil
+	}
+
+	return secret, nil, nil
+}
+
+// resolveSecretRefs returns value, read at key, with the secret references it holds resolved.
+// Maps and slices are modified in place, and must be copies of the configuration values.
+func (c *Config) resolveSecretRefs(key string, value any) any {
+	if !c.hasSecretResolvers() {
+		return value
+	}
+
+	var errs []error
+
+	resolve := func(_, str string) (any, error) {
+		ref, ok := ParseSecretRef(str)
+		if !ok {
+			return str, nil
+		}
+
+		return c.resolveSecret(context.Background(), str, ref)
+	}
+
+	if str, ok := value.(string); ok {
+		resolved, err := resolve(key, str)
+		if err != nil {
+			c.log().Warn("gcfg: secret resolution failed", "key", key, "error", err)
+
+			return nil
+		}
+
+		return resolved
+	}
+
+	walkStrings(value, key, c.keys.sep(), resolve, &errs)
+
+	for _, err := range errs {
+		c.log().Warn("gcfg: secret resolution failed", "error", err)
+	}
+
+	return value
+}
+
+// secretDecodeHook returns the decode hook resolving the secret references bound by Bind.
+func (c *Config) secretDecodeHook() DecodeHook {
+	return func(from, _ reflect.Type, value any) (any, error) {
+		if from.Kind() != reflect.String {
+			return value, nil
+		}
+
+		str, ok := value.(string)
+		if !ok {
+			return value, nil
+		}
+
+		ref, ok := ParseSecretRef(str)
+		if !ok {
+			return value, nil
+		}
+
+		return c.resolveSecret(context.Background(), str, ref)
+	}
+}
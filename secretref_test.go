@@ -0,0 +1,205 @@
+package gcfg_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSecretRef(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value  string
+		want   gcfg.SecretRef
+		wantOK bool
+	}{
+		{
+			value:  "secretref://vault/kv/app#db_password",
+			want:   gcfg.SecretRef{Backend: "vault", Path: "kv/app", Key: "db_password"},
+			wantOK: true,
+		},
+		{
+			value:  "secretref://awssm/my-secret",
+			want:   gcfg.SecretRef{Backend: "awssm", Path: "my-secret"},
+			wantOK: true,
+		},
+		{value: "secretref:///no-backend"},
+		{value: "https://vault/kv/app"},
+		{value: "plain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			ref, ok := gcfg.ParseSecretRef(tt.value)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, ref)
+
+			if ok {
+				assert.Equal(t, tt.value, ref.String())
+			}
+		})
+	}
+}
+
+func TestRegisterSecretResolver(t *testing.T) {
+	t.Parallel()
+
+	p := &mockProvider{name: "base", data: map[string]any{
+		"database": map[string]any{
+			"host":     "db.internal",
+			"password": "secretref://vault/kv/app#db_password",
+		},
+		"api_key": "secretref://awssm/api-key",
+		"unused":  "secretref://vault/kv/other#token",
+	}}
+
+	var calls atomic.Int32
+
+	cfg := gcfg.New(p, gcfg.WithoutDefaultEnv())
+	cfg.RegisterSecretResolver("vault", func(_ context.Context, ref gcfg.SecretRef) (string, error) {
+		calls.Add(1)
+
+		return ref.Path + "/" + ref.Key + "-secret", nil
+	})
+	require.NoError(t, cfg.Load())
+
+	var dest struct {
+		Database struct {
+			Host     string `json:"host"`
+			Password string `json:"password"`
+		} `json:"database"`
+	}
+
+	require.NoError(t, cfg.Bind(&dest))
+	assert.Equal(t, "kv/app/db_password-secret", dest.Database.Password)
+	assert.Equal(t, int32(1), calls.Load(), "only bound references are resolved")
+
+	// resolved secrets are cached
+	assert.Equal(t, "kv/app/db_password-secret", cfg.Get("database.password"))
+	assert.Equal(t, map[string]any{"host": "db.internal", "password": "kv/app/db_password-secret"},
+		cfg.Get("database"))
+	assert.Equal(t, int32(1), calls.Load())
+
+	// the stored values keep the references
+	assert.Equal(t, "secretref://vault/kv/app#db_password", cfg.Values()["database"].(map[string]any)["password"])
+
+	// no resolver for the backend
+	assert.Nil(t, cfg.Get("api_key"))
+
+	var apiKey struct {
+		APIKey string `json:"api_key"`
+	}
+
+	err := cfg.Bind(&apiKey)
+	require.ErrorIs(t, err, gcfg.ErrSecretResolverNotFound)
+}
+
+func TestRegisterSecretResolver_Error(t *testing.T) {
+	t.Parallel()
+
+	p := &mockProvider{name: "base", data: map[string]any{"password": "secretref://vault/kv/app"}}
+	errUnavailable := errors.New("vault unavailable")
+
+	cfg := gcfg.New(p, gcfg.WithoutDefaultEnv())
+	cfg.RegisterSecretResolver("vault", func(context.Context, gcfg.SecretRef) (string, error) {
+		return "", errUnavailable
+	})
+	require.NoError(t, cfg.Load())
+
+	var dest struct {
+		Password string `json:"password"`
+	}
+
+	err := cfg.Bind(&dest)
+	require.ErrorIs(t, err, gcfg.ErrSecretResolutionFailed)
+	require.ErrorIs(t, err, errUnavailable)
+
+	value, ok := cfg.Find("password")
+	assert.True(t, ok)
+	assert.Nil(t, value)
+}
+
+func TestRegisterSecretLeaseResolver(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := &mockProvider{name: "base", data: map[string]any{"password": "secretref://vault/database/creds"}}
+
+	var calls atomic.Int32
+
+	cfg := gcfg.New(p, gcfg.WithoutDefaultEnv(), gcfg.WithClock(func() time.Time { return now }))
+	cfg.RegisterSecretLeaseResolver("vault", func(context.Context, gcfg.SecretRef) (gcfg.Secret, error) {
+		n := calls.Add(1)
+
+		return gcfg.Secret{Value: fmt.Sprintf("v%d", n), TTL: time.Minute}, nil
+	})
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "v1", cfg.Get("password"))
+	assert.Equal(t, "v1", cfg.Get("password"))
+
+	// the secret is resolved again once expired
+	now = now.Add(time.Minute)
+
+	assert.Equal(t, "v2", cfg.Get("password"))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestWatchSecrets(t *testing.T) {
+	t.Parallel()
+
+	p := &mockProvider{name: "base", data: map[string]any{
+		"database": map[string]any{"password": "secretref://vault/database/creds"},
+	}}
+
+	var calls atomic.Int32
+
+	cfg := gcfg.New(p, gcfg.WithoutDefaultEnv())
+	cfg.RegisterSecretLeaseResolver("vault", func(context.Context, gcfg.SecretRef) (gcfg.Secret, error) {
+		n := calls.Add(1)
+
+		return gcfg.Secret{Value: fmt.Sprintf("v%d", n), TTL: 20 * time.Millisecond}, nil
+	})
+	require.NoError(t, cfg.Load())
+
+	rotations := make(chan [2]any, 10)
+	changeSets := make(chan gcfg.ChangeSet, 10)
+
+	cfg.OnChange("database.password", func(oldValue, newValue any) {
+		rotations <- [2]any{oldValue, newValue}
+	})
+	cfg.OnChanges(func(changes gcfg.ChangeSet) {
+		changeSets <- changes
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	cfg.WatchSecrets(ctx)
+
+	assert.Equal(t, "v1", cfg.Get("database.password"))
+
+	select {
+	case rotation := <-rotations:
+		assert.Equal(t, [2]any{"v1", "v2"}, rotation)
+	case <-time.After(time.Second):
+		t.Fatal("secret not rotated")
+	}
+
+	changes := <-changeSets
+	require.Len(t, changes, 1)
+	assert.Equal(t, "database.password", changes[0].Key)
+	assert.Equal(t, gcfg.ChangeUpdated, changes[0].Type)
+	assert.Equal(t, "base", changes[0].Provider)
+	assert.Equal(t, "v2", changes[0].NewValue)
+}
@@ -0,0 +1,141 @@
+package gcfg
+
+import (
+	"context"
+
+	"github.com/ahmedkamalio/gcfg/internal/reflection"
+)
+
+// Snapshot is an immutable copy of the configuration state, taken with Config.Snapshot.
+// It can be inspected on its own, or passed to Config.Restore to roll the configuration back.
+type Snapshot struct {
+	values    map[string]any
+	defaults  map[string]any
+	overrides map[string]any
+	origins   map[string]Origin
+	// providerValues holds the values last loaded by each provider.
+	providerValues map[*providerEntry]map[string]any
+	version        uint64
+	keys           keyFormat
+}
+
+// Snapshot captures the current configuration state, including the defaults, the runtime
+// overrides and the values last loaded by each provider.
+func (c *Config) Snapshot() *Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	providerValues := make(map[*providerEntry]map[string]any, len(c.providers))
+	for _, p := range c.providers {
+		providerValues[p] = reflection.Clone(p.values)
+	}
+
+	origins := make(map[string]Origin, len(c.origins))
+	for k, v := range c.origins {
+		origins[k] = v
+	}
+
+	return &Snapshot{
+		values:         reflection.Clone(c.values),
+		defaults:       reflection.Clone(c.defaults),
+		overrides:      reflection.Clone(c.overrides),
+		origins:        origins,
+		providerValues: providerValues,
+		version:        c.rev,
+		keys:           c.keys,
+	}
+}
+
+// Restore rolls the configuration back to the given snapshot, including the defaults and the
+// runtime overrides. Like a load, the restored configuration is applied to the registered
+// components, which may reject it, and the change listeners are notified.
+//
+// Providers registered after the snapshot was taken keep their last loaded values until the
+// next load; they are not part of the restored values though.
+func (c *Config) Restore(snapshot *Snapshot) error {
+	if err := c.checkFrozen("Restore"); err != nil {
+		return err
+	}
+
+	if snapshot == nil {
+		return ErrNilValues
+	}
+
+	c.loadMu.Lock()
+	defer c.loadMu.Unlock()
+
+	return c.applyUpdate(context.Background(), func() *configUpdate {
+		return c.prepareRestore(snapshot)
+	})
+}
+
+// prepareRestore computes the update restoring snapshot. The caller must hold c.mu.
+func (c *Config) prepareRestore(snapshot *Snapshot) *configUpdate {
+	next := reflection.Clone(snapshot.values)
+
+	nextOrigins := make(map[string]Origin, len(snapshot.origins))
+	for k, v := range snapshot.origins {
+		nextOrigins[k] = v
+	}
+
+	results := make(map[*providerEntry]map[string]any)
+
+	for _, p := range c.providers {
+		if values, ok := snapshot.providerValues[p]; ok {
+			results[p] = reflection.Clone(values)
+		}
+	}
+
+	changes := c.diffWith(next, nextOrigins)
+
+	return &configUpdate{
+		values:    next,
+		origins:   nextOrigins,
+		results:   results,
+		changes:   changes,
+		defaults:  reflection.Clone(snapshot.defaults),
+		overrides: reflection.Clone(snapshot.overrides),
+		rev:       c.rev,
+	}
+}
+
+// Values returns a copy of the configuration values captured by the snapshot.
+func (s *Snapshot) Values() map[string]any {
+	return reflection.Clone(s.values)
+}
+
+// Get retrieves a configuration value captured by the snapshot by key.
+// Supports hierarchical paths like "database.host".
+func (s *Snapshot) Get(key string) any {
+	if key == "" {
+		return nil
+	}
+
+	return s.keys.lookup(s.values, key)
+}
+
+// Version returns the version of the configuration when the snapshot was taken, see Config.Version.
+func (s *Snapshot) Version() uint64 {
+	return s.version
+}
+
+// Diff computes the key-level differences from snapshot a to snapshot b, attributed to the
+// providers that supplied the values, e.g. to log what changed between two deploys.
+// A nil snapshot is treated as an empty configuration.
+func Diff(a, b *Snapshot) ChangeSet {
+	var (
+		oldValues, newValues   map[string]any
+		oldOrigins, newOrigins map[string]Origin
+		keys                   keyFormat
+	)
+
+	if a != nil {
+		oldValues, oldOrigins, keys = a.values, a.origins, a.keys
+	}
+
+	if b != nil {
+		newValues, newOrigins, keys = b.values, b.origins, b.keys
+	}
+
+	return attributedDiff(oldValues, newValues, oldOrigins, newOrigins, keys.sep())
+}
@@ -0,0 +1,89 @@
+package gcfg_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_SnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{"host": "localhost"},
+	}}
+
+	cfg := gcfg.New(mockP)
+	cfg.SetDefault("level", "info")
+	require.NoError(t, cfg.Load())
+
+	snapshot := cfg.Snapshot()
+	assert.Equal(t, cfg.Version(), snapshot.Version())
+	assert.Equal(t, "localhost", snapshot.Get("database.host"))
+
+	// Experiment.
+	cfg.Set("level", "debug")
+	cfg.SetDefault("feature", true)
+	mockP.data = map[string]any{"database": map[string]any{"host": "db.internal"}}
+	require.NoError(t, cfg.Load())
+
+	// The snapshot is not affected by later modifications.
+	assert.Equal(t, "localhost", snapshot.Get("database.host"))
+	assert.Nil(t, snapshot.Get("feature"))
+
+	var changes gcfg.ChangeSet
+
+	cfg.OnChanges(func(c gcfg.ChangeSet) { changes = c })
+
+	require.NoError(t, cfg.Restore(snapshot))
+
+	assert.Equal(t, snapshot.Values(), cfg.Values())
+	assert.Equal(t, "localhost", cfg.Get("database.host"))
+	assert.Equal(t, "info", cfg.Get("level"))
+	assert.Nil(t, cfg.Get("feature"))
+	assert.Equal(t, []string{"database.host", "feature", "level"}, changes.Keys())
+	assert.Greater(t, cfg.Version(), snapshot.Version())
+
+	// The restored layers are kept: the runtime override is gone, the default is back.
+	mockP.data = map[string]any{}
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, "info", cfg.Get("level"))
+	assert.Nil(t, cfg.Get("feature"))
+
+	require.ErrorIs(t, cfg.Restore(nil), gcfg.ErrNilValues)
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	mockP := &mockProvider{name: "mock", data: map[string]any{
+		"host":  "localhost",
+		"debug": true,
+	}}
+
+	cfg := gcfg.New(mockP)
+	require.NoError(t, cfg.Load())
+
+	before := cfg.Snapshot()
+
+	mockP.data = map[string]any{"host": "db.internal"}
+	require.NoError(t, cfg.Load())
+	cfg.Delete("debug")
+	cfg.Set("level", "info")
+
+	after := cfg.Snapshot()
+
+	assert.Equal(t, gcfg.ChangeSet{
+		{Key: "debug", Type: gcfg.ChangeRemoved, OldValue: true, Provider: "mock"},
+		{Key: "host", Type: gcfg.ChangeUpdated, OldValue: "localhost", NewValue: "db.internal", Provider: "mock"},
+		{Key: "level", Type: gcfg.ChangeAdded, NewValue: "info", Provider: "Overrides"},
+	}, gcfg.Diff(before, after))
+
+	assert.Empty(t, gcfg.Diff(after, after))
+
+	fromEmpty := gcfg.Diff(nil, after)
+	assert.Len(t, fromEmpty.Added(), len(fromEmpty))
+	assert.Contains(t, fromEmpty.Keys(), "level")
+}
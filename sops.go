@@ -0,0 +1,503 @@
+package gcfg
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrSOPSDataKeyFailed indicates failure to obtain the data key of a SOPS-encrypted document.
+	ErrSOPSDataKeyFailed = errors.New("failed to obtain SOPS data key")
+
+	// ErrSOPSDecryptFailed indicates failure to decrypt a value of a SOPS-encrypted document.
+	ErrSOPSDecryptFailed = errors.New("failed to decrypt SOPS value")
+
+	// ErrSOPSMACVerificationFailed indicates that the message authentication code of a
+	// SOPS-encrypted document is missing or doesn't match its values.
+	ErrSOPSMACVerificationFailed = errors.New("failed to verify SOPS message authentication code")
+
+	// errSOPSUnencryptedValue indicates an unencrypted value where SOPS encrypts values.
+	errSOPSUnencryptedValue = errors.New("value is not encrypted")
+
+	// errSOPSUnorderedProvider indicates a decorated provider losing the key order of documents.
+	errSOPSUnorderedProvider = errors.New("provider doesn't preserve the key order of documents")
+)
+
+// SOPS metadata keys.
+const (
+	sopsMetadataKey          = "sops"
+	sopsMACKey               = "mac"
+	sopsLastModifiedKey      = "lastmodified"
+	sopsUnencryptedSuffixKey = "unencrypted_suffix"
+	sopsEncryptedSuffixKey   = "encrypted_suffix"
+	sopsUnencryptedRegexKey  = "unencrypted_regex"
+	sopsEncryptedRegexKey    = "encrypted_regex"
+	sopsMACOnlyEncryptedKey  = "mac_only_encrypted"
+)
+
+// sopsValue matches the values encrypted by SOPS.
+var sopsValue = regexp.MustCompile(`^ENC\[AES256_GCM,data:([^,]*),iv:([^,]+),tag:([^,]+),type:([a-z]+)\]$`)
+
+// SOPSDataKeyFunc returns the 256-bit data key of a SOPS-encrypted document, decrypting it with one
+// of the master keys listed in metadata, the "sops" section of the document (e.g. its "age", "kms"
+// or "pgp" entries). It is typically implemented with the SOPS or age libraries, or a KMS client.
+type SOPSDataKeyFunc func(metadata map[string]any) ([]byte, error)
+
+// orderedLoader is implemented by providers able to load their document with the order of its keys
+// preserved, which the message authentication code of SOPS-encrypted documents depends on.
+type orderedLoader interface {
+	loadOrdered() (orderedMap, error)
+}
+
+// orderedMap is a JSON object decoded with the order of its keys preserved, see decodeOrderedJSON.
+type orderedMap []orderedEntry
+
+// orderedEntry is a key of an orderedMap with its value.
+type orderedEntry struct {
+	key   string
+	value any
+}
+
+// get returns the value of key.
+func (m orderedMap) get(key string) (any, bool) {
+	for _, entry := range m {
+		if entry.key == key {
+			return entry.value, true
+		}
+	}
+
+	return nil, false
+}
+
+// with sets the value of key, replacing the value of a duplicate key in place like json.Unmarshal
+// does, and returns the updated map.
+func (m orderedMap) with(key string, value any) orderedMap {
+	for i := range m {
+		if m[i].key == key {
+			m[i].value = value
+
+			return m
+		}
+	}
+
+	return append(m, orderedEntry{key: key, value: value})
+}
+
+// toMap returns the values of m as a map, converting the nested ordered maps too.
+func (m orderedMap) toMap() map[string]any {
+	out := make(map[string]any, len(m))
+
+	for _, entry := range m {
+		out[entry.key] = toPlainValue(entry.value)
+	}
+
+	return out
+}
+
+// toPlainValue converts the ordered maps nested in v to maps.
+func toPlainValue(v any) any {
+	switch v := v.(type) {
+	case orderedMap:
+		return v.toMap()
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = toPlainValue(item)
+		}
+
+		return out
+	default:
+		return v
+	}
+}
+
+// sopsProvider decrypts the values of a decorated provider loading SOPS-encrypted documents.
+type sopsProvider struct {
+	providerWrapper
+
+	dataKey SOPSDataKeyFunc
+}
+
+var _ WatchableProvider = (*sopsProvider)(nil)
+
+// WithSOPS decorates the given JSON provider so that the SOPS-encrypted documents it loads are
+// decrypted before being merged, keeping encrypted-at-rest configuration in version control:
+//
+//	gcfg.WithSOPS(gcfg.NewJSONProvider(gcfg.WithJSONFilePath("secrets.enc.json")), ageDataKey)
+//
+// The data key of the document is obtained from dataKey, and the values encrypted with it
+// ("ENC[AES256_GCM,...]") are decrypted and converted back to their original type. Values are
+// authenticated together with their key path, and the message authentication code of the whole
+// document is verified, so values can't be removed, reordered or replaced with plaintext. Values
+// are only allowed unencrypted as set by the unencrypted_suffix, encrypted_suffix,
+// unencrypted_regex or encrypted_regex metadata. The "sops" metadata is removed, and documents
+// without it are loaded as-is, so unencrypted files can be used in development.
+//
+// The message authentication code depends on the key order of the document, so only providers
+// preserving it (NewJSONProvider) can load encrypted documents.
+//
+// Loads fail with ErrSOPSDataKeyFailed, ErrSOPSDecryptFailed or ErrSOPSMACVerificationFailed; when
+// watching, the documents that can't be decrypted are skipped.
+func WithSOPS(p Provider, dataKey SOPSDataKeyFunc) Provider {
+	return &sopsProvider{
+		providerWrapper: providerWrapper{Provider: p},
+		dataKey:         dataKey,
+	}
+}
+
+// Load implements the Provider interface.
+func (p *sopsProvider) Load() (map[string]any, error) {
+	loader, ok := p.Provider.(orderedLoader)
+	if !ok {
+		values, err := p.Provider.Load()
+		if err != nil {
+			return nil, err
+		}
+
+		return p.decryptUnordered(values)
+	}
+
+	document, err := loader.loadOrdered()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.decrypt(document)
+}
+
+// Watch implements the WatchableProvider interface. The document is loaded again, with its key
+// order, whenever the decorated provider reports a change.
+func (p *sopsProvider) Watch(ctx context.Context) (<-chan map[string]any, error) {
+	return p.watchAndProcess(ctx, func(values map[string]any) (map[string]any, error) {
+		if _, ok := p.Provider.(orderedLoader); ok {
+			return p.Load()
+		}
+
+		return p.decryptUnordered(values)
+	})
+}
+
+// decryptUnordered loads the values of a provider not preserving the key order of documents, which
+// are only accepted without SOPS metadata.
+func (p *sopsProvider) decryptUnordered(values map[string]any) (map[string]any, error) {
+	if _, ok := values[sopsMetadataKey]; !ok {
+		return values, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s: %w", ErrSOPSMACVerificationFailed, p.Name(), errSOPSUnorderedProvider)
+}
+
+// decrypt decrypts the SOPS-encrypted document and verifies its message authentication code.
+func (p *sopsProvider) decrypt(document orderedMap) (map[string]any, error) {
+	metadataValue, ok := document.get(sopsMetadataKey)
+	if !ok {
+		return document.toMap(), nil
+	}
+
+	metadata, ok := toPlainValue(metadataValue).(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s metadata is not an object", ErrSOPSDecryptFailed, sopsMetadataKey)
+	}
+
+	key, err := p.dataKey(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSOPSDataKeyFailed, err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrSOPSDataKeyFailed, err)
+	}
+
+	d, err := newSOPSDecrypter(gcm, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]any, len(document)-1)
+
+	for _, entry := range document {
+		if entry.key == sopsMetadataKey {
+			continue
+		}
+
+		if out[entry.key], err = d.decrypt(entry.value, []string{entry.key}); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = d.verifyMAC(metadata); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// sopsDecrypter decrypts the values of a SOPS-encrypted document in document order, hashing them
+// as SOPS does to compute the message authentication code of the document.
+type sopsDecrypter struct {
+	gcm  func(nonceSize int) (cipher.AEAD, error)
+	hash hash.Hash
+
+	unencryptedSuffix string
+	encryptedSuffix   string
+	unencryptedRegex  *regexp.Regexp
+	encryptedRegex    *regexp.Regexp
+	macOnlyEncrypted  bool
+}
+
+// newSOPSDecrypter returns a sopsDecrypter of the values encrypted with gcm, which are encrypted or
+// not according to metadata.
+func newSOPSDecrypter(gcm func(nonceSize int) (cipher.AEAD, error), metadata map[string]any) (*sopsDecrypter, error) {
+	d := &sopsDecrypter{gcm: gcm, hash: sha512.New()}
+
+	d.unencryptedSuffix, _ = metadata[sopsUnencryptedSuffixKey].(string)
+	d.encryptedSuffix, _ = metadata[sopsEncryptedSuffixKey].(string)
+	d.macOnlyEncrypted, _ = metadata[sopsMACOnlyEncryptedKey].(bool)
+
+	for key, re := range map[string]**regexp.Regexp{
+		sopsUnencryptedRegexKey: &d.unencryptedRegex,
+		sopsEncryptedRegexKey:   &d.encryptedRegex,
+	} {
+		expr, _ := metadata[key].(string)
+		if expr == "" {
+			continue
+		}
+
+		compiled, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrSOPSDecryptFailed, key, err)
+		}
+
+		*re = compiled
+	}
+
+	return d, nil
+}
+
+// decrypt decrypts the SOPS-encrypted values nested in v, found at path.
+func (d *sopsDecrypter) decrypt(v any, path []string) (any, error) {
+	switch v := v.(type) {
+	case orderedMap:
+		out := make(map[string]any, len(v))
+
+		for _, entry := range v {
+			decrypted, err := d.decrypt(entry.value, append(path[:len(path):len(path)], entry.key))
+			if err != nil {
+				return nil, err
+			}
+
+			out[entry.key] = decrypted
+		}
+
+		return out, nil
+	case []any:
+		out := make([]any, len(v))
+
+		// SOPS authenticates list items with the path of their list
+		for i, item := range v {
+			decrypted, err := d.decrypt(item, path)
+			if err != nil {
+				return nil, err
+			}
+
+			out[i] = decrypted
+		}
+
+		return out, nil
+	case nil:
+		return v, nil
+	default:
+		return d.decryptLeaf(v, path)
+	}
+}
+
+// decryptLeaf decrypts the value v found at path if SOPS encrypts it, and hashes it.
+func (d *sopsDecrypter) decryptLeaf(v any, path []string) (any, error) {
+	encrypted := d.encrypted(path)
+
+	value := v
+
+	// SOPS doesn't encrypt empty strings
+	if s, ok := v.(string); encrypted && (!ok || s != "") {
+		m := sopsValue.FindStringSubmatch(s)
+		if m == nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrSOPSDecryptFailed, strings.Join(path, "."), errSOPSUnencryptedValue)
+		}
+
+		decrypted, err := decryptSOPSString(d.gcm, m, strings.Join(path, ":")+":")
+		if err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrSOPSDecryptFailed, strings.Join(path, "."), err)
+		}
+
+		value = decrypted
+	}
+
+	if encrypted || !d.macOnlyEncrypted {
+		d.hash.Write(sopsBytes(value))
+	}
+
+	return value, nil
+}
+
+// encrypted reports whether SOPS encrypts the values found at path, applying the rules of the
+// metadata in the same order as SOPS.
+func (d *sopsDecrypter) encrypted(path []string) bool {
+	encrypted := true
+
+	if d.unencryptedSuffix != "" && anyKey(path, func(key string) bool {
+		return strings.HasSuffix(key, d.unencryptedSuffix)
+	}) {
+		encrypted = false
+	}
+
+	if d.encryptedSuffix != "" {
+		encrypted = anyKey(path, func(key string) bool { return strings.HasSuffix(key, d.encryptedSuffix) })
+	}
+
+	if d.unencryptedRegex != nil && anyKey(path, d.unencryptedRegex.MatchString) {
+		encrypted = false
+	}
+
+	if d.encryptedRegex != nil {
+		encrypted = anyKey(path, d.encryptedRegex.MatchString)
+	}
+
+	return encrypted
+}
+
+// verifyMAC verifies the message authentication code of metadata, encrypted with the time of the
+// last modification of the document, against the hash of the decrypted values.
+func (d *sopsDecrypter) verifyMAC(metadata map[string]any) error {
+	mac, _ := metadata[sopsMACKey].(string)
+
+	m := sopsValue.FindStringSubmatch(mac)
+	if m == nil {
+		return fmt.Errorf("%w: %s is missing", ErrSOPSMACVerificationFailed, sopsMACKey)
+	}
+
+	lastModifiedValue, _ := metadata[sopsLastModifiedKey].(string)
+
+	lastModified, err := time.Parse(time.RFC3339, lastModifiedValue)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrSOPSMACVerificationFailed, sopsLastModifiedKey, err)
+	}
+
+	want, err := decryptSOPSString(d.gcm, m, lastModified.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSOPSMACVerificationFailed, err)
+	}
+
+	wantMAC, _ := want.(string)
+	got := strings.ToUpper(hex.EncodeToString(d.hash.Sum(nil)))
+
+	if subtle.ConstantTimeCompare([]byte(wantMAC), []byte(got)) != 1 {
+		return fmt.Errorf("%w: the document was modified", ErrSOPSMACVerificationFailed)
+	}
+
+	return nil
+}
+
+// anyKey reports whether match matches any key of path.
+func anyKey(path []string, match func(key string) bool) bool {
+	for _, key := range path {
+		if match(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sopsBytes returns the representation of value hashed by SOPS.
+func sopsBytes(value any) []byte {
+	switch value := value.(type) {
+	case string:
+		return []byte(value)
+	case int:
+		return []byte(strconv.Itoa(value))
+	case float64:
+		return []byte(strconv.FormatFloat(value, 'f', -1, 64))
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return []byte(strconv.FormatInt(i, 10))
+		}
+
+		f, _ := value.Float64()
+
+		return []byte(strconv.FormatFloat(f, 'f', -1, 64))
+	case bool:
+		if value {
+			return []byte("True")
+		}
+
+		return []byte("False")
+	default:
+		return []byte(fmt.Sprint(value))
+	}
+}
+
+// decryptSOPSString decrypts the SOPS-encrypted value matched by sopsValue as m, authenticated with
+// additionalData, and converts it to its original type.
+func decryptSOPSString(gcm func(nonceSize int) (cipher.AEAD, error), m []string, additionalData string) (any, error) {
+	var parts [3][]byte
+
+	for i := range parts {
+		decoded, err := base64.StdEncoding.DecodeString(m[i+1])
+		if err != nil {
+			return nil, err
+		}
+
+		parts[i] = decoded
+	}
+
+	data, iv, tag := parts[0], parts[1], parts[2]
+
+	aead, err := gcm(len(iv))
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := aead.Open(nil, iv, append(data, tag...), []byte(additionalData))
+	if err != nil {
+		return nil, err
+	}
+
+	switch typ := m[4]; typ {
+	case "str", "bytes":
+		return string(plaintext), nil
+	case "int":
+		return strconv.Atoi(string(plaintext))
+	case "float":
+		return strconv.ParseFloat(string(plaintext), 64)
+	case "bool":
+		return strconv.ParseBool(string(plaintext))
+	default:
+		return nil, fmt.Errorf("unknown value type %q", typ)
+	}
+}
+
+// newGCM returns a constructor of AES-GCM ciphers with the given nonce size, keyed with key.
+func newGCM(key []byte) (func(nonceSize int) (cipher.AEAD, error), error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(nonceSize int) (cipher.AEAD, error) {
+		return cipher.NewGCMWithNonceSize(block, nonceSize)
+	}, nil
+}
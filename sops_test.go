@@ -0,0 +1,235 @@
+package gcfg_test
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sopsLastModified is the time of the last modification of the test documents.
+const sopsLastModified = "2024-05-01T10:00:00Z"
+
+// sopsEncrypt encrypts value the way SOPS does, authenticated with the key path.
+func sopsEncrypt(t *testing.T, key []byte, value, typ, path string) string {
+	t.Helper()
+
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+
+	gcm, err := cipher.NewGCMWithNonceSize(block, 32)
+	require.NoError(t, err)
+
+	iv := make([]byte, 32)
+	_, err = rand.Read(iv)
+	require.NoError(t, err)
+
+	sealed := gcm.Seal(nil, iv, []byte(value), []byte(path))
+	data, tag := sealed[:len(sealed)-gcm.Overhead()], sealed[len(sealed)-gcm.Overhead():]
+
+	return fmt.Sprintf("ENC[AES256_GCM,data:%s,iv:%s,tag:%s,type:%s]",
+		base64.StdEncoding.EncodeToString(data),
+		base64.StdEncoding.EncodeToString(iv),
+		base64.StdEncoding.EncodeToString(tag),
+		typ,
+	)
+}
+
+// sopsMAC computes the encrypted message authentication code of a document the way SOPS does,
+// from the plaintext of its values in document order.
+func sopsMAC(t *testing.T, key []byte, values ...string) string {
+	t.Helper()
+
+	hash := sha512.New()
+	for _, value := range values {
+		hash.Write([]byte(value))
+	}
+
+	return sopsEncrypt(t, key, strings.ToUpper(hex.EncodeToString(hash.Sum(nil))), "str", sopsLastModified)
+}
+
+// sopsJSONProvider returns a JSON provider loading document.
+func sopsJSONProvider(document string) *gcfg.JSONProvider {
+	return gcfg.NewJSONProvider(
+		gcfg.WithJSONFilePath("secrets.enc.json"),
+		gcfg.WithJSONFileFS(fstest.MapFS{"secrets.enc.json": &fstest.MapFile{Data: []byte(document)}}),
+	)
+}
+
+func TestWithSOPS(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	document := fmt.Sprintf(`{
+		"database": {
+			"password": %q,
+			"port": %q,
+			"host_unencrypted": "db.internal"
+		},
+		"tokens": [%q],
+		"debug": %q,
+		"sops": {
+			"age": [{"recipient": "age1..."}],
+			"lastmodified": %q,
+			"mac": %q,
+			"unencrypted_suffix": "_unencrypted"
+		}
+	}`,
+		sopsEncrypt(t, key, "s3cr3t", "str", "database:password:"),
+		sopsEncrypt(t, key, "5432", "int", "database:port:"),
+		sopsEncrypt(t, key, "t0k3n", "str", "tokens:"),
+		sopsEncrypt(t, key, "True", "bool", "debug:"),
+		sopsLastModified,
+		sopsMAC(t, key, "s3cr3t", "5432", "db.internal", "t0k3n", "True"),
+	)
+
+	var metadata map[string]any
+
+	cfg := gcfg.New(gcfg.WithSOPS(sopsJSONProvider(document), func(m map[string]any) ([]byte, error) {
+		metadata = m
+
+		return key, nil
+	}), gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	assert.Contains(t, metadata, "age")
+	assert.Equal(t, "s3cr3t", cfg.Get("database.password"))
+	assert.Equal(t, 5432, cfg.Get("database.port"))
+	assert.Equal(t, "db.internal", cfg.Get("database.host_unencrypted"))
+	assert.Equal(t, "t0k3n", cfg.Get("tokens.0"))
+	assert.Equal(t, true, cfg.Get("debug"))
+	assert.False(t, cfg.IsSet("sops"))
+}
+
+func TestWithSOPS_Errors(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	errNoKey := errors.New("no master key")
+
+	document := func(values, mac string) string {
+		return fmt.Sprintf(`{%s, "sops": {"lastmodified": %q, "mac": %q}}`, values, sopsLastModified, mac)
+	}
+
+	password := sopsEncrypt(t, key, "s3cr3t", "str", "password:")
+	token := sopsEncrypt(t, key, "t0k3n", "str", "token:")
+
+	tests := []struct {
+		name     string
+		document string
+		dataKey  gcfg.SOPSDataKeyFunc
+		wantErr  error
+	}{
+		{
+			name:     "data key",
+			document: document(fmt.Sprintf(`"password": %q`, password), sopsMAC(t, key, "s3cr3t")),
+			dataKey:  func(map[string]any) ([]byte, error) { return nil, errNoKey },
+			wantErr:  errNoKey,
+		},
+		{
+			name:     "moved value",
+			document: document(fmt.Sprintf(`"password": %q`, token), sopsMAC(t, key, "t0k3n")),
+			wantErr:  gcfg.ErrSOPSDecryptFailed,
+		},
+		{
+			name:     "unencrypted value",
+			document: document(`"password": "plain"`, sopsMAC(t, key, "plain")),
+			wantErr:  gcfg.ErrSOPSDecryptFailed,
+		},
+		{
+			name:     "removed value",
+			document: document(fmt.Sprintf(`"password": %q`, password), sopsMAC(t, key, "s3cr3t", "t0k3n")),
+			wantErr:  gcfg.ErrSOPSMACVerificationFailed,
+		},
+		{
+			name: "reordered values",
+			document: document(fmt.Sprintf(`"token": %q, "password": %q`, token, password),
+				sopsMAC(t, key, "s3cr3t", "t0k3n")),
+			wantErr: gcfg.ErrSOPSMACVerificationFailed,
+		},
+		{
+			name:     "missing MAC",
+			document: fmt.Sprintf(`{"password": %q, "sops": {"lastmodified": %q}}`, password, sopsLastModified),
+			wantErr:  gcfg.ErrSOPSMACVerificationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dataKey := tt.dataKey
+			if dataKey == nil {
+				dataKey = func(map[string]any) ([]byte, error) { return key, nil }
+			}
+
+			_, err := gcfg.WithSOPS(sopsJSONProvider(tt.document), dataKey).Load()
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWithSOPS_EncryptedRegex(t *testing.T) {
+	t.Parallel()
+
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	document := fmt.Sprintf(`{"host": "db.internal", "password": %q, "port": 5432, "sops": {
+		"lastmodified": %q, "mac": %q, "encrypted_regex": "^password$"
+	}}`,
+		sopsEncrypt(t, key, "s3cr3t", "str", "password:"),
+		sopsLastModified,
+		sopsMAC(t, key, "db.internal", "s3cr3t", "5432"),
+	)
+
+	values, err := gcfg.WithSOPS(sopsJSONProvider(document), func(map[string]any) ([]byte, error) {
+		return key, nil
+	}).Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"host": "db.internal", "password": "s3cr3t", "port": float64(5432)}, values)
+}
+
+func TestWithSOPS_UnorderedProvider(t *testing.T) {
+	t.Parallel()
+
+	p := &mockProvider{name: "sops", data: map[string]any{
+		"password": "ENC[AES256_GCM,data:AA==,iv:AA==,tag:AA==,type:str]",
+		"sops":     map[string]any{},
+	}}
+
+	_, err := gcfg.WithSOPS(p, func(map[string]any) ([]byte, error) {
+		return nil, errors.New("unexpected call")
+	}).Load()
+	require.ErrorIs(t, err, gcfg.ErrSOPSMACVerificationFailed)
+}
+
+func TestWithSOPS_Unencrypted(t *testing.T) {
+	t.Parallel()
+
+	p := &mockProvider{name: "plain", data: map[string]any{"password": "dev"}}
+
+	values, err := gcfg.WithSOPS(p, func(map[string]any) ([]byte, error) {
+		return nil, errors.New("unexpected call")
+	}).Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"password": "dev"}, values)
+}
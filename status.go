@@ -0,0 +1,42 @@
+package gcfg
+
+import (
+	"time"
+)
+
+// ProviderStatus describes the state of a registered provider.
+type ProviderStatus struct {
+	// Name is the name of the provider.
+	Name string
+	// Source is the source of the provider, see SourceProvider.
+	Source string
+	// Priority is the merge priority of the provider, see WithPriority.
+	Priority int
+	// LastLoad is the time of the last successful load, zero if the provider was never loaded.
+	LastLoad time.Time
+	// LastError is the error of the last load, nil if it succeeded.
+	LastError error
+	// ErrorCount is the number of failed loads.
+	ErrorCount int
+}
+
+// ProviderStatuses returns the status of the registered providers in merge order.
+func (c *Config) ProviderStatuses() []ProviderStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	statuses := make([]ProviderStatus, 0, len(c.providers))
+
+	for _, p := range c.providers {
+		statuses = append(statuses, ProviderStatus{
+			Name:       p.Name(),
+			Source:     providerOrigin(p.Provider).Source,
+			Priority:   providerPriority(p.Provider),
+			LastLoad:   p.loadedAt,
+			LastError:  p.lastErr,
+			ErrorCount: p.errCount,
+		})
+	}
+
+	return statuses
+}
@@ -0,0 +1,121 @@
+package gcfg
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/go-playground/validator/v10"
+)
+
+// Validator validates bound structs, see WithValidator.
+type Validator interface {
+	// ValidateStruct validates v, a pointer to a struct.
+	ValidateStruct(v any) error
+}
+
+// noopValidator is the Validator used when validation is disabled with WithValidator(nil).
+type noopValidator struct{}
+
+// ValidateStruct implements Validator.
+func (noopValidator) ValidateStruct(any) error {
+	return nil
+}
+
+// validateValue validates dest, a pointer to a struct, or to a slice, array or map whose
+// elements are validated.
+func (c *Config) validateValue(dest any) error {
+	rv := reflect.ValueOf(dest)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	if c.structValidator != nil {
+		return validateElems(c.structValidator, dest, rv)
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		return c.validate.Struct(dest)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return c.validate.Var(rv.Interface(), "dive")
+	default:
+		return nil
+	}
+}
+
+// validateElems validates dest with v if rv, the value dest points to, is a struct, or each of
+// its struct elements if it is a slice, array or map, joining the errors.
+func validateElems(v Validator, dest any, rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Struct:
+		return v.ValidateStruct(dest)
+	case reflect.Slice, reflect.Array:
+		errs := make([]error, 0, rv.Len())
+		for i := range rv.Len() {
+			errs = append(errs, validateElem(v, rv.Index(i)))
+		}
+
+		return errors.Join(errs...)
+	case reflect.Map:
+		errs := make([]error, 0, rv.Len())
+		keys := rv.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+
+		for _, key := range keys {
+			errs = append(errs, validateElem(v, rv.MapIndex(key)))
+		}
+
+		return errors.Join(errs...)
+	default:
+		return nil
+	}
+}
+
+// validateElem validates elem, a slice, array or map element, if it is a struct or a pointer to one.
+func validateElem(v Validator, elem reflect.Value) error {
+	if elem.Kind() == reflect.Ptr && !elem.IsNil() && elem.Elem().Kind() == reflect.Struct {
+		return v.ValidateStruct(elem.Interface())
+	}
+
+	if elem.Kind() != reflect.Struct {
+		return nil
+	}
+
+	// map elements aren't addressable, so validate a copy
+	ptr := reflect.New(elem.Type())
+	ptr.Elem().Set(elem)
+
+	return v.ValidateStruct(ptr.Interface())
+}
+
+// Validator returns the validator used to validate bound structs (see Bind), so custom validations,
+// aliases and translations can be registered on it:
+//
+//	err := cfg.Validator().RegisterValidation("even", func(fl validator.FieldLevel) bool {
+//		return fl.Field().Int()%2 == 0
+//	})
+//
+// Registrations must happen before binding, as the validator doesn't support concurrent
+// registrations. It isn't used when another Validator is given with WithValidator. See also
+// WithValidatorInstance.
+func (c *Config) Validator() *validator.Validate {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.validate
+}
+
+// FieldKey returns the configuration key a struct field is bound from by default: the name given
+// by its gcfg tag, then its json tag, or its lowercased name. Register it as the tag name function
+// of the validator so validation errors reference configuration keys instead of Go field names:
+//
+//	cfg.Validator().RegisterTagNameFunc(gcfg.FieldKey)
+func FieldKey(field reflect.StructField) string {
+	return maps.FieldKey(field, nil)
+}
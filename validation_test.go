@@ -0,0 +1,98 @@
+package gcfg_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validator(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"server": map[string]any{"http_port": 80, "workers": 3},
+	}}, gcfg.WithoutDefaultEnv())
+	require.NoError(t, cfg.Load())
+
+	require.NoError(t, cfg.Validator().RegisterValidation("even", func(fl validator.FieldLevel) bool {
+		return fl.Field().Int()%2 == 0
+	}))
+	cfg.Validator().RegisterTagNameFunc(gcfg.FieldKey)
+
+	type appConfig struct {
+		Server struct {
+			HTTPPort int `gcfg:"http_port" validate:"min=1024"`
+			Workers  int `validate:"even"`
+		}
+	}
+
+	var dest appConfig
+
+	err := cfg.Bind(&dest)
+
+	var validationErrs validator.ValidationErrors
+
+	require.ErrorAs(t, err, &validationErrs)
+	require.Len(t, validationErrs, 2)
+	assert.Equal(t, "appConfig.server.http_port", validationErrs[0].Namespace())
+	assert.Equal(t, "min", validationErrs[0].Tag())
+	assert.Equal(t, "appConfig.server.workers", validationErrs[1].Namespace())
+	assert.Equal(t, "even", validationErrs[1].Tag())
+}
+
+// portValidator is a Validator rejecting privileged ports.
+type portValidator struct {
+	calls int
+}
+
+var errPrivilegedPort = errors.New("privileged port")
+
+func (v *portValidator) ValidateStruct(value any) error {
+	v.calls++
+
+	if cfg, ok := value.(*portConfig); ok && cfg.Port < 1024 {
+		return errPrivilegedPort
+	}
+
+	return nil
+}
+
+type portConfig struct {
+	Port int `validate:"min=1"`
+}
+
+func TestWithValidator(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"port":    80,
+		"servers": []any{map[string]any{"port": 8080}, map[string]any{"port": 22}},
+	}}
+
+	v := &portValidator{}
+
+	cfg := gcfg.New(provider, gcfg.WithoutDefaultEnv(), gcfg.WithValidator(v))
+	require.NoError(t, cfg.Load())
+
+	var dest portConfig
+	require.ErrorIs(t, cfg.Bind(&dest), errPrivilegedPort)
+
+	var servers []portConfig
+	require.ErrorIs(t, cfg.BindKey("servers", &servers), errPrivilegedPort)
+	assert.Equal(t, 3, v.calls)
+}
+
+func TestWithValidator_Nil(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{"port": 0}},
+		gcfg.WithoutDefaultEnv(), gcfg.WithValidator(nil))
+	require.NoError(t, cfg.Load())
+
+	var dest portConfig
+	require.NoError(t, cfg.Bind(&dest))
+}
@@ -0,0 +1,233 @@
+package gcfg
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	// ErrVaultTransitKeyNotSet indicates that the name of the Vault Transit key is not configured.
+	ErrVaultTransitKeyNotSet = errors.New("vault transit key is not set")
+
+	// ErrVaultTransitDecryptFailed indicates failure to decrypt values with Vault Transit.
+	ErrVaultTransitDecryptFailed = errors.New("failed to decrypt with vault transit")
+)
+
+const (
+	vaultTransitExtensionName = "VaultTransit"
+
+	defaultVaultAddress      = "http://127.0.0.1:8200"
+	defaultVaultTransitMount = "transit"
+)
+
+// vaultCiphertext matches the ciphertexts of Vault Transit, e.g. "vault:v1:...".
+var vaultCiphertext = regexp.MustCompile(`^vault:v[0-9]+:`)
+
+// VaultTransitExtension decrypts the values encrypted with the Transit secrets engine of Vault
+// (e.g. "vault:v1:8SDd3WHDOjf7mq69CyCqYjBXAiQQAVZRkFM13ok481zoCmHnSeDX9vyf7w=="), so ciphertexts can
+// be kept in any provider. The string values are decrypted after every load, in a single batch
+// request, and replace the ciphertexts with Set; the decrypted keys are marked as secret (see
+// WithSecret).
+type VaultTransitExtension struct {
+	address string
+	token   string
+	mount   string
+	key     string
+	client  *http.Client
+}
+
+var _ Extension = (*VaultTransitExtension)(nil)
+
+// VaultTransitOption is a function that configures a VaultTransitExtension.
+type VaultTransitOption func(*VaultTransitExtension)
+
+// WithVaultAddress sets the address of the Vault server.
+//
+// Default: the VAULT_ADDR environment variable, or http://127.0.0.1:8200.
+func WithVaultAddress(address string) VaultTransitOption {
+	return func(e *VaultTransitExtension) {
+		e.address = address
+	}
+}
+
+// WithVaultToken sets the token authenticating the requests to Vault.
+//
+// Default: the VAULT_TOKEN environment variable.
+func WithVaultToken(token string) VaultTransitOption {
+	return func(e *VaultTransitExtension) {
+		e.token = token
+	}
+}
+
+// WithVaultTransitMount sets the path the Transit secrets engine is mounted at.
+//
+// Default: "transit".
+func WithVaultTransitMount(mount string) VaultTransitOption {
+	return func(e *VaultTransitExtension) {
+		e.mount = mount
+	}
+}
+
+// WithVaultTransitKey sets the name of the Transit key the values are encrypted with. Required.
+func WithVaultTransitKey(key string) VaultTransitOption {
+	return func(e *VaultTransitExtension) {
+		e.key = key
+	}
+}
+
+// WithVaultHTTPClient sets the HTTP client of the requests to Vault, e.g. to configure TLS.
+//
+// Default: http.DefaultClient.
+func WithVaultHTTPClient(client *http.Client) VaultTransitOption {
+	return func(e *VaultTransitExtension) {
+		e.client = client
+	}
+}
+
+// NewVaultTransitExtension creates a Vault Transit extension with options, to be registered with
+// Config.WithExtensions.
+func NewVaultTransitExtension(opts ...VaultTransitOption) *VaultTransitExtension {
+	e := &VaultTransitExtension{
+		address: os.Getenv("VAULT_ADDR"),
+		token:   os.Getenv("VAULT_TOKEN"),
+		mount:   defaultVaultTransitMount,
+		client:  http.DefaultClient,
+	}
+
+	if e.address == "" {
+		e.address = defaultVaultAddress
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Name implements the Extension interface.
+func (e *VaultTransitExtension) Name() string {
+	return vaultTransitExtensionName
+}
+
+// PreLoad implements the Extension interface.
+func (e *VaultTransitExtension) PreLoad(context.Context, *Config) error {
+	if e.key == "" {
+		return ErrVaultTransitKeyNotSet
+	}
+
+	return nil
+}
+
+// PostLoad implements the Extension interface, decrypting the ciphertexts of cfg.
+func (e *VaultTransitExtension) PostLoad(ctx context.Context, cfg *Config) error {
+	var keys, ciphertexts []string
+
+	cfg.Walk(func(path string, value any) bool {
+		if str, ok := value.(string); ok && vaultCiphertext.MatchString(str) {
+			keys = append(keys, path)
+			ciphertexts = append(ciphertexts, str)
+		}
+
+		return true
+	})
+
+	if len(ciphertexts) == 0 {
+		return nil
+	}
+
+	plaintexts, err := e.decrypt(ctx, keys, ciphertexts)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrVaultTransitDecryptFailed, err)
+	}
+
+	cfg.WithSecret(keys...)
+
+	for i, key := range keys {
+		cfg.Set(key, plaintexts[i])
+	}
+
+	return nil
+}
+
+// vaultTransitBatchItem is an item of the batch decryption requests and responses of Vault Transit.
+type vaultTransitBatchItem struct {
+	Ciphertext string `json:"ciphertext,omitempty"`
+	Plaintext  string `json:"plaintext,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// decrypt decrypts ciphertexts, the values of keys, with a batch decryption request.
+func (e *VaultTransitExtension) decrypt(ctx context.Context, keys, ciphertexts []string) ([]string, error) {
+	input := make([]vaultTransitBatchItem, len(ciphertexts))
+	for i, ciphertext := range ciphertexts {
+		input[i].Ciphertext = ciphertext
+	}
+
+	body, err := json.Marshal(map[string]any{"batch_input": input})
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := strings.TrimSuffix(e.address, "/") + "/v1/" + strings.Trim(e.mount, "/") + "/decrypt/" +
+		url.PathEscape(e.key)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", e.token)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data struct {
+			BatchResults []vaultTransitBatchItem `json:"batch_results"`
+		} `json:"data"`
+		Errors []string `json:"errors"`
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.Join(result.Errors, "; "))
+	}
+
+	if len(result.Data.BatchResults) != len(ciphertexts) {
+		return nil, fmt.Errorf("got %d results for %d ciphertexts", len(result.Data.BatchResults), len(ciphertexts))
+	}
+
+	plaintexts := make([]string, len(ciphertexts))
+
+	for i, item := range result.Data.BatchResults {
+		if item.Error != "" {
+			return nil, fmt.Errorf("key %s: %s", keys[i], item.Error)
+		}
+
+		plaintext, dErr := base64.StdEncoding.DecodeString(item.Plaintext)
+		if dErr != nil {
+			return nil, fmt.Errorf("key %s: %w", keys[i], dErr)
+		}
+
+		plaintexts[i] = string(plaintext)
+	}
+
+	return plaintexts, nil
+}
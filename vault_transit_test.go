@@ -0,0 +1,144 @@
+package gcfg_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vaultTransitServer returns a fake Vault server decrypting the ciphertexts of plaintexts with the
+// transit key "app".
+func vaultTransitServer(t *testing.T, plaintexts map[string]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/transit/decrypt/app" || r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string]any{"errors": []string{"permission denied"}})
+
+			return
+		}
+
+		var req struct {
+			BatchInput []struct {
+				Ciphertext string `json:"ciphertext"`
+			} `json:"batch_input"`
+		}
+
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+			return
+		}
+
+		results := make([]map[string]string, len(req.BatchInput))
+
+		for i, item := range req.BatchInput {
+			plaintext, ok := plaintexts[item.Ciphertext]
+			if !ok {
+				results[i] = map[string]string{"error": "cipher: message authentication failed"}
+
+				continue
+			}
+
+			results[i] = map[string]string{"plaintext": base64.StdEncoding.EncodeToString([]byte(plaintext))}
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"batch_results": results}})
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestVaultTransitExtension(t *testing.T) {
+	t.Parallel()
+
+	server := vaultTransitServer(t, map[string]string{
+		"vault:v1:cGFzc3dvcmQ=": "s3cr3t",
+		"vault:v2:dG9rZW4=":     "t0k3n",
+	})
+
+	p := &mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{"host": "db.internal", "password": "vault:v1:cGFzc3dvcmQ="},
+		"token":    "vault:v2:dG9rZW4=",
+	}}
+
+	cfg := gcfg.New(p, gcfg.WithoutDefaultEnv()).WithExtensions(gcfg.NewVaultTransitExtension(
+		gcfg.WithVaultAddress(server.URL),
+		gcfg.WithVaultToken("s.token"),
+		gcfg.WithVaultTransitKey("app"),
+	))
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, "s3cr3t", cfg.Get("database.password"))
+	assert.Equal(t, "t0k3n", cfg.Get("token"))
+	assert.Equal(t, "db.internal", cfg.Get("database.host"))
+
+	// the decrypted values are masked
+	assert.NotEqual(t, "s3cr3t", cfg.Values()["database"].(map[string]any)["password"])
+
+	// and decrypted again on reload
+	require.NoError(t, cfg.Load())
+	assert.Equal(t, "s3cr3t", cfg.Get("database.password"))
+}
+
+func TestVaultTransitExtension_Errors(t *testing.T) {
+	t.Parallel()
+
+	server := vaultTransitServer(t, map[string]string{})
+
+	tests := []struct {
+		name    string
+		options []gcfg.VaultTransitOption
+		wantErr error
+		wantMsg string
+	}{
+		{
+			name:    "key not set",
+			options: []gcfg.VaultTransitOption{gcfg.WithVaultAddress(server.URL)},
+			wantErr: gcfg.ErrVaultTransitKeyNotSet,
+		},
+		{
+			name: "permission denied",
+			options: []gcfg.VaultTransitOption{
+				gcfg.WithVaultAddress(server.URL),
+				gcfg.WithVaultTransitKey("app"),
+			},
+			wantErr: gcfg.ErrVaultTransitDecryptFailed,
+			wantMsg: "permission denied",
+		},
+		{
+			name: "invalid ciphertext",
+			options: []gcfg.VaultTransitOption{
+				gcfg.WithVaultAddress(server.URL),
+				gcfg.WithVaultToken("s.token"),
+				gcfg.WithVaultTransitKey("app"),
+			},
+			wantErr: gcfg.ErrVaultTransitDecryptFailed,
+			wantMsg: "key password: cipher: message authentication failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &mockProvider{name: "mock", data: map[string]any{"password": "vault:v1:Zm9v"}}
+
+			cfg := gcfg.New(p, gcfg.WithoutDefaultEnv()).
+				WithExtensions(gcfg.NewVaultTransitExtension(tt.options...))
+
+			err := cfg.Load()
+			require.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantMsg != "" {
+				assert.Contains(t, err.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
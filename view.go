@@ -0,0 +1,152 @@
+package gcfg
+
+import (
+	"reflect"
+
+	"github.com/ahmedkamalio/gcfg/internal/maps"
+	"github.com/ahmedkamalio/gcfg/internal/reflection"
+)
+
+// View is a live view of a configuration section, rooted at a key prefix, see Config.Sub.
+// Keys passed to its methods are relative to the prefix, so a package can receive its own section
+// without knowing the layout of the whole configuration.
+type View struct {
+	config *Config
+	prefix string
+}
+
+// Sub returns a view of the configuration section at prefix (e.g., Sub("database")).
+// The view reflects the current configuration, including later loads and modifications.
+func (c *Config) Sub(prefix string) *View {
+	return &View{config: c, prefix: c.keys.normalize(prefix)}
+}
+
+// Sub returns a view of the section at prefix, relative to the view.
+func (v *View) Sub(prefix string) *View {
+	return &View{config: v.config, prefix: v.key(v.config.keys.normalize(prefix))}
+}
+
+// Prefix returns the dotted key prefix the view is rooted at.
+func (v *View) Prefix() string {
+	return v.prefix
+}
+
+// Config returns the configuration the view belongs to.
+func (v *View) Config() *Config {
+	return v.config
+}
+
+// Get retrieves a configuration value by key, relative to the view prefix.
+func (v *View) Get(key string) any {
+	if key == "" {
+		return nil
+	}
+
+	return v.config.Get(v.key(key))
+}
+
+// Find searches for and retrieves a configuration value by key, relative to the view prefix.
+func (v *View) Find(key string) (value any, exist bool) {
+	if key == "" {
+		return value, exist
+	}
+
+	return v.config.Find(v.key(key))
+}
+
+// IsSet reports whether key, relative to the view prefix, resolves to a value in the configuration.
+func (v *View) IsSet(key string) bool {
+	if key == "" {
+		return false
+	}
+
+	return v.config.IsSet(v.key(key))
+}
+
+// Values returns the values of the section, or an empty map if the section doesn't exist, with
+// the values of keys marked as secret (see Config.WithSecret) masked.
+func (v *View) Values() map[string]any {
+	v.config.mu.RLock()
+	defer v.config.mu.RUnlock()
+
+	return v.config.redactValues(reflection.Clone(v.section()), v.config.keys.normalize(v.prefix), false)
+}
+
+// Bind binds the section to the provided struct, or the value at the prefix of the view to the
+// provided slice or map (e.g., an array to a []ServerConfig). A missing section binds as an empty
+// one to structs, so validation still reports the required fields, and as nil to slices and maps.
+func (v *View) Bind(dest any, options ...BindOption) error {
+	opts := BindOptions{
+		validate: true,
+	}
+
+	for _, opt := range options {
+		opt(&opts)
+	}
+
+	c := v.config
+
+	c.mu.RLock()
+
+	var values any = v.section()
+	if reflect.Indirect(reflect.ValueOf(dest)).Kind() != reflect.Struct {
+		values = v.value()
+	}
+
+	deprecated, err := c.bindValues(v.prefix, values, dest, opts)
+	c.mu.RUnlock()
+
+	c.warnDeprecated(deprecated)
+
+	return err
+}
+
+// OnChange registers fn to be called whenever a reload changes the value of key, relative to the
+// view prefix, or any value nested under it; an empty key watches the whole section.
+// See Config.OnChange.
+func (v *View) OnChange(key string, fn func(oldValue, newValue any)) *View {
+	v.config.OnChange(v.key(key), fn)
+
+	return v
+}
+
+// section returns the values of the section, or an empty map if the section doesn't exist.
+// The caller must hold v.config.mu.
+func (v *View) section() map[string]any {
+	if v.prefix == "" {
+		return v.config.values
+	}
+
+	pathParts, finalKey := v.config.keys.split(v.prefix)
+
+	if finalMap := maps.FindNestedMap(v.config.values, pathParts, false); finalMap != nil {
+		if values, ok := finalMap[finalKey].(map[string]any); ok {
+			return values
+		}
+	}
+
+	return make(map[string]any)
+}
+
+// value returns the value at the prefix of the view, or nil if it doesn't exist.
+// The caller must hold v.config.mu.
+func (v *View) value() any {
+	if v.prefix == "" {
+		return v.config.values
+	}
+
+	return v.config.keys.lookup(v.config.values, v.prefix)
+}
+
+// key returns the absolute key of the given key relative to the view prefix.
+func (v *View) key(key string) string {
+	if key == "" {
+		return v.prefix
+	}
+
+	if v.prefix == "" {
+		return key
+	}
+
+	return v.prefix + v.config.keys.sep() + key
+}
@@ -0,0 +1,107 @@
+package gcfg_test
+
+import (
+	"testing"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Sub(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{
+			"host": "localhost",
+			"pool": map[string]any{"size": 10},
+		},
+	}})
+	require.NoError(t, cfg.Load())
+
+	db := cfg.Sub("Database")
+	assert.Equal(t, "database", db.Prefix())
+	assert.Same(t, cfg, db.Config())
+
+	assert.Equal(t, "localhost", db.Get("host"))
+	assert.Equal(t, 10, db.Get("pool.size"))
+	assert.Equal(t, 10, db.Sub("pool").Get("size"))
+	assert.Nil(t, db.Get(""))
+	assert.True(t, db.IsSet("pool"))
+	assert.False(t, db.IsSet("port"))
+
+	value, ok := db.Find("host")
+	assert.True(t, ok)
+	assert.Equal(t, "localhost", value)
+
+	assert.Equal(t, map[string]any{"host": "localhost", "pool": map[string]any{"size": 10}}, db.Values())
+	assert.Empty(t, cfg.Sub("cache").Values())
+
+	// The view reflects later modifications.
+	cfg.Set("database.host", "db.internal")
+	assert.Equal(t, "db.internal", db.Get("host"))
+
+	values := db.Values()
+	values["host"] = "mutated"
+	assert.Equal(t, "db.internal", db.Get("host"))
+}
+
+func TestView_Bind(t *testing.T) {
+	t.Parallel()
+
+	type databaseConfig struct {
+		Host string `validate:"required"`
+		Pool struct {
+			Size int
+		}
+	}
+
+	cfg := gcfg.New(&mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{
+			"host": "localhost",
+			"pool": map[string]any{"size": 10},
+		},
+	}})
+	require.NoError(t, cfg.Load())
+
+	var db databaseConfig
+
+	require.NoError(t, cfg.Sub("database").Bind(&db))
+	assert.Equal(t, "localhost", db.Host)
+	assert.Equal(t, 10, db.Pool.Size)
+
+	// A missing section binds as an empty one and fails validation.
+	var missing databaseConfig
+
+	require.Error(t, cfg.Sub("cache").Bind(&missing))
+	require.NoError(t, cfg.Sub("cache").Bind(&missing, gcfg.WithValidate(false)))
+}
+
+func TestView_OnChange(t *testing.T) {
+	t.Parallel()
+
+	provider := &mockProvider{name: "mock", data: map[string]any{
+		"database": map[string]any{"host": "localhost"},
+	}}
+
+	cfg := gcfg.New(provider)
+	require.NoError(t, cfg.Load())
+
+	var hostChanges, sectionChanges int
+
+	db := cfg.Sub("database")
+	db.OnChange("host", func(oldValue, newValue any) {
+		hostChanges++
+
+		assert.Equal(t, "localhost", oldValue)
+		assert.Equal(t, "db.internal", newValue)
+	}).OnChange("", func(_, _ any) {
+		sectionChanges++
+	})
+
+	provider.data = map[string]any{"database": map[string]any{"host": "db.internal"}}
+	require.NoError(t, cfg.Load())
+
+	assert.Equal(t, 1, hostChanges)
+	assert.Equal(t, 1, sectionChanges)
+}
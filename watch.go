@@ -0,0 +1,187 @@
+package gcfg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrWatchNotSupported indicates that a provider cannot watch its source.
+	ErrWatchNotSupported = errors.New("watch not supported")
+
+	// ErrNoWatchableProvider indicates that no registered provider can watch its source.
+	ErrNoWatchableProvider = errors.New("no watchable provider registered")
+
+	// ErrProviderWatchFailed indicates failure to start watching a provider.
+	ErrProviderWatchFailed = errors.New("failed to watch provider")
+
+	// ErrInvalidWatchInterval indicates that a provider was given a negative watch interval.
+	ErrInvalidWatchInterval = errors.New("watch interval cannot be negative")
+)
+
+// defaultWatchDebounce is the default window within which watched provider updates are coalesced.
+const defaultWatchDebounce = 100 * time.Millisecond
+
+// providerUpdate holds values emitted by a watched provider.
+type providerUpdate struct {
+	entry  *providerEntry
+	values map[string]any
+}
+
+// Watch starts watching all providers implementing WatchableProvider. Whenever a provider reports
+// new values, the configuration is re-merged and updated, running the same hooks, component
+// notifications and restart-required checks as Load.
+//
+// Updates received within the debounce window (see WithWatchDebounce) are coalesced into a
+// single reload, keeping the latest values of each provider.
+//
+// Watch returns once all watches are started; they are stopped when ctx is done.
+// Errors occurring during background reloads are reported to the handler registered with
+// OnReloadError. Load should be called before Watch so that all providers have been loaded once.
+func (c *Config) Watch(ctx context.Context) error {
+	if err := c.checkFrozen("Watch"); err != nil {
+		return err
+	}
+
+	c.mu.RLock()
+	providers := append([]*providerEntry{}, c.providers...)
+	debounce := c.watchDebounce
+	c.mu.RUnlock()
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	updates := make(chan providerUpdate)
+
+	watching := 0
+
+	for _, p := range providers {
+		wp, ok := p.Provider.(WatchableProvider)
+		if !ok {
+			continue
+		}
+
+		ch, err := wp.Watch(watchCtx)
+		if errors.Is(err, ErrWatchNotSupported) {
+			continue
+		}
+
+		if err != nil {
+			cancel()
+
+			return fmt.Errorf("%w %s: %w", ErrProviderWatchFailed, p.Name(), err)
+		}
+
+		watching++
+
+		go forwardUpdates(watchCtx, p, ch, updates)
+	}
+
+	if watching == 0 {
+		cancel()
+
+		return ErrNoWatchableProvider
+	}
+
+	go c.applyUpdates(watchCtx, updates, debounce)
+
+	// The watches live until ctx is done; cancel is only used to stop them early on failure.
+	go func() {
+		<-watchCtx.Done()
+		cancel()
+	}()
+
+	return nil
+}
+
+// OnReloadError registers a handler for errors occurring during background reloads (e.g., those
+// triggered by Watch), which have no caller to return them to.
+func (c *Config) OnReloadError(handler func(err error)) *Config {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reloadErrorHandler = handler
+
+	return c
+}
+
+// WithWatchDebounce sets the window within which updates from watched providers are coalesced
+// into a single reload, so that bursts of changes result in one consistent update.
+// A window of zero or less applies every update immediately. It applies to subsequent calls to Watch.
+//
+// Default: 100ms.
+func (c *Config) WithWatchDebounce(window time.Duration) *Config {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.watchDebounce = window
+
+	return c
+}
+
+// forwardUpdates forwards the values received from a watched provider to updates until ch is
+// closed or ctx is done.
+func forwardUpdates(
+	ctx context.Context,
+	p *providerEntry,
+	ch <-chan map[string]any,
+	updates chan<- providerUpdate,
+) {
+	for values := range ch {
+		select {
+		case updates <- providerUpdate{entry: p, values: values}:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// applyUpdates reloads the configuration with the updates received from watched providers,
+// coalescing those received within the debounce window, until ctx is done.
+func (c *Config) applyUpdates(ctx context.Context, updates <-chan providerUpdate, debounce time.Duration) {
+	pending := make(map[*providerEntry]map[string]any)
+
+	var flush <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update := <-updates:
+			pending[update.entry] = update.values
+
+			if debounce > 0 {
+				if flush == nil {
+					flush = time.After(debounce)
+				}
+
+				continue
+			}
+		case <-flush:
+		}
+
+		results := pending
+		pending = make(map[*providerEntry]map[string]any)
+		flush = nil
+
+		err := c.reload(ctx, func() (map[*providerEntry]map[string]any, error) {
+			return results, nil
+		})
+		if err != nil {
+			c.reportReloadError(err)
+		}
+	}
+}
+
+// reportReloadError reports an error occurring during a background reload.
+func (c *Config) reportReloadError(err error) {
+	c.log().Warn("gcfg: background reload failed", "error", err)
+
+	c.mu.RLock()
+	handler := c.reloadErrorHandler
+	c.mu.RUnlock()
+
+	if handler != nil {
+		handler(err)
+	}
+}
@@ -0,0 +1,212 @@
+package gcfg_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ahmedkamalio/gcfg"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockWatchableProvider is a mock implementation of the WatchableProvider interface for testing.
+type mockWatchableProvider struct {
+	mockProvider
+
+	updates  chan map[string]any
+	watchErr error
+}
+
+func newMockWatchableProvider(name string, data map[string]any) *mockWatchableProvider {
+	return &mockWatchableProvider{
+		mockProvider: mockProvider{name: name, data: data},
+		updates:      make(chan map[string]any),
+	}
+}
+
+func (m *mockWatchableProvider) Watch(ctx context.Context) (<-chan map[string]any, error) {
+	if m.watchErr != nil {
+		return nil, m.watchErr
+	}
+
+	out := make(chan map[string]any)
+
+	go func() {
+		defer close(out)
+
+		for {
+			select {
+			case values := <-m.updates:
+				select {
+				case out <- values:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func TestConfig_Watch_AppliesUpdates(t *testing.T) {
+	t.Parallel()
+
+	static := &mockProvider{name: "static", data: map[string]any{"static": "value"}}
+	watched := newMockWatchableProvider("watched", map[string]any{"level": "info"})
+
+	cfg := gcfg.New(static, watched)
+
+	require.NoError(t, cfg.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, cfg.Watch(ctx))
+
+	watched.updates <- map[string]any{"level": "debug"}
+
+	assert.Eventually(t, func() bool {
+		return cfg.Get("level") == "debug"
+	}, time.Second, time.Millisecond)
+
+	assert.Equal(t, "value", cfg.Get("static"))
+}
+
+func TestConfig_Watch_ThroughDecorators(t *testing.T) {
+	t.Parallel()
+
+	watched := newMockWatchableProvider("watched", map[string]any{"level": "info"})
+
+	cfg := gcfg.New(gcfg.WithKeyTransform(gcfg.WithPriority(watched, 1), func(key string) string {
+		return "logging." + key
+	}))
+
+	require.NoError(t, cfg.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, cfg.Watch(ctx))
+
+	watched.updates <- map[string]any{"level": "debug"}
+
+	assert.Eventually(t, func() bool {
+		return cfg.Get("logging.level") == "debug"
+	}, time.Second, time.Millisecond)
+}
+
+func TestConfig_Watch_ReportsReloadErrors(t *testing.T) {
+	t.Parallel()
+
+	watched := newMockWatchableProvider("watched", map[string]any{"pool": 1})
+
+	var (
+		mu     sync.Mutex
+		errs   []error
+		noop   = gcfg.ComponentFunc[struct{ Pool int }](func(context.Context, struct{ Pool int }) error { return nil })
+		reject = gcfg.ComponentFunc[struct{ Pool int }](func(_ context.Context, c struct{ Pool int }) error {
+			if c.Pool > 10 {
+				//nolint:err113
+				return errors.New("pool too large")
+			}
+
+			return nil
+		})
+	)
+
+	cfg := gcfg.New(watched).OnReloadError(func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		errs = append(errs, err)
+	})
+
+	require.NoError(t, gcfg.RegisterComponent(cfg, "noop", "", noop))
+	require.NoError(t, gcfg.RegisterComponent(cfg, "pool", "", reject))
+	require.NoError(t, cfg.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, cfg.Watch(ctx))
+
+	watched.updates <- map[string]any{"pool": 100}
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return len(errs) == 1 && errors.Is(errs[0], gcfg.ErrComponentRejected)
+	}, time.Second, time.Millisecond)
+
+	assert.Equal(t, 1, cfg.Get("pool"))
+}
+
+func TestConfig_Watch_Errors(t *testing.T) {
+	t.Parallel()
+
+	cfg := gcfg.New(&mockProvider{name: "static"})
+	require.ErrorIs(t, cfg.Watch(context.Background()), gcfg.ErrNoWatchableProvider)
+
+	// Decorated providers that can't watch are skipped.
+	cfg = gcfg.New(gcfg.WithPriority(&mockProvider{name: "static"}, 1))
+	require.ErrorIs(t, cfg.Watch(context.Background()), gcfg.ErrNoWatchableProvider)
+
+	//nolint:err113
+	failing := newMockWatchableProvider("failing", nil)
+	failing.watchErr = errors.New("watch failed")
+
+	err := gcfg.New(failing).Watch(context.Background())
+	require.ErrorIs(t, err, gcfg.ErrProviderWatchFailed)
+	assert.Contains(t, err.Error(), "watch failed")
+}
+
+func TestConfig_Watch_CoalescesUpdates(t *testing.T) {
+	t.Parallel()
+
+	first := newMockWatchableProvider("first", map[string]any{"a": 0})
+	second := newMockWatchableProvider("second", map[string]any{"b": 0})
+
+	var (
+		mu         sync.Mutex
+		changeSets []gcfg.ChangeSet
+	)
+
+	cfg := gcfg.New(first, second).
+		WithWatchDebounce(200 * time.Millisecond).
+		OnChanges(func(changes gcfg.ChangeSet) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			changeSets = append(changeSets, changes)
+		})
+
+	require.NoError(t, cfg.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, cfg.Watch(ctx))
+
+	first.updates <- map[string]any{"a": 1}
+	second.updates <- map[string]any{"b": 1}
+	first.updates <- map[string]any{"a": 2}
+
+	assert.Eventually(t, func() bool {
+		return cfg.Get("a") == 2 && cfg.Get("b") == 1
+	}, time.Second, time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	require.Len(t, changeSets, 1)
+	assert.Equal(t, []string{"a", "b"}, changeSets[0].Keys())
+	assert.Equal(t, 0, changeSets[0][0].OldValue)
+	assert.Equal(t, 2, changeSets[0][0].NewValue)
+}